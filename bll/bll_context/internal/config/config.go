@@ -1,6 +1,10 @@
 package config
 
-import "github.com/zeromicro/go-zero/zrpc"
+import (
+	"jxzy/common/logger"
+
+	"github.com/zeromicro/go-zero/zrpc"
+)
 
 type Config struct {
 	zrpc.RpcServerConf
@@ -8,6 +12,10 @@ type Config struct {
 	LLMRpc   zrpc.RpcClientConf `json:",optional"`
 	BsLlmRpc zrpc.RpcClientConf `json:",optional"`
 	BsRagRpc zrpc.RpcClientConf `json:",optional"`
+	Redact   logger.RedactConf  `json:",optional"` // 日志脱敏规则
+
+	// ForwardReasoning 是否将推理模型的思考内容转发给客户端，关闭时丢弃只含思考内容的增量
+	ForwardReasoning bool `json:",optional"`
 }
 
 type MysqlConf struct {
@@ -30,13 +30,13 @@ type StreamChatLogic struct {
 }
 
 func NewStreamChatLogic(ctx context.Context, svcCtx *svc.ServiceContext) *StreamChatLogic {
-	// 使用自定义的 ServiceLogger，在日志中显示服务名
+	// 使用自定义的 ServiceLogger，在日志中显示服务名，日志中的用户消息和回复按配置脱敏
 	serviceLogger := logger.NewServiceLogger("bll-context").WithContext(ctx)
 
 	return &StreamChatLogic{
 		ctx:    ctx,
 		svcCtx: svcCtx,
-		Logger: serviceLogger,
+		Logger: logger.WithRedactor(serviceLogger, svcCtx.Redactor),
 	}
 }
 
@@ -98,7 +98,7 @@ func (l *StreamChatLogic) StreamChat(in *contextpb.ChatRequest, stream contextpb
 		}
 
 		responseCount++
-		l.Logger.Infof("Received LLM response %d - Delta: %s, Finished: %v", responseCount, llmResp.Delta, llmResp.Finished)
+		l.Logger.Debugf("Received LLM response %d - Delta: %s, Finished: %v", responseCount, llmResp.Delta, llmResp.Finished)
 
 		// 构建Context层的流式响应
 		contextResp := &contextpb.StreamChatResponse{
@@ -107,6 +107,12 @@ func (l *StreamChatLogic) StreamChat(in *contextpb.ChatRequest, stream contextpb
 			Delta:     llmResp.Delta,
 			Finished:  llmResp.Finished,
 		}
+		if l.svcCtx.Config.ForwardReasoning {
+			contextResp.ReasoningDelta = llmResp.ReasoningDelta
+		} else if llmResp.Delta == "" && !llmResp.Finished && llmResp.ReasoningDelta != "" {
+			// 不转发思考内容时跳过只含思考内容的增量
+			continue
+		}
 
 		// 如果有token使用信息，转换并添加
 		if llmResp.Usage != nil {
@@ -248,6 +254,12 @@ func (l *StreamChatLogic) getRag(in *contextpb.ChatRequest) string {
 		},
 		ExtraParams: make(map[string]string),
 		UserId:      in.UserId,
+		// bs_llm 按 Schema 校验并修复输出，返回的 Completion 保证是合法的 JSON
+		ResponseFormat: &bs_llm.ResponseFormat{
+			Type:   "json_schema",
+			Name:   "key_sentences",
+			Schema: keySentencesSchema,
+		},
 	}
 
 	l.Logger.Debug("Calling LLM for key sentence extraction")
@@ -417,7 +429,17 @@ func (l *StreamChatLogic) parseSessionId(sessionId string) int64 {
 	return id
 }
 
+// keySentencesSchema 关键句提取结果的 JSON Schema
+const keySentencesSchema = `{
+  "type": "object",
+  "properties": {
+    "key_sentences": {"type": "array", "items": {"type": "string"}}
+  },
+  "required": ["key_sentences"]
+}`
+
 // parseKeySentencesFromLLMResponse 解析LLM响应中的关键句列表
+// bs_llm 未启用 Schema 校验（如旧版本或供应商不支持）时输出可能不是合法的 JSON，保留回退解析
 func (l *StreamChatLogic) parseKeySentencesFromLLMResponse(llmResponse string) ([]string, error) {
 	// 尝试解析JSON响应
 	var response struct {
@@ -501,9 +523,9 @@ func (l *StreamChatLogic) searchRAGConcurrently(keySentences []string, userId st
 				QueryText:      sentence,                     // 使用query_text字段，bs_rag会自动生成向量
 				TopK:           3,                            // 返回前3个最相似的结果
 				MinScore:       0.5,                          // 最小相似度阈值
+				MinRerankScore: 0.3,                          // 场景启用重排序时的最小重排序分数
 				CollectionName: consts.DefaultCollectionName, // 集合名称
-				Filters:        make(map[string]string),
-				UserId:         userId,
+				UserId:         userId,                       // bs_rag 只检索该用户的知识
 			}
 
 			// 调用RAG服务
@@ -515,12 +537,14 @@ func (l *StreamChatLogic) searchRAGConcurrently(keySentences []string, userId st
 
 			l.Logger.Infof("RAG search returned %d results for key sentence: %s", len(ragResp.Results), sentence)
 
-			// 处理RAG结果
+			// 处理RAG结果，bs_rag 已按 MinScore 和 MinRerankScore 过滤，重排序后以重排序分数表示相关度
 			var localResults []string
 			for _, result := range ragResp.Results {
-				if result.Score >= 0.5 { // 只使用相似度足够高的结果
-					localResults = append(localResults, fmt.Sprintf("相关内容 (相似度: %.2f): %s", result.Score, result.Content))
+				score := result.Score
+				if ragResp.Reranked {
+					score = result.RerankScore
 				}
+				localResults = append(localResults, fmt.Sprintf("相关内容 (相似度: %.2f): %s", score, result.Content))
 			}
 
 			// 线程安全地添加到全局结果
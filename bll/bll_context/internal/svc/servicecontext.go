@@ -6,6 +6,7 @@ import (
 	"jxzy/bll/bll_context/internal/model"
 	"jxzy/bs/bs_llm/bsllmservice"
 	"jxzy/bs/bs_rag/bsragservice"
+	commonlogger "jxzy/common/logger"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/zeromicro/go-zero/core/logx"
@@ -19,6 +20,7 @@ type ServiceContext struct {
 	ChatSessionQasModel model.ChatSessionQasModel
 	LLMRpc              bsllmservice.BsLlmService
 	RagRpc              bsragservice.BsRagService
+	Redactor            *commonlogger.Redactor // 日志脱敏规则，为空表示不脱敏
 	logger              logx.Logger
 }
 
@@ -75,6 +77,7 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		ChatSessionQasModel: sessionQasModel,
 		LLMRpc:              llmRpc,
 		RagRpc:              ragRpc,
+		Redactor:            commonlogger.MustNewRedactor(c.Redact),
 		logger:              logger,
 	}
 }
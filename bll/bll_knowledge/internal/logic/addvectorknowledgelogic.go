@@ -181,8 +181,10 @@ func (l *AddVectorKnowledgeLogic) processSummariesMode(in *knowledgepb.AddVector
 func (l *AddVectorKnowledgeLogic) processSegmentsToDocuments(segments []string, fileId int64, userId string) []*bs_rag.VectorDocument {
 	var documents []*bs_rag.VectorDocument
 
+	// 存储语义段到数据库
+	storedSegments := make([]string, 0, len(segments))
+	segIds := make([]int64, 0, len(segments))
 	for _, seg := range segments {
-		// 存储语义段到数据库
 		segMd5 := l.md5String(seg)
 		segRec := &model.KnowledgeSegment{
 			KnowledgeFileId: fileId,
@@ -195,11 +197,21 @@ func (l *AddVectorKnowledgeLogic) processSegmentsToDocuments(segments []string,
 			continue
 		}
 		segId, _ := segRes.LastInsertId()
+		storedSegments = append(storedSegments, seg)
+		segIds = append(segIds, segId)
+	}
 
-		// 为语义段生成多个维度的摘要句
-		summaries, err := l.summarizeSegmentWithLLM(seg, userId)
-		if err != nil || len(summaries) == 0 {
-			l.Logger.Errorf("LLM summary failed for segment %d: %v", segId, err)
+	// 批量为语义段生成多个维度的摘要句
+	summariesList, err := l.summarizeSegmentsWithLLM(storedSegments, userId)
+	if err != nil {
+		l.Logger.Errorf("LLM batch summary failed: %v", err)
+		return documents
+	}
+
+	for i, segId := range segIds {
+		summaries := summariesList[i]
+		if len(summaries) == 0 {
+			l.Logger.Errorf("LLM summary failed for segment %d", segId)
 			continue
 		}
 
@@ -307,11 +319,26 @@ func (l *AddVectorKnowledgeLogic) insertVectorsToRAG(fileMd5 string, documents [
 		}, nil
 	}
 
-	l.Logger.Infof("Inserted %d documents to RAG", ragResp.InsertedCount)
+	for _, failed := range ragResp.FailedDocuments {
+		l.Logger.Errorf("Failed to insert document %s to RAG: %s", failed.Id, failed.Error)
+	}
+	if ragResp.InsertedCount == 0 {
+		return &knowledgepb.AddVectorKnowledgeResponse{
+			VectorId: fileMd5,
+			Success:  false,
+			Message:  fmt.Sprintf("插入RAG失败: %s", ragResp.ErrorMessage),
+		}, nil
+	}
+
+	l.Logger.Infof("Inserted %d documents to RAG, failed: %d", ragResp.InsertedCount, len(ragResp.FailedDocuments))
+	message := "知识库添加成功"
+	if len(ragResp.FailedDocuments) > 0 {
+		message = fmt.Sprintf("知识库添加成功，%d 个摘要插入失败", len(ragResp.FailedDocuments))
+	}
 	return &knowledgepb.AddVectorKnowledgeResponse{
 		VectorId: fileMd5,
 		Success:  true,
-		Message:  "知识库添加成功",
+		Message:  message,
 	}, nil
 }
 
@@ -423,25 +450,61 @@ func (l *AddVectorKnowledgeLogic) segmentTextWithLLM(text string, userId string)
 	return cleaned, nil
 }
 
-// summarizeSegmentWithLLM 使用LLM为语义段生成多个维度的摘要句
-func (l *AddVectorKnowledgeLogic) summarizeSegmentWithLLM(segment string, userId string) ([]string, error) {
+// summarizeSegmentsWithLLM 使用LLM批量为语义段生成多个维度的摘要句，结果与segments一一对应，单条失败时对应结果为空
+// 批量流中途出错时保留已收到的摘要，未收到的语义段结果为空
+func (l *AddVectorKnowledgeLogic) summarizeSegmentsWithLLM(segments []string, userId string) ([][]string, error) {
+	results := make([][]string, len(segments))
+	if len(segments) == 0 {
+		return results, nil
+	}
 	if l.svcCtx.LlmRpc == nil {
-		return []string{segment}, nil
+		for i, segment := range segments {
+			results[i] = []string{segment}
+		}
+		return results, nil
 	}
-	req := &bsllm.LLMRequest{
-		SceneCode: "knowledge_segment_summary",
-		UserId:    userId,
-		Messages: []*bsllm.ChatMessage{
-			{Role: "system", Content: "请为以下文本从多个维度生成一句话摘要，每个摘要句简洁、完整且可用于检索。每个摘要句占一行，直接返回摘要句，不要编号。"},
-			{Role: "user", Content: segment},
-		},
+
+	req := &bsllm.BatchLLMRequest{Requests: make([]*bsllm.LLMRequest, 0, len(segments))}
+	for _, segment := range segments {
+		req.Requests = append(req.Requests, &bsllm.LLMRequest{
+			SceneCode: "knowledge_segment_summary",
+			UserId:    userId,
+			Messages: []*bsllm.ChatMessage{
+				{Role: "system", Content: "请为以下文本从多个维度生成一句话摘要，每个摘要句简洁、完整且可用于检索。每个摘要句占一行，直接返回摘要句，不要编号。"},
+				{Role: "user", Content: segment},
+			},
+		})
 	}
-	resp, err := l.svcCtx.LlmRpc.LLM(l.ctx, req)
+	stream, err := l.svcCtx.LlmRpc.BatchLLM(l.ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	// 按行分割，去除空行
-	lines := strings.Split(resp.Completion, "\n")
+
+	for {
+		item, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			l.Logger.Errorf("LLM batch summary stream interrupted, keeping received summaries: %v", err)
+			break
+		}
+		index := int(item.Index)
+		if index < 0 || index >= len(segments) {
+			continue
+		}
+		if item.ErrorCode != 0 || item.Response == nil {
+			l.Logger.Errorf("LLM summary failed for segment index %d: [%d] %s", index, item.ErrorCode, item.ErrorMsg)
+			continue
+		}
+		results[index] = l.parseSummaries(item.Response.Completion, segments[index])
+	}
+	return results, nil
+}
+
+// parseSummaries 按行分割摘要句并去除空行，没有生成任何摘要时返回原始段落作为默认摘要
+func (l *AddVectorKnowledgeLogic) parseSummaries(completion string, segment string) []string {
+	lines := strings.Split(completion, "\n")
 	summaries := make([]string, 0, len(lines))
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
@@ -449,9 +512,8 @@ func (l *AddVectorKnowledgeLogic) summarizeSegmentWithLLM(segment string, userId
 			summaries = append(summaries, line)
 		}
 	}
-	// 如果没有生成任何摘要，返回原始段落作为默认摘要
 	if len(summaries) == 0 {
 		summaries = []string{strings.TrimSpace(segment)}
 	}
-	return summaries, nil
+	return summaries
 }
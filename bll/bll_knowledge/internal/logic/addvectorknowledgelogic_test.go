@@ -0,0 +1,124 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	knowledgepb "jxzy/bll/bll_knowledge/bll_knowledge"
+	"jxzy/bll/bll_knowledge/internal/model"
+	"jxzy/bll/bll_knowledge/internal/svc"
+	"jxzy/bs/bs_llm/bsllmtest"
+	"jxzy/bs/bs_rag/bs_rag"
+	"jxzy/bs/bs_rag/bsragservice"
+
+	"google.golang.org/grpc"
+)
+
+// fakeScriptPath bs_llm fake 供应商的示例应答脚本
+const fakeScriptPath = "../../../../bs/bs_llm/etc/fake.yaml"
+
+type insertResult int64
+
+func (r insertResult) LastInsertId() (int64, error) { return int64(r), nil }
+func (r insertResult) RowsAffected() (int64, error) { return 1, nil }
+
+type fileModel struct {
+	model.KnowledgeFileModel
+}
+
+func (m *fileModel) FindOneByMd5(ctx context.Context, fileMd5 string) (*model.KnowledgeFile, error) {
+	return nil, model.ErrNotFound
+}
+
+func (m *fileModel) Insert(ctx context.Context, data *model.KnowledgeFile) (sql.Result, error) {
+	return insertResult(1), nil
+}
+
+type segmentModel struct {
+	model.KnowledgeSegmentModel
+	segments []*model.KnowledgeSegment
+}
+
+func (m *segmentModel) Insert(ctx context.Context, data *model.KnowledgeSegment) (sql.Result, error) {
+	m.segments = append(m.segments, data)
+	return insertResult(len(m.segments)), nil
+}
+
+type summaryModel struct {
+	model.KnowledgeSummarySentenceModel
+	summaries []*model.KnowledgeSummarySentence
+}
+
+func (m *summaryModel) Insert(ctx context.Context, data *model.KnowledgeSummarySentence) (sql.Result, error) {
+	m.summaries = append(m.summaries, data)
+	return insertResult(len(m.summaries)), nil
+}
+
+type ragService struct {
+	bsragservice.BsRagService
+	req *bs_rag.VectorInsertRequest
+}
+
+func (r *ragService) VectorInsert(ctx context.Context, in *bs_rag.VectorInsertRequest, opts ...grpc.CallOption) (*bs_rag.VectorInsertResponse, error) {
+	r.req = in
+	return &bs_rag.VectorInsertResponse{InsertedCount: int32(len(in.Documents))}, nil
+}
+
+// TestAddVectorKnowledgePipeline 通过进程内使用 fake 供应商的 bs_llm 服务，离线验证文件下载、语义分段、批量摘要到写入 RAG 的完整流程
+func TestAddVectorKnowledgePipeline(t *testing.T) {
+	llm, err := bsllmtest.NewServer(fakeScriptPath)
+	if err != nil {
+		t.Fatalf("Failed to start bs_llm: %v", err)
+	}
+	defer llm.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "第一段测试内容。第二段测试内容。")
+	}))
+	defer server.Close()
+
+	segments := &segmentModel{}
+	summaries := &summaryModel{}
+	rag := &ragService{}
+	svcCtx := &svc.ServiceContext{
+		RagRpc:                        rag,
+		LlmRpc:                        llm.Client(),
+		KnowledgeFileModel:            &fileModel{},
+		KnowledgeSegmentModel:         segments,
+		KnowledgeSummarySentenceModel: summaries,
+	}
+
+	resp, err := NewAddVectorKnowledgeLogic(context.Background(), svcCtx).AddVectorKnowledge(&knowledgepb.AddVectorKnowledgeRequest{
+		SourceType: knowledgepb.KnowledgeSourceType_SOURCE_TYPE_FILE_URL,
+		FileUrl:    server.URL + "/test.txt",
+		UserId:     "u1",
+		SceneCode:  "knowledge",
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("AddVectorKnowledge failed: %+v, %v", resp, err)
+	}
+
+	// 分段调用1次，2个分段的摘要各调用1次
+	if llm.Calls("knowledge_segmentation") != 1 || llm.Calls("knowledge_segment_summary") != 2 {
+		t.Errorf("Expected 1 segmentation and 2 summary calls, got %d and %d",
+			llm.Calls("knowledge_segmentation"), llm.Calls("knowledge_segment_summary"))
+	}
+	// 脚本将内容拆为2段，每段生成2个摘要句
+	if len(segments.segments) != 2 || segments.segments[1].SegmentText != "第二段测试内容。" {
+		t.Errorf("Unexpected segments: %+v", segments.segments)
+	}
+	if len(summaries.summaries) != 4 {
+		t.Fatalf("Expected 4 summaries, got %d", len(summaries.summaries))
+	}
+	if rag.req == nil || len(rag.req.Documents) != 4 || rag.req.SceneCode != "knowledge" {
+		t.Fatalf("Unexpected RAG insert request: %+v", rag.req)
+	}
+	doc := rag.req.Documents[2]
+	if doc.Text != "这是一段测试摘要。" || doc.Metadata["user_id"] != "u1" || doc.Metadata["knowledge_segment_id"] != "2" || doc.Metadata["knowledge_file_id"] != "1" {
+		t.Errorf("Unexpected document: %+v", doc)
+	}
+}
@@ -22,6 +22,10 @@ type BsLlmServiceClient interface {
 	StreamLLM(ctx context.Context, in *LLMRequest, opts ...grpc.CallOption) (BsLlmService_StreamLLMClient, error)
 	// 非流式LLM调用
 	LLM(ctx context.Context, in *LLMRequest, opts ...grpc.CallOption) (*LLMResponse, error)
+	// 批量LLM调用，按供应商限制并发，按请求顺序流式返回每条结果
+	BatchLLM(ctx context.Context, in *BatchLLMRequest, opts ...grpc.CallOption) (BsLlmService_BatchLLMClient, error)
+	// 文本向量化，一次请求可包含多条文本
+	Embed(ctx context.Context, in *EmbedRequest, opts ...grpc.CallOption) (*EmbedResponse, error)
 }
 
 type bsLlmServiceClient struct {
@@ -73,6 +77,47 @@ func (c *bsLlmServiceClient) LLM(ctx context.Context, in *LLMRequest, opts ...gr
 	return out, nil
 }
 
+func (c *bsLlmServiceClient) BatchLLM(ctx context.Context, in *BatchLLMRequest, opts ...grpc.CallOption) (BsLlmService_BatchLLMClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BsLlmService_ServiceDesc.Streams[1], "/bs_llm.BsLlmService/BatchLLM", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &bsLlmServiceBatchLLMClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BsLlmService_BatchLLMClient interface {
+	Recv() (*BatchLLMResponse, error)
+	grpc.ClientStream
+}
+
+type bsLlmServiceBatchLLMClient struct {
+	grpc.ClientStream
+}
+
+func (x *bsLlmServiceBatchLLMClient) Recv() (*BatchLLMResponse, error) {
+	m := new(BatchLLMResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *bsLlmServiceClient) Embed(ctx context.Context, in *EmbedRequest, opts ...grpc.CallOption) (*EmbedResponse, error) {
+	out := new(EmbedResponse)
+	err := c.cc.Invoke(ctx, "/bs_llm.BsLlmService/Embed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BsLlmServiceServer is the server API for BsLlmService service.
 // All implementations must embed UnimplementedBsLlmServiceServer
 // for forward compatibility
@@ -81,6 +126,10 @@ type BsLlmServiceServer interface {
 	StreamLLM(*LLMRequest, BsLlmService_StreamLLMServer) error
 	// 非流式LLM调用
 	LLM(context.Context, *LLMRequest) (*LLMResponse, error)
+	// 批量LLM调用，按供应商限制并发，按请求顺序流式返回每条结果
+	BatchLLM(*BatchLLMRequest, BsLlmService_BatchLLMServer) error
+	// 文本向量化，一次请求可包含多条文本
+	Embed(context.Context, *EmbedRequest) (*EmbedResponse, error)
 	mustEmbedUnimplementedBsLlmServiceServer()
 }
 
@@ -94,6 +143,12 @@ func (UnimplementedBsLlmServiceServer) StreamLLM(*LLMRequest, BsLlmService_Strea
 func (UnimplementedBsLlmServiceServer) LLM(context.Context, *LLMRequest) (*LLMResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LLM not implemented")
 }
+func (UnimplementedBsLlmServiceServer) BatchLLM(*BatchLLMRequest, BsLlmService_BatchLLMServer) error {
+	return status.Errorf(codes.Unimplemented, "method BatchLLM not implemented")
+}
+func (UnimplementedBsLlmServiceServer) Embed(context.Context, *EmbedRequest) (*EmbedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Embed not implemented")
+}
 func (UnimplementedBsLlmServiceServer) mustEmbedUnimplementedBsLlmServiceServer() {}
 
 // UnsafeBsLlmServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -146,6 +201,45 @@ func _BsLlmService_LLM_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BsLlmService_BatchLLM_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchLLMRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BsLlmServiceServer).BatchLLM(m, &bsLlmServiceBatchLLMServer{stream})
+}
+
+type BsLlmService_BatchLLMServer interface {
+	Send(*BatchLLMResponse) error
+	grpc.ServerStream
+}
+
+type bsLlmServiceBatchLLMServer struct {
+	grpc.ServerStream
+}
+
+func (x *bsLlmServiceBatchLLMServer) Send(m *BatchLLMResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BsLlmService_Embed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmbedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BsLlmServiceServer).Embed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bs_llm.BsLlmService/Embed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BsLlmServiceServer).Embed(ctx, req.(*EmbedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BsLlmService_ServiceDesc is the grpc.ServiceDesc for BsLlmService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -157,6 +251,10 @@ var BsLlmService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "LLM",
 			Handler:    _BsLlmService_LLM_Handler,
 		},
+		{
+			MethodName: "Embed",
+			Handler:    _BsLlmService_Embed_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -164,6 +262,327 @@ var BsLlmService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _BsLlmService_StreamLLM_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "BatchLLM",
+			Handler:       _BsLlmService_BatchLLM_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "bsllm.proto",
+}
+
+// BsLlmAdminServiceClient is the client API for BsLlmAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BsLlmAdminServiceClient interface {
+	// 创建场景
+	CreateScene(ctx context.Context, in *CreateSceneRequest, opts ...grpc.CallOption) (*CreateSceneResponse, error)
+	// 更新场景
+	UpdateScene(ctx context.Context, in *UpdateSceneRequest, opts ...grpc.CallOption) (*UpdateSceneResponse, error)
+	// 分页查询场景
+	ListScenes(ctx context.Context, in *ListScenesRequest, opts ...grpc.CallOption) (*ListScenesResponse, error)
+	// 查询场景
+	GetScene(ctx context.Context, in *GetSceneRequest, opts ...grpc.CallOption) (*GetSceneResponse, error)
+	// 软删除场景
+	SoftDeleteScene(ctx context.Context, in *SoftDeleteSceneRequest, opts ...grpc.CallOption) (*SoftDeleteSceneResponse, error)
+	// 查询已注册供应商及其健康状态
+	ListProviders(ctx context.Context, in *ListProvidersRequest, opts ...grpc.CallOption) (*ListProvidersResponse, error)
+	// 按用户、场景、模型、日期聚合调用量和费用
+	GetUsageReport(ctx context.Context, in *GetUsageReportRequest, opts ...grpc.CallOption) (*GetUsageReportResponse, error)
+}
+
+type bsLlmAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBsLlmAdminServiceClient(cc grpc.ClientConnInterface) BsLlmAdminServiceClient {
+	return &bsLlmAdminServiceClient{cc}
+}
+
+func (c *bsLlmAdminServiceClient) CreateScene(ctx context.Context, in *CreateSceneRequest, opts ...grpc.CallOption) (*CreateSceneResponse, error) {
+	out := new(CreateSceneResponse)
+	err := c.cc.Invoke(ctx, "/bs_llm.BsLlmAdminService/CreateScene", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bsLlmAdminServiceClient) UpdateScene(ctx context.Context, in *UpdateSceneRequest, opts ...grpc.CallOption) (*UpdateSceneResponse, error) {
+	out := new(UpdateSceneResponse)
+	err := c.cc.Invoke(ctx, "/bs_llm.BsLlmAdminService/UpdateScene", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bsLlmAdminServiceClient) ListScenes(ctx context.Context, in *ListScenesRequest, opts ...grpc.CallOption) (*ListScenesResponse, error) {
+	out := new(ListScenesResponse)
+	err := c.cc.Invoke(ctx, "/bs_llm.BsLlmAdminService/ListScenes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bsLlmAdminServiceClient) GetScene(ctx context.Context, in *GetSceneRequest, opts ...grpc.CallOption) (*GetSceneResponse, error) {
+	out := new(GetSceneResponse)
+	err := c.cc.Invoke(ctx, "/bs_llm.BsLlmAdminService/GetScene", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bsLlmAdminServiceClient) SoftDeleteScene(ctx context.Context, in *SoftDeleteSceneRequest, opts ...grpc.CallOption) (*SoftDeleteSceneResponse, error) {
+	out := new(SoftDeleteSceneResponse)
+	err := c.cc.Invoke(ctx, "/bs_llm.BsLlmAdminService/SoftDeleteScene", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bsLlmAdminServiceClient) ListProviders(ctx context.Context, in *ListProvidersRequest, opts ...grpc.CallOption) (*ListProvidersResponse, error) {
+	out := new(ListProvidersResponse)
+	err := c.cc.Invoke(ctx, "/bs_llm.BsLlmAdminService/ListProviders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bsLlmAdminServiceClient) GetUsageReport(ctx context.Context, in *GetUsageReportRequest, opts ...grpc.CallOption) (*GetUsageReportResponse, error) {
+	out := new(GetUsageReportResponse)
+	err := c.cc.Invoke(ctx, "/bs_llm.BsLlmAdminService/GetUsageReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BsLlmAdminServiceServer is the server API for BsLlmAdminService service.
+// All implementations must embed UnimplementedBsLlmAdminServiceServer
+// for forward compatibility
+type BsLlmAdminServiceServer interface {
+	// 创建场景
+	CreateScene(context.Context, *CreateSceneRequest) (*CreateSceneResponse, error)
+	// 更新场景
+	UpdateScene(context.Context, *UpdateSceneRequest) (*UpdateSceneResponse, error)
+	// 分页查询场景
+	ListScenes(context.Context, *ListScenesRequest) (*ListScenesResponse, error)
+	// 查询场景
+	GetScene(context.Context, *GetSceneRequest) (*GetSceneResponse, error)
+	// 软删除场景
+	SoftDeleteScene(context.Context, *SoftDeleteSceneRequest) (*SoftDeleteSceneResponse, error)
+	// 查询已注册供应商及其健康状态
+	ListProviders(context.Context, *ListProvidersRequest) (*ListProvidersResponse, error)
+	// 按用户、场景、模型、日期聚合调用量和费用
+	GetUsageReport(context.Context, *GetUsageReportRequest) (*GetUsageReportResponse, error)
+	mustEmbedUnimplementedBsLlmAdminServiceServer()
+}
+
+// UnimplementedBsLlmAdminServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedBsLlmAdminServiceServer struct {
+}
+
+func (UnimplementedBsLlmAdminServiceServer) CreateScene(context.Context, *CreateSceneRequest) (*CreateSceneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateScene not implemented")
+}
+func (UnimplementedBsLlmAdminServiceServer) UpdateScene(context.Context, *UpdateSceneRequest) (*UpdateSceneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateScene not implemented")
+}
+func (UnimplementedBsLlmAdminServiceServer) ListScenes(context.Context, *ListScenesRequest) (*ListScenesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScenes not implemented")
+}
+func (UnimplementedBsLlmAdminServiceServer) GetScene(context.Context, *GetSceneRequest) (*GetSceneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScene not implemented")
+}
+func (UnimplementedBsLlmAdminServiceServer) SoftDeleteScene(context.Context, *SoftDeleteSceneRequest) (*SoftDeleteSceneResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SoftDeleteScene not implemented")
+}
+func (UnimplementedBsLlmAdminServiceServer) ListProviders(context.Context, *ListProvidersRequest) (*ListProvidersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProviders not implemented")
+}
+func (UnimplementedBsLlmAdminServiceServer) GetUsageReport(context.Context, *GetUsageReportRequest) (*GetUsageReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsageReport not implemented")
+}
+func (UnimplementedBsLlmAdminServiceServer) mustEmbedUnimplementedBsLlmAdminServiceServer() {}
+
+// UnsafeBsLlmAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BsLlmAdminServiceServer will
+// result in compilation errors.
+type UnsafeBsLlmAdminServiceServer interface {
+	mustEmbedUnimplementedBsLlmAdminServiceServer()
+}
+
+func RegisterBsLlmAdminServiceServer(s grpc.ServiceRegistrar, srv BsLlmAdminServiceServer) {
+	s.RegisterService(&BsLlmAdminService_ServiceDesc, srv)
+}
+
+func _BsLlmAdminService_CreateScene_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSceneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BsLlmAdminServiceServer).CreateScene(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bs_llm.BsLlmAdminService/CreateScene",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BsLlmAdminServiceServer).CreateScene(ctx, req.(*CreateSceneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BsLlmAdminService_UpdateScene_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSceneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BsLlmAdminServiceServer).UpdateScene(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bs_llm.BsLlmAdminService/UpdateScene",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BsLlmAdminServiceServer).UpdateScene(ctx, req.(*UpdateSceneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BsLlmAdminService_ListScenes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScenesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BsLlmAdminServiceServer).ListScenes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bs_llm.BsLlmAdminService/ListScenes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BsLlmAdminServiceServer).ListScenes(ctx, req.(*ListScenesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BsLlmAdminService_GetScene_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSceneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BsLlmAdminServiceServer).GetScene(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bs_llm.BsLlmAdminService/GetScene",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BsLlmAdminServiceServer).GetScene(ctx, req.(*GetSceneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BsLlmAdminService_SoftDeleteScene_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SoftDeleteSceneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BsLlmAdminServiceServer).SoftDeleteScene(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bs_llm.BsLlmAdminService/SoftDeleteScene",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BsLlmAdminServiceServer).SoftDeleteScene(ctx, req.(*SoftDeleteSceneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BsLlmAdminService_ListProviders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProvidersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BsLlmAdminServiceServer).ListProviders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bs_llm.BsLlmAdminService/ListProviders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BsLlmAdminServiceServer).ListProviders(ctx, req.(*ListProvidersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BsLlmAdminService_GetUsageReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BsLlmAdminServiceServer).GetUsageReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bs_llm.BsLlmAdminService/GetUsageReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BsLlmAdminServiceServer).GetUsageReport(ctx, req.(*GetUsageReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BsLlmAdminService_ServiceDesc is the grpc.ServiceDesc for BsLlmAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BsLlmAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bs_llm.BsLlmAdminService",
+	HandlerType: (*BsLlmAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateScene",
+			Handler:    _BsLlmAdminService_CreateScene_Handler,
+		},
+		{
+			MethodName: "UpdateScene",
+			Handler:    _BsLlmAdminService_UpdateScene_Handler,
+		},
+		{
+			MethodName: "ListScenes",
+			Handler:    _BsLlmAdminService_ListScenes_Handler,
+		},
+		{
+			MethodName: "GetScene",
+			Handler:    _BsLlmAdminService_GetScene_Handler,
+		},
+		{
+			MethodName: "SoftDeleteScene",
+			Handler:    _BsLlmAdminService_SoftDeleteScene_Handler,
+		},
+		{
+			MethodName: "ListProviders",
+			Handler:    _BsLlmAdminService_ListProviders_Handler,
+		},
+		{
+			MethodName: "GetUsageReport",
+			Handler:    _BsLlmAdminService_GetUsageReport_Handler,
+		},
 	},
+	Streams:  []grpc.StreamDesc{},
 	Metadata: "bsllm.proto",
 }
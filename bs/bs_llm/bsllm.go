@@ -2,8 +2,10 @@ package main
 
 import (
 	"flag"
+	"time"
 
 	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/cache"
 	"jxzy/bs/bs_llm/internal/config"
 	"jxzy/bs/bs_llm/internal/server"
 	"jxzy/bs/bs_llm/internal/svc"
@@ -14,6 +16,7 @@ import (
 	"github.com/zeromicro/go-zero/core/service"
 	"github.com/zeromicro/go-zero/zrpc"
 	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -23,7 +26,7 @@ func main() {
 	flag.Parse()
 
 	var c config.Config
-	conf.MustLoad(*configFile, &c)
+	conf.MustLoad(*configFile, &c, conf.UseEnv())
 
 	// 初始化统一日志系统
 	if err := logger.InitUnifiedLogger("bs-llm"); err != nil {
@@ -33,8 +36,33 @@ func main() {
 
 	ctx := svc.NewServiceContext(c)
 
+	// 启动供应商配置热加载
+	ctx.ProviderRegistry.Start(time.Duration(c.ProviderReloadInterval) * time.Second)
+	defer ctx.ProviderRegistry.Stop()
+
+	// 启动模型价格热加载
+	ctx.Pricing.Start(time.Duration(c.PricingReloadInterval) * time.Second)
+	defer ctx.Pricing.Stop()
+
+	// 启动 mysql 响应缓存的过期清理
+	if mysqlCache, ok := ctx.ResponseCache.(*cache.MySQL); ok {
+		mysqlCache.Start(time.Duration(c.ResponseCache.CleanupInterval) * time.Second)
+		defer mysqlCache.Stop()
+	}
+
+	// 启动供应商健康检查
+	ctx.ProviderHealth.Start(time.Duration(c.ProviderHealth.CheckInterval) * time.Second)
+	defer ctx.ProviderHealth.Stop()
+
+	// 使用按供应商健康状态更新的 gRPC 健康检查服务替代 zrpc 默认的健康检查服务
+	healthServer := server.NewHealthServer(ctx)
+	defer healthServer.Shutdown()
+	c.Health = false
+
 	s, err := zrpc.NewServer(c.RpcServerConf, func(grpcServer *grpc.Server) {
 		bs_llm.RegisterBsLlmServiceServer(grpcServer, server.NewBsLlmServiceServer(ctx))
+		bs_llm.RegisterBsLlmAdminServiceServer(grpcServer, server.NewBsLlmAdminServiceServer(ctx))
+		healthpb.RegisterHealthServer(grpcServer, healthServer)
 
 		if c.Mode == service.DevMode || c.Mode == service.TestMode {
 			reflection.Register(grpcServer)
@@ -13,17 +13,49 @@ import (
 )
 
 type (
-	ChatMessage       = bs_llm.ChatMessage
-	LLMRequest        = bs_llm.LLMRequest
-	LLMResponse       = bs_llm.LLMResponse
-	LLMUsage          = bs_llm.LLMUsage
-	StreamLLMResponse = bs_llm.StreamLLMResponse
+	BatchLLMRequest         = bs_llm.BatchLLMRequest
+	BatchLLMResponse        = bs_llm.BatchLLMResponse
+	ChatMessage             = bs_llm.ChatMessage
+	ContentPart             = bs_llm.ContentPart
+	CreateSceneRequest      = bs_llm.CreateSceneRequest
+	CreateSceneResponse     = bs_llm.CreateSceneResponse
+	EmbedRequest            = bs_llm.EmbedRequest
+	EmbedResponse           = bs_llm.EmbedResponse
+	Embedding               = bs_llm.Embedding
+	FunctionCall            = bs_llm.FunctionCall
+	FunctionDefinition      = bs_llm.FunctionDefinition
+	GetSceneRequest         = bs_llm.GetSceneRequest
+	GetSceneResponse        = bs_llm.GetSceneResponse
+	GetUsageReportRequest   = bs_llm.GetUsageReportRequest
+	GetUsageReportResponse  = bs_llm.GetUsageReportResponse
+	LLMRequest              = bs_llm.LLMRequest
+	LLMResponse             = bs_llm.LLMResponse
+	LLMUsage                = bs_llm.LLMUsage
+	ListProvidersRequest    = bs_llm.ListProvidersRequest
+	ListProvidersResponse   = bs_llm.ListProvidersResponse
+	ListScenesRequest       = bs_llm.ListScenesRequest
+	ListScenesResponse      = bs_llm.ListScenesResponse
+	ProviderStatus          = bs_llm.ProviderStatus
+	ResponseFormat          = bs_llm.ResponseFormat
+	Scene                   = bs_llm.Scene
+	SoftDeleteSceneRequest  = bs_llm.SoftDeleteSceneRequest
+	SoftDeleteSceneResponse = bs_llm.SoftDeleteSceneResponse
+	StreamLLMResponse       = bs_llm.StreamLLMResponse
+	Tool                    = bs_llm.Tool
+	ToolCall                = bs_llm.ToolCall
+	UpdateSceneRequest      = bs_llm.UpdateSceneRequest
+	UpdateSceneResponse     = bs_llm.UpdateSceneResponse
+	UsageReportRow          = bs_llm.UsageReportRow
 
 	BsLlmService interface {
 		// 流式LLM调用
 		StreamLLM(ctx context.Context, in *LLMRequest, opts ...grpc.CallOption) (bs_llm.BsLlmService_StreamLLMClient, error)
 		// 非流式LLM调用
 		LLM(ctx context.Context, in *LLMRequest, opts ...grpc.CallOption) (*LLMResponse, error)
+		// 批量LLM调用，按供应商限制并发，按请求顺序流式返回每条结果
+		BatchLLM(ctx context.Context, in *BatchLLMRequest, opts ...grpc.CallOption) (bs_llm.BsLlmService_BatchLLMClient, error)
+		// 文本向量化，一次请求可包含多条文本
+		Embed(ctx context.Context, in *EmbedRequest, opts ...grpc.CallOption) (*EmbedResponse, error)
 	}
 
 	defaultBsLlmService struct {
@@ -48,3 +80,15 @@ func (m *defaultBsLlmService) LLM(ctx context.Context, in *LLMRequest, opts ...g
 	client := bs_llm.NewBsLlmServiceClient(m.cli.Conn())
 	return client.LLM(ctx, in, opts...)
 }
+
+// 批量LLM调用，按供应商限制并发，按请求顺序流式返回每条结果
+func (m *defaultBsLlmService) BatchLLM(ctx context.Context, in *BatchLLMRequest, opts ...grpc.CallOption) (bs_llm.BsLlmService_BatchLLMClient, error) {
+	client := bs_llm.NewBsLlmServiceClient(m.cli.Conn())
+	return client.BatchLLM(ctx, in, opts...)
+}
+
+// 文本向量化，一次请求可包含多条文本
+func (m *defaultBsLlmService) Embed(ctx context.Context, in *EmbedRequest, opts ...grpc.CallOption) (*EmbedResponse, error) {
+	client := bs_llm.NewBsLlmServiceClient(m.cli.Conn())
+	return client.Embed(ctx, in, opts...)
+}
@@ -0,0 +1,122 @@
+// Package bsllmtest 在进程内启动使用 fake 供应商的 bs_llm 服务，供其他服务的集成测试离线调用
+package bsllmtest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"sync"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/bsllmservice"
+	"jxzy/bs/bs_llm/internal/config"
+	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/bs/bs_llm/internal/provider/fake"
+	"jxzy/bs/bs_llm/internal/provider/registry"
+	"jxzy/bs/bs_llm/internal/server"
+	"jxzy/bs/bs_llm/internal/svc"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// ModelCode 场景使用的模型编码
+const ModelCode = "fake-model"
+
+// Server 进程内的 bs_llm 服务，所有场景都路由到按应答脚本返回的 fake 供应商
+type Server struct {
+	listener    *bufconn.Listener
+	grpcServer  *grpc.Server
+	conn        *grpc.ClientConn
+	completions *completionModel
+}
+
+// NewServer 启动 bs_llm 服务，scriptPath 为 fake 供应商的应答脚本路径（如 bs/bs_llm/etc/fake.yaml）
+func NewServer(scriptPath string) (*Server, error) {
+	svcCtx := svc.NewServiceContext(config.Config{
+		Providers: []config.ProviderConf{{
+			ProviderCode: fake.ProviderName,
+			ProviderType: registry.TypeFake,
+			APIEndpoint:  scriptPath,
+		}},
+	})
+	if svcCtx.ProviderManager.GetProvider(fake.ProviderName) == nil {
+		return nil, fmt.Errorf("failed to load fake provider from %s", scriptPath)
+	}
+	completions := &completionModel{calls: make(map[string]int)}
+	svcCtx.LlmSceneModel = &sceneModel{}
+	svcCtx.LlmCompletionModel = completions
+
+	s := &Server{
+		listener:    bufconn.Listen(1024 * 1024),
+		grpcServer:  grpc.NewServer(),
+		completions: completions,
+	}
+	bs_llm.RegisterBsLlmServiceServer(s.grpcServer, server.NewBsLlmServiceServer(svcCtx))
+	go s.grpcServer.Serve(s.listener)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return s.listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		s.grpcServer.Stop()
+		return nil, err
+	}
+	s.conn = conn
+	return s, nil
+}
+
+// Client 返回与 zrpc 客户端相同的 bs_llm 服务客户端
+func (s *Server) Client() bsllmservice.BsLlmService {
+	return bsllmservice.NewBsLlmService(s)
+}
+
+// Conn 实现 zrpc.Client
+func (s *Server) Conn() *grpc.ClientConn {
+	return s.conn
+}
+
+// Calls 按场景统计的调用记录数
+func (s *Server) Calls(sceneCode string) int {
+	s.completions.mu.Lock()
+	defer s.completions.mu.Unlock()
+	return s.completions.calls[sceneCode]
+}
+
+// Close 关闭客户端连接和服务
+func (s *Server) Close() {
+	s.conn.Close()
+	s.grpcServer.Stop()
+}
+
+// sceneModel 任意场景编码都返回使用 fake 供应商的场景
+type sceneModel struct {
+	model.LlmSceneModel
+}
+
+func (m *sceneModel) FindOneBySceneCode(ctx context.Context, sceneCode string) (*model.LlmScene, error) {
+	return &model.LlmScene{
+		SceneCode:    sceneCode,
+		ProviderCode: fake.ProviderName,
+		ModelCode:    ModelCode,
+		EnableStream: 1,
+	}, nil
+}
+
+// completionModel 只统计调用记录，不落库
+type completionModel struct {
+	model.LlmCompletionModel
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (m *completionModel) Insert(ctx context.Context, data *model.LlmCompletion) (sql.Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls[data.SceneCode]++
+	return nil, nil
+}
@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"jxzy/bs/bs_llm/internal/provider"
+)
+
+// 缓存后端类型
+const (
+	BackendMemory = "memory"
+	BackendMySQL  = "mysql"
+)
+
+// Entry 缓存的非流式响应
+type Entry struct {
+	Content          string               `json:"content"`
+	FinishReason     string               `json:"finish_reason"`
+	ToolCalls        []*provider.ToolCall `json:"tool_calls,omitempty"`
+	ProviderCode     string               `json:"provider_code"`
+	ModelCode        string               `json:"model_code"`
+	PromptTokens     int64                `json:"prompt_tokens"`
+	CompletionTokens int64                `json:"completion_tokens"`
+	TotalTokens      int64                `json:"total_tokens"`
+	ReasoningContent string               `json:"reasoning_content,omitempty"`
+	ReasoningTokens  int64                `json:"reasoning_tokens,omitempty"`
+}
+
+// Cache 响应缓存
+type Cache interface {
+	// Get 获取未过期的缓存，未命中时返回 nil
+	Get(ctx context.Context, key string) (*Entry, error)
+	// Set 写入缓存，ttl 后过期
+	Set(ctx context.Context, key, sceneCode string, entry *Entry, ttl time.Duration) error
+}
+
+// keyFields 参与缓存键计算的请求字段
+type keyFields struct {
+	SceneCode   string                   `json:"scene_code"`
+	ModelCode   string                   `json:"model_code"`
+	Temperature float64                  `json:"temperature"`
+	MaxTokens   int64                    `json:"max_tokens"`
+	Messages    []*provider.ChatMessage  `json:"messages"`
+	Tools       []*provider.Tool         `json:"tools,omitempty"`
+	ToolChoice  string                   `json:"tool_choice,omitempty"`
+	ExtraParams map[string]string        `json:"extra_params,omitempty"`
+	Format      *provider.ResponseFormat `json:"response_format,omitempty"`
+}
+
+// Key 计算缓存键：场景、模型、温度及消息等请求内容的 SHA-256
+// 工具定义、额外参数和输出格式会影响响应，一并计入
+func Key(sceneCode string, req *provider.LLMRequest) string {
+	data, _ := json.Marshal(&keyFields{
+		SceneCode:   sceneCode,
+		ModelCode:   req.ModelCode,
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+		Messages:    req.Messages,
+		Tools:       req.Tools,
+		ToolChoice:  req.ToolChoice,
+		ExtraParams: req.ExtraParams,
+		Format:      req.ResponseFormat,
+	})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"jxzy/bs/bs_llm/internal/provider"
+)
+
+func TestKey(t *testing.T) {
+	req := &provider.LLMRequest{
+		Messages:    []*provider.ChatMessage{{Role: "user", Content: "hi"}},
+		ModelCode:   "qwen-plus",
+		Temperature: 0,
+		ExtraParams: map[string]string{"b": "2", "a": "1"},
+	}
+	key := Key("rag-sentence-extraction", req)
+	if len(key) != 64 {
+		t.Fatalf("Expected sha256 hex key, got '%s'", key)
+	}
+
+	same := *req
+	same.ExtraParams = map[string]string{"a": "1", "b": "2"}
+	same.Config = &provider.ProviderConfig{APIKey: "ignored"}
+	if Key("rag-sentence-extraction", &same) != key {
+		t.Error("Expected key to ignore map order and provider config")
+	}
+
+	changed := *req
+	changed.Messages = []*provider.ChatMessage{{Role: "user", Content: "hello"}}
+	if Key("rag-sentence-extraction", &changed) == key {
+		t.Error("Expected different messages to produce a different key")
+	}
+	if Key("knowledge_segmentation", req) == key {
+		t.Error("Expected different scene to produce a different key")
+	}
+}
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	c := NewLRU(2)
+	c.now = func() time.Time { return now }
+
+	c.Set(ctx, "a", "s", &Entry{Content: "A"}, time.Minute)
+	c.Set(ctx, "b", "s", &Entry{Content: "B"}, time.Minute)
+	if entry, _ := c.Get(ctx, "a"); entry == nil || entry.Content != "A" {
+		t.Fatalf("Expected hit for 'a', got %+v", entry)
+	}
+
+	// 'b' 最久未使用，写入 'c' 时被淘汰
+	c.Set(ctx, "c", "s", &Entry{Content: "C"}, time.Minute)
+	if entry, _ := c.Get(ctx, "b"); entry != nil {
+		t.Errorf("Expected 'b' to be evicted, got %+v", entry)
+	}
+	if c.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", c.Len())
+	}
+
+	// 过期后未命中
+	now = now.Add(time.Minute)
+	if entry, _ := c.Get(ctx, "a"); entry != nil {
+		t.Errorf("Expected 'a' to expire, got %+v", entry)
+	}
+}
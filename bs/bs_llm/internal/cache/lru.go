@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// LRU 进程内的 LRU 缓存，超出容量时淘汰最久未使用的条目
+type LRU struct {
+	capacity int
+	now      func() time.Time
+
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type lruItem struct {
+	key       string
+	entry     *Entry
+	expiresAt time.Time
+}
+
+// NewLRU 创建容量为 capacity 的 LRU 缓存
+func NewLRU(capacity int) *LRU {
+	if capacity <= 0 {
+		capacity = 1
+	}
+	return &LRU{
+		capacity: capacity,
+		now:      time.Now,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *LRU) Get(ctx context.Context, key string) (*Entry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, nil
+	}
+	item := elem.Value.(*lruItem)
+	if !c.now().Before(item.expiresAt) {
+		c.remove(elem)
+		return nil, nil
+	}
+	c.ll.MoveToFront(elem)
+	return item.entry, nil
+}
+
+func (c *LRU) Set(ctx context.Context, key, sceneCode string, entry *Entry, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(ttl)
+	if elem, ok := c.items[key]; ok {
+		item := elem.Value.(*lruItem)
+		item.entry = entry
+		item.expiresAt = expiresAt
+		c.ll.MoveToFront(elem)
+		return nil
+	}
+
+	c.items[key] = c.ll.PushFront(&lruItem{key: key, entry: entry, expiresAt: expiresAt})
+	for c.ll.Len() > c.capacity {
+		c.remove(c.ll.Back())
+	}
+	return nil
+}
+
+// Len 当前缓存条目数（含已过期但未淘汰的条目）
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+func (c *LRU) remove(elem *list.Element) {
+	c.ll.Remove(elem)
+	delete(c.items, elem.Value.(*lruItem).key)
+}
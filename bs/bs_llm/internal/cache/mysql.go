@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"jxzy/bs/bs_llm/internal/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// MySQL 基于 llm_response_cache 表的缓存，多实例共享
+type MySQL struct {
+	model  model.LlmResponseCacheModel
+	now    func() time.Time
+	logger logx.Logger
+
+	mu     sync.Mutex
+	stopCh chan struct{}
+}
+
+// NewMySQL 创建基于 llm_response_cache 表的缓存
+func NewMySQL(cacheModel model.LlmResponseCacheModel) *MySQL {
+	return &MySQL{
+		model:  cacheModel,
+		now:    time.Now,
+		logger: logx.WithContext(context.Background()),
+	}
+}
+
+func (c *MySQL) Get(ctx context.Context, key string) (*Entry, error) {
+	row, err := c.model.FindOneByCacheKey(ctx, key)
+	if err == model.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query llm_response_cache: %w", err)
+	}
+	if !c.now().Before(row.ExpiresAt) {
+		return nil, nil
+	}
+
+	var entry Entry
+	if err := json.Unmarshal([]byte(row.Response), &entry); err != nil {
+		return nil, fmt.Errorf("invalid cached response: %w", err)
+	}
+	return &entry, nil
+}
+
+func (c *MySQL) Set(ctx context.Context, key, sceneCode string, entry *Entry, ttl time.Duration) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cached response: %w", err)
+	}
+	return c.model.Upsert(ctx, &model.LlmResponseCache{
+		CacheKey:  key,
+		SceneCode: sceneCode,
+		Response:  string(data),
+		ExpiresAt: c.now().Add(ttl),
+	})
+}
+
+// Cleanup 删除已过期的缓存
+func (c *MySQL) Cleanup(ctx context.Context) (int64, error) {
+	return c.model.DeleteExpired(ctx, c.now())
+}
+
+// Start 启动定期清理过期缓存
+func (c *MySQL) Start(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	if c.stopCh != nil {
+		c.mu.Unlock()
+		return
+	}
+	stopCh := make(chan struct{})
+	c.stopCh = stopCh
+	c.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				if n, err := c.Cleanup(ctx); err != nil {
+					c.logger.Errorf("Failed to cleanup expired response cache: %v", err)
+				} else if n > 0 {
+					c.logger.Infof("Cleaned up %d expired response cache entries", n)
+				}
+				cancel()
+			case <-stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止定期清理
+func (c *MySQL) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopCh != nil {
+		close(c.stopCh)
+		c.stopCh = nil
+	}
+}
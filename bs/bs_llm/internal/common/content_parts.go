@@ -0,0 +1,101 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/provider"
+)
+
+// ValidateContentParts 校验消息中的多模态内容片段
+func ValidateContentParts(messages []*bs_llm.ChatMessage) error {
+	for i, msg := range messages {
+		for j, part := range msg.Parts {
+			switch part.Type {
+			case provider.ContentPartText:
+				continue
+			case provider.ContentPartImage, provider.ContentPartAudio:
+			default:
+				return fmt.Errorf("messages[%d].parts[%d]: unsupported type '%s'", i, j, part.Type)
+			}
+			if (part.Url == "") == (len(part.Data) == 0) {
+				return fmt.Errorf("messages[%d].parts[%d]: exactly one of url and data is required", i, j)
+			}
+			if len(part.Data) > 0 && !strings.HasPrefix(part.MimeType, part.Type+"/") {
+				return fmt.Errorf("messages[%d].parts[%d]: mime_type must be %s/*, got '%s'", i, j, part.Type, part.MimeType)
+			}
+		}
+	}
+	return nil
+}
+
+// convertContentParts 转换消息的内容片段，content 作为第一个文本片段
+// 返回全部文本片段的拼接及片段列表，仅含文本时片段列表为空
+func convertContentParts(msg *bs_llm.ChatMessage) (string, []*provider.ContentPart) {
+	if len(msg.Parts) == 0 {
+		return msg.Content, nil
+	}
+
+	var texts []string
+	var parts []*provider.ContentPart
+	if msg.Content != "" {
+		texts = append(texts, msg.Content)
+		parts = append(parts, &provider.ContentPart{Type: provider.ContentPartText, Text: msg.Content})
+	}
+	hasMedia := false
+	for _, part := range msg.Parts {
+		if part.Type == provider.ContentPartText {
+			texts = append(texts, part.Text)
+		} else {
+			hasMedia = true
+		}
+		parts = append(parts, &provider.ContentPart{
+			Type:     part.Type,
+			Text:     part.Text,
+			URL:      part.Url,
+			Data:     part.Data,
+			MimeType: part.MimeType,
+		})
+	}
+	if !hasMedia {
+		parts = nil
+	}
+	return strings.Join(texts, "\n"), parts
+}
+
+// summarizeContent 问答记录中的消息内容，图片、音频只记录摘要，不保存原始数据
+func summarizeContent(msg *bs_llm.ChatMessage) string {
+	if len(msg.Parts) == 0 {
+		return msg.Content
+	}
+
+	var texts []string
+	if msg.Content != "" {
+		texts = append(texts, msg.Content)
+	}
+	for _, part := range msg.Parts {
+		if part.Type == provider.ContentPartText {
+			texts = append(texts, part.Text)
+			continue
+		}
+		texts = append(texts, summarizeMedia(part))
+	}
+	return strings.Join(texts, "\n")
+}
+
+// summarizeMedia 图片、音频片段的摘要：URL 去掉可能含签名的查询参数，原始数据记录类型、大小和哈希
+func summarizeMedia(part *bs_llm.ContentPart) string {
+	if part.Url != "" {
+		if u, err := url.Parse(part.Url); err == nil && u.Scheme != "data" {
+			u.RawQuery, u.Fragment = "", ""
+			return fmt.Sprintf("[%s url=%s]", part.Type, u.String())
+		}
+		return fmt.Sprintf("[%s url=(%d chars)]", part.Type, len(part.Url))
+	}
+	sum := sha256.Sum256(part.Data)
+	return fmt.Sprintf("[%s %s %d bytes sha256=%s]", part.Type, part.MimeType, len(part.Data), hex.EncodeToString(sum[:8]))
+}
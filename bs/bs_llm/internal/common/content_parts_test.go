@@ -0,0 +1,76 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/provider"
+)
+
+func newImageMessage() *bs_llm.ChatMessage {
+	return &bs_llm.ChatMessage{
+		Role:    "user",
+		Content: "这张图里有什么",
+		Parts: []*bs_llm.ContentPart{
+			{Type: "image", Data: []byte("raw-image-bytes"), MimeType: "image/png"},
+			{Type: "image", Url: "https://oss.example.com/a.jpg?Signature=secret"},
+			{Type: "text", Text: "请简要回答"},
+		},
+	}
+}
+
+func TestConvertContentParts(t *testing.T) {
+	messages := ConvertToProviderMessages([]*bs_llm.ChatMessage{
+		{Role: "system", Content: "你是助手", Parts: []*bs_llm.ContentPart{{Type: "text", Text: "用中文回答"}}},
+		newImageMessage(),
+	})
+
+	if messages[0].Parts != nil || messages[0].Content != "你是助手\n用中文回答" {
+		t.Errorf("Expected text-only parts to be merged into content, got %+v", messages[0])
+	}
+
+	msg := messages[1]
+	if !msg.HasMedia() || len(msg.Parts) != 4 {
+		t.Fatalf("Expected content plus 3 parts, got %+v", msg.Parts)
+	}
+	if msg.Parts[0].Type != provider.ContentPartText || msg.Parts[0].Text != "这张图里有什么" {
+		t.Errorf("Expected content as the first text part, got %+v", msg.Parts[0])
+	}
+	if msg.Content != "这张图里有什么\n请简要回答" {
+		t.Errorf("Expected text parts joined in content, got %q", msg.Content)
+	}
+}
+
+func TestValidateContentParts(t *testing.T) {
+	if err := ValidateContentParts([]*bs_llm.ChatMessage{newImageMessage()}); err != nil {
+		t.Fatalf("Expected valid parts, got %v", err)
+	}
+
+	cases := []*bs_llm.ContentPart{
+		{Type: "video", Url: "https://example.com/a.mp4"},
+		{Type: "image"},
+		{Type: "image", Url: "https://example.com/a.png", Data: []byte("x"), MimeType: "image/png"},
+		{Type: "audio", Data: []byte("x"), MimeType: "image/png"},
+	}
+	for _, part := range cases {
+		msg := &bs_llm.ChatMessage{Role: "user", Parts: []*bs_llm.ContentPart{part}}
+		if err := ValidateContentParts([]*bs_llm.ChatMessage{msg}); err == nil {
+			t.Errorf("Expected error for %+v", part)
+		}
+	}
+}
+
+func TestBuildPromptTextRedactsMedia(t *testing.T) {
+	c := newTestCommon(t, nil)
+	prompt := c.BuildPromptText([]*bs_llm.ChatMessage{newImageMessage()})
+
+	if strings.Contains(prompt, "raw-image-bytes") || strings.Contains(prompt, "Signature") {
+		t.Errorf("Expected binary data and URL query to be redacted, got %q", prompt)
+	}
+	for _, want := range []string{"这张图里有什么", "[image image/png 15 bytes sha256=", "[image url=https://oss.example.com/a.jpg]", "请简要回答"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Expected prompt to contain %q, got %q", want, prompt)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/bs/bs_llm/internal/provider"
+)
+
+// 重试退避参数
+var (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 5 * time.Second
+)
+
+// LLMCandidate 场景可用的供应商/模型组合，主配置在前，降级链按顺序在后
+type LLMCandidate struct {
+	ProviderCode string `json:"provider_code"`
+	ModelCode    string `json:"model_code"`
+}
+
+// AttemptFunc 对单个候选发起一次调用
+type AttemptFunc func(ctx context.Context, candidate *LLMCandidate, llmProvider provider.Provider, config *provider.ProviderConfig) error
+
+// GetCandidates 获取场景的候选供应商列表：主供应商 + fallback_providers
+func (c *LLMCommon) GetCandidates(sceneConfig *model.LlmScene) ([]*LLMCandidate, error) {
+	candidates := []*LLMCandidate{{
+		ProviderCode: sceneConfig.ProviderCode,
+		ModelCode:    sceneConfig.ModelCode,
+	}}
+
+	if !sceneConfig.FallbackProviders.Valid || strings.TrimSpace(sceneConfig.FallbackProviders.String) == "" {
+		return candidates, nil
+	}
+
+	var fallbacks []*LLMCandidate
+	if err := json.Unmarshal([]byte(sceneConfig.FallbackProviders.String), &fallbacks); err != nil {
+		return nil, fmt.Errorf("invalid fallback_providers for scene %s: %w", sceneConfig.SceneCode, err)
+	}
+	for _, fallback := range fallbacks {
+		if fallback.ProviderCode == "" || fallback.ModelCode == "" {
+			return nil, fmt.Errorf("invalid fallback_providers for scene %s: provider_code and model_code are required", sceneConfig.SceneCode)
+		}
+		candidates = append(candidates, fallback)
+	}
+	return candidates, nil
+}
+
+// ExecuteWithFallback 按顺序尝试候选供应商
+// 同一候选遇到可重试错误（429、5xx、超时）时按供应商配置的 RetryCount 指数退避重试，
+// 重试耗尽或遇到不可重试错误时切换到下一个候选。处于熔断状态的供应商直接跳过，不等待超时。
+// 返回成功的候选及尝试序号（从1开始，含重试和降级）；全部失败时返回最后一次尝试的序号及汇总的错误。
+func (c *LLMCommon) ExecuteWithFallback(candidates []*LLMCandidate, fn AttemptFunc) (*LLMCandidate, int, error) {
+	attempt := 0
+	var failures []string
+
+	for _, candidate := range candidates {
+		llmProvider := c.providerManager.GetProvider(candidate.ProviderCode)
+		if llmProvider == nil {
+			attempt++
+			c.logger.Errorf("Attempt %d skipped - provider %s not supported", attempt, candidate.ProviderCode)
+			failures = append(failures, fmt.Sprintf("attempt %d (%s/%s): provider %s not supported",
+				attempt, candidate.ProviderCode, candidate.ModelCode, candidate.ProviderCode))
+			continue
+		}
+		config := c.GetProviderConfig(candidate.ProviderCode)
+		providerHealth := c.svcCtx.ProviderHealth
+
+		for retry := 0; ; retry++ {
+			attempt++
+			if err := providerHealth.Allow(candidate.ProviderCode); err != nil {
+				c.logger.Errorf("Attempt %d skipped - provider %s: %v", attempt, candidate.ProviderCode, err)
+				failures = append(failures, fmt.Sprintf("attempt %d (%s/%s): provider %s: %v",
+					attempt, candidate.ProviderCode, candidate.ModelCode, candidate.ProviderCode, err))
+				break
+			}
+			c.logger.Infof("Attempt %d - Provider: %s, Model: %s, Retry: %d",
+				attempt, candidate.ProviderCode, candidate.ModelCode, retry)
+
+			err := fn(c.ctx, candidate, llmProvider, config)
+			providerHealth.Record(candidate.ProviderCode, err)
+			if err == nil {
+				return candidate, attempt, nil
+			}
+
+			c.logger.Errorf("Attempt %d failed - Provider: %s, Model: %s, Error: %v",
+				attempt, candidate.ProviderCode, candidate.ModelCode, err)
+			failures = append(failures, fmt.Sprintf("attempt %d (%s/%s): %v",
+				attempt, candidate.ProviderCode, candidate.ModelCode, err))
+
+			// 调用方已取消，不再重试或降级
+			if c.ctx.Err() != nil {
+				return candidate, attempt, fmt.Errorf("%s", strings.Join(failures, "; "))
+			}
+
+			if !provider.IsRetryable(err) || retry >= int(config.RetryCount) {
+				break
+			}
+
+			if !c.sleep(backoff(retry)) {
+				return candidate, attempt, fmt.Errorf("%s", strings.Join(failures, "; "))
+			}
+		}
+	}
+
+	var last *LLMCandidate
+	if len(candidates) > 0 {
+		last = candidates[len(candidates)-1]
+	}
+	return last, attempt, fmt.Errorf("%s", strings.Join(failures, "; "))
+}
+
+// sleep 等待指定时间，上下文取消时返回false
+func (c *LLMCommon) sleep(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-c.ctx.Done():
+		return false
+	}
+}
+
+// backoff 计算第 retry 次重试前的等待时间（指数退避 + 随机抖动）
+func backoff(retry int) time.Duration {
+	delay := retryBaseDelay << uint(retry)
+	if delay <= 0 || delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// OpenStream 建立流式调用并预读到第一个有效响应
+// 第一个增量发送给客户端之前的失败（包括建立连接和读取首个响应）都会返回错误，以便重试或降级
+func OpenStream(ctx context.Context, llmProvider provider.Provider, req *provider.LLMRequest) (provider.StreamReader, error) {
+	reader, err := llmProvider.StreamLLM(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		response, err := reader.Read()
+		if err != nil {
+			reader.Close()
+			return nil, err
+		}
+		if response.Delta() != "" || response.ReasoningDelta() != "" || len(response.ToolCalls()) > 0 || response.Finished() {
+			return &prefetchedStreamReader{StreamReader: reader, first: response}, nil
+		}
+	}
+}
+
+// prefetchedStreamReader 先返回预读的第一个响应，之后委托给原读取器
+type prefetchedStreamReader struct {
+	provider.StreamReader
+	first provider.StreamResponse
+}
+
+func (r *prefetchedStreamReader) Read() (provider.StreamResponse, error) {
+	if r.first != nil {
+		first := r.first
+		r.first = nil
+		return first, nil
+	}
+	return r.StreamReader.Read()
+}
@@ -0,0 +1,181 @@
+package common
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"jxzy/bs/bs_llm/internal/config"
+	"jxzy/bs/bs_llm/internal/health"
+	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/bs/bs_llm/internal/provider"
+	"jxzy/bs/bs_llm/internal/svc"
+)
+
+// stubProvider 按顺序返回预设错误的测试供应商
+type stubProvider struct {
+	errs  []error
+	calls int
+}
+
+func (p *stubProvider) Name() string { return "stub" }
+
+func (p *stubProvider) CallLLM(ctx context.Context, req *provider.LLMRequest) (*provider.LLMResponse, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	return &provider.LLMResponse{Content: "ok", ModelCode: req.ModelCode}, nil
+}
+
+func (p *stubProvider) StreamLLM(ctx context.Context, req *provider.LLMRequest) (provider.StreamReader, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	return &stubStreamReader{responses: []provider.StreamResponse{
+		provider.NewStreamResponse("", false, nil, "", nil),
+		provider.NewStreamResponse("hi", false, nil, "", nil),
+		provider.NewStreamResponse("", true, nil, "stop", nil),
+	}}, nil
+}
+
+func (p *stubProvider) HealthCheck(ctx context.Context) error { return nil }
+
+func (p *stubProvider) next() error {
+	p.calls++
+	if len(p.errs) == 0 {
+		return nil
+	}
+	err := p.errs[0]
+	p.errs = p.errs[1:]
+	return err
+}
+
+type stubStreamReader struct {
+	responses []provider.StreamResponse
+}
+
+func (r *stubStreamReader) Read() (provider.StreamResponse, error) {
+	if len(r.responses) == 0 {
+		return nil, io.EOF
+	}
+	resp := r.responses[0]
+	r.responses = r.responses[1:]
+	return resp, nil
+}
+
+func (r *stubStreamReader) Close() error { return nil }
+
+func newTestCommon(t *testing.T, providers map[string]*stubProvider) *LLMCommon {
+	retryBaseDelay = time.Millisecond
+	svcCtx := svc.NewServiceContext(config.Config{})
+	for name, p := range providers {
+		svcCtx.ProviderManager.RegisterWithConfig(name, p, &provider.ProviderConfig{RetryCount: 2})
+	}
+	return NewLLMCommon(context.Background(), svcCtx)
+}
+
+func TestGetCandidates(t *testing.T) {
+	c := newTestCommon(t, nil)
+	candidates, err := c.GetCandidates(&model.LlmScene{
+		SceneCode:         "test",
+		ProviderCode:      "doubao",
+		ModelCode:         "doubao-pro",
+		FallbackProviders: sql.NullString{String: `[{"provider_code":"bailian","model_code":"qwen-plus"}]`, Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("GetCandidates failed: %v", err)
+	}
+	if len(candidates) != 2 || candidates[1].ProviderCode != "bailian" || candidates[1].ModelCode != "qwen-plus" {
+		t.Errorf("Unexpected candidates: %+v", candidates)
+	}
+
+	_, err = c.GetCandidates(&model.LlmScene{
+		SceneCode:         "test",
+		FallbackProviders: sql.NullString{String: `[{"provider_code":"bailian"}]`, Valid: true},
+	})
+	if err == nil {
+		t.Error("Expected error for fallback without model_code")
+	}
+}
+
+func TestExecuteWithFallbackRetry(t *testing.T) {
+	primary := &stubProvider{errs: []error{
+		&provider.StatusError{StatusCode: http.StatusTooManyRequests},
+		&provider.StatusError{StatusCode: http.StatusBadGateway},
+	}}
+	c := newTestCommon(t, map[string]*stubProvider{"primary": primary})
+
+	candidates := []*LLMCandidate{{ProviderCode: "primary", ModelCode: "m1"}}
+	candidate, attempt, err := c.ExecuteWithFallback(candidates, func(ctx context.Context, candidate *LLMCandidate, llmProvider provider.Provider, config *provider.ProviderConfig) error {
+		_, err := llmProvider.CallLLM(ctx, &provider.LLMRequest{ModelCode: candidate.ModelCode})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Expected success after retries, got %v", err)
+	}
+	if candidate.ProviderCode != "primary" || attempt != 3 {
+		t.Errorf("Expected primary to succeed on attempt 3, got %s on attempt %d", candidate.ProviderCode, attempt)
+	}
+}
+
+func TestExecuteWithFallbackFailover(t *testing.T) {
+	primary := &stubProvider{errs: []error{&provider.StatusError{StatusCode: http.StatusBadRequest}}}
+	secondary := &stubProvider{}
+	c := newTestCommon(t, map[string]*stubProvider{"primary": primary, "secondary": secondary})
+
+	candidates := []*LLMCandidate{
+		{ProviderCode: "missing", ModelCode: "m0"},
+		{ProviderCode: "primary", ModelCode: "m1"},
+		{ProviderCode: "secondary", ModelCode: "m2"},
+	}
+	var reader provider.StreamReader
+	candidate, attempt, err := c.ExecuteWithFallback(candidates, func(ctx context.Context, candidate *LLMCandidate, llmProvider provider.Provider, config *provider.ProviderConfig) error {
+		r, err := OpenStream(ctx, llmProvider, &provider.LLMRequest{ModelCode: candidate.ModelCode})
+		reader = r
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Expected fallback to succeed, got %v", err)
+	}
+	if candidate.ProviderCode != "secondary" || attempt != 3 {
+		t.Errorf("Expected secondary to succeed on attempt 3, got %s on attempt %d", candidate.ProviderCode, attempt)
+	}
+	if primary.calls != 1 {
+		t.Errorf("Expected non-retryable error not to be retried, got %d calls", primary.calls)
+	}
+
+	// 预读时跳过空增量，首个响应为第一个有效增量
+	first, err := reader.Read()
+	if err != nil || first.Delta() != "hi" {
+		t.Errorf("Expected first delta 'hi', got %v, %v", first, err)
+	}
+}
+
+func TestExecuteWithFallbackCircuitOpen(t *testing.T) {
+	primary := &stubProvider{}
+	secondary := &stubProvider{}
+	c := newTestCommon(t, map[string]*stubProvider{"primary": primary, "secondary": secondary})
+	c.svcCtx.ProviderHealth = health.NewMonitor(c.svcCtx.ProviderManager, health.Options{ErrorRateThreshold: 0.5})
+	c.svcCtx.ProviderHealth.Record("primary", &provider.StatusError{StatusCode: http.StatusServiceUnavailable})
+
+	candidates := []*LLMCandidate{
+		{ProviderCode: "primary", ModelCode: "m1"},
+		{ProviderCode: "secondary", ModelCode: "m2"},
+	}
+	candidate, attempt, err := c.ExecuteWithFallback(candidates, func(ctx context.Context, candidate *LLMCandidate, llmProvider provider.Provider, config *provider.ProviderConfig) error {
+		_, err := llmProvider.CallLLM(ctx, &provider.LLMRequest{ModelCode: candidate.ModelCode})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Expected fallback to succeed, got %v", err)
+	}
+	if candidate.ProviderCode != "secondary" || attempt != 2 {
+		t.Errorf("Expected secondary to succeed on attempt 2, got %s on attempt %d", candidate.ProviderCode, attempt)
+	}
+	if primary.calls != 0 {
+		t.Errorf("Expected open circuit to skip primary, got %d calls", primary.calls)
+	}
+}
@@ -9,9 +9,8 @@ import (
 	"jxzy/bs/bs_llm/bs_llm"
 	"jxzy/bs/bs_llm/internal/model"
 	"jxzy/bs/bs_llm/internal/provider"
-	"jxzy/bs/bs_llm/internal/provider/bailian"
-	"jxzy/bs/bs_llm/internal/provider/doubao"
 	"jxzy/bs/bs_llm/internal/svc"
+	"jxzy/bs/bs_llm/internal/tokenizer"
 	"jxzy/common/logger"
 
 	"github.com/google/uuid"
@@ -25,23 +24,26 @@ type LLMCommon struct {
 	svcCtx          *svc.ServiceContext
 	providerManager *provider.Manager
 	logger          logx.Logger
+	redactor        *logger.Redactor // 全局规则，获取场景配置后追加场景规则
+	contentPolicy   string
+	routeReq        *routeRequest // 路由依据的请求特征
+	route           string        // 选择的路由名称
 }
 
 // NewLLMCommon 创建公共LLM逻辑实例
 func NewLLMCommon(ctx context.Context, svcCtx interface{}) *LLMCommon {
-	// 初始化供应商管理器
-	manager := provider.NewManager()
-	manager.Register("doubao", doubao.NewDoubaoProvider())
-	manager.Register("bailian", bailian.NewBailianProvider())
+	serviceCtx := svcCtx.(*svc.ServiceContext)
 
-	// 使用自定义的 ServiceLogger，在日志中显示服务名
+	// 使用自定义的 ServiceLogger，在日志中显示服务名，日志内容按全局规则脱敏
 	serviceLogger := logger.NewServiceLogger("bs-llm").WithContext(ctx)
 
 	return &LLMCommon{
 		ctx:             ctx,
-		svcCtx:          svcCtx.(*svc.ServiceContext),
-		providerManager: manager,
-		logger:          serviceLogger,
+		svcCtx:          serviceCtx,
+		providerManager: serviceCtx.ProviderManager,
+		logger:          logger.WithRedactor(serviceLogger, serviceCtx.Redactor),
+		redactor:        serviceCtx.Redactor,
+		contentPolicy:   ContentPolicyRedact,
 	}
 }
 
@@ -78,15 +80,21 @@ func (c *LLMCommon) InitializeCompletion(sceneCode string, messages []*bs_llm.Ch
 		OutputTokens: 0,
 		TotalTokens:  0,
 		Status:       0, // 初始状态为失败，成功时会更新
+		Attempt:      1,
 		CreatedAt:    time.Now(),
 	}
+	c.SetRouteRequest(messages, userId)
 
-	c.logger.Infof("Completion record initialized - RequestId: %s, Prompt: %s", requestId, completion.Prompt)
+	// 此时尚未获取场景配置，日志中不记录提示词内容
+	c.logger.Infof("Completion record initialized - RequestId: %s, Messages: %d, PromptLength: %d",
+		requestId, len(messages), len([]rune(completion.Prompt)))
 
 	return completion, requestId
 }
 
 // GetSceneConfig 获取场景配置
+// 场景配置按 SceneCacheExpire 缓存，返回的是副本，调用方可以修改
+// 场景配置了路由规则时，返回的主供应商和模型为按请求特征路由后的结果
 func (c *LLMCommon) GetSceneConfig(sceneCode string) (*model.LlmScene, error) {
 	c.logger.Infof("getSceneConfig called for scene_code: %s", sceneCode)
 
@@ -95,7 +103,16 @@ func (c *LLMCommon) GetSceneConfig(sceneCode string) (*model.LlmScene, error) {
 		return nil, fmt.Errorf("scene model not initialized")
 	}
 
-	sceneInfo, err := c.svcCtx.LlmSceneModel.FindOneBySceneCode(c.ctx, sceneCode)
+	load := func() (any, error) {
+		return c.svcCtx.LlmSceneModel.FindOneBySceneCode(c.ctx, sceneCode)
+	}
+	var value any
+	var err error
+	if c.svcCtx.SceneCache != nil {
+		value, err = c.svcCtx.SceneCache.Take(sceneCode, load)
+	} else {
+		value, err = load()
+	}
 	if err != nil {
 		if err == sqlc.ErrNotFound {
 			c.logger.Errorf("Scene_code %s not found", sceneCode)
@@ -105,105 +122,97 @@ func (c *LLMCommon) GetSceneConfig(sceneCode string) (*model.LlmScene, error) {
 		return nil, fmt.Errorf("failed to get scene info: %w", err)
 	}
 
+	sceneInfo := *value.(*model.LlmScene)
+	if sceneInfo.Deleted == 1 {
+		c.logger.Errorf("Scene_code %s has been deleted", sceneCode)
+		return nil, fmt.Errorf("scene_code %s not found", sceneCode)
+	}
+	c.useScene(&sceneInfo)
+	c.routeScene(&sceneInfo)
+
 	c.logger.Infof("Found scene config - SceneCode: %s, ProviderCode: %s, ModelCode: %s",
 		sceneInfo.SceneCode, sceneInfo.ProviderCode, sceneInfo.ModelCode)
-	return sceneInfo, nil
+	return &sceneInfo, nil
 }
 
 // GetProviderConfig 获取供应商配置
+// 供应商配置由 ServiceContext 中的供应商注册中心从配置文件和 llm_provider 表加载
 func (c *LLMCommon) GetProviderConfig(providerCode string) *provider.ProviderConfig {
-	// 这里应该从配置文件或数据库中获取供应商配置
-	// 为了简化，这里提供一个默认配置
-	switch providerCode {
-	case "doubao":
-		return &provider.ProviderConfig{
-			APIEndpoint: "https://ark.cn-beijing.volces.com/api/v3/chat/completions",
-			APIKey:      c.svcCtx.Config.DoubaoAPIKey,
-			Headers:     make(map[string]string),
-			Timeout:     30,
-			RetryCount:  3,
-		}
-	case "bailian":
-		return &provider.ProviderConfig{
-			APIEndpoint: "https://dashscope.aliyuncs.com/api/v1/services/aigc/text-generation/generation",
-			APIKey:      c.svcCtx.Config.BailianAPIKey,
-			Headers:     make(map[string]string),
-			Timeout:     120,
-			RetryCount:  3,
-		}
-	default:
-		return &provider.ProviderConfig{
-			Headers:    make(map[string]string),
-			Timeout:    30,
-			RetryCount: 3,
-		}
+	if config := c.providerManager.GetProviderConfig(providerCode); config != nil {
+		return config
 	}
+	return &provider.ProviderConfig{
+		Headers:    make(map[string]string),
+		Timeout:    30,
+		RetryCount: 3,
+	}
+}
+
+// MergeExtraParams 合并供应商默认参数与请求额外参数，请求参数优先
+func (c *LLMCommon) MergeExtraParams(config *provider.ProviderConfig, extraParams map[string]string) map[string]string {
+	if config == nil || len(config.DefaultParams) == 0 {
+		return extraParams
+	}
+	merged := make(map[string]string, len(config.DefaultParams)+len(extraParams))
+	for k, v := range config.DefaultParams {
+		merged[k] = v
+	}
+	for k, v := range extraParams {
+		merged[k] = v
+	}
+	return merged
 }
 
-// BuildPromptText 构建提示词文本
+// BuildPromptText 构建提示词文本，图片、音频等多模态片段只记录摘要
 func (c *LLMCommon) BuildPromptText(messages []*bs_llm.ChatMessage) string {
 	var parts []string
 	for _, msg := range messages {
-		parts = append(parts, fmt.Sprintf("[%s]: %s", msg.Role, msg.Content))
+		role := msg.Role
+		if msg.ToolCallId != "" {
+			role = fmt.Sprintf("%s:%s", msg.Role, msg.ToolCallId)
+		}
+		content := summarizeContent(msg)
+		if len(msg.ToolCalls) > 0 {
+			content = strings.TrimSpace(content + " " + FormatToolCalls(ConvertToProviderToolCalls(msg.ToolCalls)))
+		}
+		parts = append(parts, fmt.Sprintf("[%s]: %s", role, content))
 	}
 	return strings.Join(parts, "\n")
 }
 
-// EstimateTokens 估算文本的 token 数量
-// 这是一个简化的估算方法，基于字符数来计算
-// 对于英文，大约 4 个字符 = 1 个 token
-// 对于中文，大约 1.5 个字符 = 1 个 token
-func (c *LLMCommon) EstimateTokens(text string) int64 {
-	if text == "" {
-		return 0
-	}
-
-	// 计算中文字符数量
-	chineseChars := 0
-	for _, r := range text {
-		if r >= 0x4e00 && r <= 0x9fff {
-			chineseChars++
-		}
-	}
-
-	// 计算英文字符数量（包括空格、标点等）
-	totalChars := len(text)
-	englishChars := totalChars - chineseChars
-
-	// 估算 token 数量
-	// 中文：1.5 字符 = 1 token
-	// 英文：4 字符 = 1 token
-	chineseTokens := int64(float64(chineseChars) / 1.5)
-	englishTokens := int64(float64(englishChars) / 4.0)
-
-	totalTokens := chineseTokens + englishTokens
-
-	// 确保至少返回 1 个 token
-	if totalTokens < 1 {
-		totalTokens = 1
-	}
+// CountTokens 使用模型对应的分词器计算文本的 token 数量
+// 未配置分词器的模型按字符数估算
+func (c *LLMCommon) CountTokens(modelCode, text string) int64 {
+	return int64(c.svcCtx.Tokenizers.ForModel(modelCode).Count(text))
+}
 
-	return totalTokens
+// CountMessageTokens 使用模型对应的分词器计算消息列表发送给模型时的 token 数量
+// 可在调用前用于预算检查和上下文窗口截断
+func (c *LLMCommon) CountMessageTokens(modelCode string, messages []*provider.ChatMessage) int64 {
+	return int64(tokenizer.CountMessages(c.svcCtx.Tokenizers.ForModel(modelCode), messages))
 }
 
-// SaveCompletion 保存问答记录
+// SaveCompletion 保存问答记录，提示词和回答按场景落库策略脱敏或丢弃，并记录场景路由选择的路由名称
 func (c *LLMCommon) SaveCompletion(completion *model.LlmCompletion) {
 	c.logger.Infof("saveCompletion called for request_id: %s", completion.RequestId)
+	if completion.Route == "" {
+		completion.Route = c.route
+	}
 
-	// 检查context是否已取消
-	select {
-	case <-c.ctx.Done():
-		c.logger.Infof("Context canceled, skipping completion save")
-		return
-	default:
+	// 累加实际 token 用量到调用配额，未落库的用量也计入；命中响应缓存的调用不消耗配额，也不产生费用
+	if completion.CacheHit == 0 {
+		c.svcCtx.QuotaLimiter.Record(completion.UserId, completion.SceneCode, completion.TotalTokens)
+		completion.Cost, completion.Currency = c.svcCtx.Pricing.Cost(completion.ProviderCode, completion.ModelCode, completion.CreatedAt,
+			completion.InputTokens, completion.OutputTokens)
 	}
 
 	if c.svcCtx.LlmCompletionModel == nil {
 		c.logger.Error("completion model not initialized")
 		return
 	}
+	c.redactCompletion(completion)
 
-	// 使用独立的 context 进行数据库操作，避免 context canceled 错误
+	// 使用独立的 context 进行数据库操作，客户端取消的请求同样需要保存已生成的部分内容
 	dbCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -216,6 +225,15 @@ func (c *LLMCommon) SaveCompletion(completion *model.LlmCompletion) {
 	}
 }
 
+// CheckQuota 检查用户在场景下的调用配额，超出时返回 ErrCodeLLMQuotaExceeded 业务错误
+func (c *LLMCommon) CheckQuota(sceneCode, userId string) error {
+	if err := c.svcCtx.QuotaLimiter.Allow(c.ctx, userId, sceneCode); err != nil {
+		c.logger.Errorf("Quota exceeded - SceneCode: %s, UserId: %s, Error: %v", sceneCode, userId, err)
+		return err
+	}
+	return nil
+}
+
 // ValidateSceneCode 验证场景码
 func (c *LLMCommon) ValidateSceneCode(sceneCode string) error {
 	if sceneCode == "" {
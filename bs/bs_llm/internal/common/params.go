@@ -0,0 +1,208 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/bs/bs_llm/internal/provider"
+	"jxzy/common/errorx"
+)
+
+// 调用方可通过 LLMRequest.extra_params 覆盖的参数，需在 llm_scene.param_overrides 中允许
+const (
+	ParamTemperature      = "temperature"       // 0-2
+	ParamMaxTokens        = "max_tokens"        // 不超过场景的 max_tokens
+	ParamTopP             = "top_p"             // (0, 1]
+	ParamStop             = "stop"              // 停止词，JSON字符串数组或单个停止词
+	ParamSeed             = "seed"              // 非负整数
+	ParamPresencePenalty  = "presence_penalty"  // -2-2
+	ParamFrequencyPenalty = "frequency_penalty" // -2-2
+)
+
+// maxStopSequences 停止词的最大数量
+const maxStopSequences = 4
+
+var overridableParams = []string{ParamTemperature, ParamMaxTokens, ParamTopP, ParamStop, ParamSeed, ParamPresencePenalty, ParamFrequencyPenalty}
+
+// Params 场景参数与调用方覆盖参数合并后的采样参数
+type Params struct {
+	Temperature      float64
+	MaxTokens        int64
+	TopP             *float64
+	Stop             []string
+	Seed             *int64
+	PresencePenalty  *float64
+	FrequencyPenalty *float64
+}
+
+// ParseParamOverrides 解析场景允许调用方覆盖的参数列表（JSON数组）
+func ParseParamOverrides(overrides string) ([]string, error) {
+	if strings.TrimSpace(overrides) == "" {
+		return nil, nil
+	}
+	var names []string
+	if err := json.Unmarshal([]byte(overrides), &names); err != nil {
+		return nil, fmt.Errorf("invalid param_overrides: %w", err)
+	}
+	for _, name := range names {
+		if !isOverridableParam(name) {
+			return nil, fmt.Errorf("invalid param_overrides: unknown param %q, available: %s", name, strings.Join(overridableParams, "/"))
+		}
+	}
+	return names, nil
+}
+
+// ResolveParams 按场景白名单校验调用方的 extra_params，返回最终使用的采样参数
+// 不在白名单中的可覆盖参数及取值非法时返回 ErrCodeParamError 业务错误，其他键原样透传给供应商
+func ResolveParams(scene *model.LlmScene, extraParams map[string]string) (*Params, error) {
+	params := &Params{Temperature: scene.Temperature, MaxTokens: scene.MaxTokens}
+	if len(extraParams) == 0 {
+		return params, nil
+	}
+
+	allowed, err := ParseParamOverrides(scene.ParamOverrides.String)
+	if err != nil {
+		return nil, fmt.Errorf("scene %s: %w", scene.SceneCode, err)
+	}
+	for name, value := range extraParams {
+		if !isOverridableParam(name) {
+			continue
+		}
+		if !contains(allowed, name) {
+			return nil, errorx.NewCodeErrorf(errorx.ErrCodeParamError, "extra_params %q is not allowed in scene %s", name, scene.SceneCode)
+		}
+		if err := params.set(name, value, scene.MaxTokens); err != nil {
+			return nil, errorx.NewCodeErrorf(errorx.ErrCodeParamError, "invalid extra_params %q: %v", name, err)
+		}
+	}
+	return params, nil
+}
+
+// Apply 将采样参数写入供应商请求
+// 供应商默认参数（DefaultParams）中的采样参数在场景和调用方均未指定时生效，无效的默认参数忽略
+func (p *Params) Apply(req *provider.LLMRequest) {
+	merged := *p
+	if req.Config != nil {
+		defaults := &Params{}
+		for name, value := range req.Config.DefaultParams {
+			if name != ParamTemperature && name != ParamMaxTokens && isOverridableParam(name) {
+				_ = defaults.set(name, value, 0)
+			}
+		}
+		if merged.TopP == nil {
+			merged.TopP = defaults.TopP
+		}
+		if merged.Stop == nil {
+			merged.Stop = defaults.Stop
+		}
+		if merged.Seed == nil {
+			merged.Seed = defaults.Seed
+		}
+		if merged.PresencePenalty == nil {
+			merged.PresencePenalty = defaults.PresencePenalty
+		}
+		if merged.FrequencyPenalty == nil {
+			merged.FrequencyPenalty = defaults.FrequencyPenalty
+		}
+	}
+
+	req.Temperature = merged.Temperature
+	req.MaxTokens = merged.MaxTokens
+	req.TopP = merged.TopP
+	req.Stop = merged.Stop
+	req.Seed = merged.Seed
+	req.PresencePenalty = merged.PresencePenalty
+	req.FrequencyPenalty = merged.FrequencyPenalty
+}
+
+// set 解析并校验单个参数，maxTokens 为场景允许的最大生成token数，超出时截断
+func (p *Params) set(name, value string, maxTokens int64) error {
+	value = strings.TrimSpace(value)
+	switch name {
+	case ParamTemperature:
+		v, err := parseFloatInRange(value, 0, 2)
+		if err != nil {
+			return err
+		}
+		p.Temperature = v
+	case ParamMaxTokens:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || v <= 0 {
+			return fmt.Errorf("must be a positive integer")
+		}
+		if maxTokens > 0 && v > maxTokens {
+			v = maxTokens
+		}
+		p.MaxTokens = v
+	case ParamTopP:
+		v, err := parseFloatInRange(value, 0, 1)
+		if err != nil {
+			return err
+		}
+		if v == 0 {
+			return fmt.Errorf("must be greater than 0")
+		}
+		p.TopP = &v
+	case ParamStop:
+		stop := []string{value}
+		if strings.HasPrefix(value, "[") {
+			stop = nil
+			if err := json.Unmarshal([]byte(value), &stop); err != nil {
+				return fmt.Errorf("must be a JSON string array: %v", err)
+			}
+		}
+		if len(stop) == 0 || len(stop) > maxStopSequences {
+			return fmt.Errorf("must contain 1 to %d stop sequences", maxStopSequences)
+		}
+		for _, s := range stop {
+			if s == "" {
+				return fmt.Errorf("stop sequence must not be empty")
+			}
+		}
+		p.Stop = stop
+	case ParamSeed:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || v < 0 {
+			return fmt.Errorf("must be a non-negative integer")
+		}
+		p.Seed = &v
+	case ParamPresencePenalty:
+		v, err := parseFloatInRange(value, -2, 2)
+		if err != nil {
+			return err
+		}
+		p.PresencePenalty = &v
+	case ParamFrequencyPenalty:
+		v, err := parseFloatInRange(value, -2, 2)
+		if err != nil {
+			return err
+		}
+		p.FrequencyPenalty = &v
+	}
+	return nil
+}
+
+func parseFloatInRange(value string, min, max float64) (float64, error) {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(v) || v < min || v > max {
+		return 0, fmt.Errorf("must be a number between %g and %g", min, max)
+	}
+	return v, nil
+}
+
+func isOverridableParam(name string) bool {
+	return contains(overridableParams, name)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package common
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/bs/bs_llm/internal/provider"
+	"jxzy/common/errorx"
+)
+
+func newParamsScene(overrides string) *model.LlmScene {
+	return &model.LlmScene{
+		SceneCode:      "chat_general",
+		Temperature:    0.7,
+		MaxTokens:      1000,
+		ParamOverrides: sql.NullString{String: overrides, Valid: overrides != ""},
+	}
+}
+
+func TestResolveParams(t *testing.T) {
+	scene := newParamsScene(`["temperature","max_tokens","top_p","stop","seed"]`)
+	params, err := ResolveParams(scene, map[string]string{
+		"temperature": "0",
+		"max_tokens":  "4000",
+		"top_p":       "0.9",
+		"stop":        `["\n\n","END"]`,
+		"seed":        "42",
+		"user_tag":    "a", // 非采样参数原样透传，不校验
+	})
+	if err != nil {
+		t.Fatalf("ResolveParams failed: %v", err)
+	}
+	if params.Temperature != 0 || params.MaxTokens != 1000 {
+		t.Errorf("Expected temperature 0 and max_tokens capped at 1000, got %v/%d", params.Temperature, params.MaxTokens)
+	}
+	if *params.TopP != 0.9 || *params.Seed != 42 || !reflect.DeepEqual(params.Stop, []string{"\n\n", "END"}) {
+		t.Errorf("Unexpected params: %+v", params)
+	}
+
+	params, err = ResolveParams(scene, map[string]string{"stop": "###"})
+	if err != nil || !reflect.DeepEqual(params.Stop, []string{"###"}) {
+		t.Errorf("Expected single stop sequence, got %v, %v", params, err)
+	}
+
+	cases := map[string]map[string]string{
+		"not allowed":    {"presence_penalty": "1"},
+		"top_p zero":     {"top_p": "0"},
+		"top_p NaN":      {"top_p": "NaN"},
+		"temperature":    {"temperature": "3"},
+		"max_tokens":     {"max_tokens": "-1"},
+		"seed":           {"seed": "abc"},
+		"too many stops": {"stop": `["a","b","c","d","e"]`},
+	}
+	for name, extra := range cases {
+		_, err := ResolveParams(scene, extra)
+		if codeErr, ok := errorx.FromError(err); !ok || codeErr.Code != errorx.ErrCodeParamError {
+			t.Errorf("%s: expected param error, got %v", name, err)
+		}
+	}
+
+	// 场景未配置白名单时不允许覆盖
+	if _, err := ResolveParams(newParamsScene(""), map[string]string{"top_p": "0.5"}); err == nil {
+		t.Error("Expected error when scene has no param_overrides")
+	}
+}
+
+func TestParamsApply(t *testing.T) {
+	params, err := ResolveParams(newParamsScene(`["top_p"]`), map[string]string{"top_p": "0.5"})
+	if err != nil {
+		t.Fatalf("ResolveParams failed: %v", err)
+	}
+	req := &provider.LLMRequest{Config: &provider.ProviderConfig{DefaultParams: map[string]string{
+		"top_p":             "0.8",
+		"frequency_penalty": "0.3",
+		"seed":              "invalid",
+		"temperature":       "1.5",
+	}}}
+	params.Apply(req)
+
+	if req.Temperature != 0.7 || req.MaxTokens != 1000 {
+		t.Errorf("Expected scene temperature and max_tokens, got %v/%d", req.Temperature, req.MaxTokens)
+	}
+	if *req.TopP != 0.5 {
+		t.Errorf("Expected caller top_p to override provider default, got %v", *req.TopP)
+	}
+	if req.FrequencyPenalty == nil || *req.FrequencyPenalty != 0.3 {
+		t.Errorf("Expected provider default frequency_penalty, got %v", req.FrequencyPenalty)
+	}
+	if req.Seed != nil {
+		t.Errorf("Expected invalid provider default to be ignored, got %v", *req.Seed)
+	}
+}
@@ -0,0 +1,104 @@
+package common
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/common/logger"
+)
+
+// 场景问答内容落库策略
+const (
+	ContentPolicyRedact  = "redact"  // 脱敏后保存（默认）
+	ContentPolicyRaw     = "raw"     // 原文保存
+	ContentPolicyDiscard = "discard" // 不保存提示词和回答内容，只保存用量等元数据
+)
+
+// discardedContent 不保存内容时日志中的占位文本
+const discardedContent = "[DISCARDED]"
+
+// ParseContentPolicy 校验落库策略，为空时返回默认的 redact
+func ParseContentPolicy(policy string) (string, error) {
+	switch policy {
+	case "":
+		return ContentPolicyRedact, nil
+	case ContentPolicyRedact, ContentPolicyRaw, ContentPolicyDiscard:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid content_policy %q, available: redact/raw/discard", policy)
+	}
+}
+
+// SceneRedactor 在全局脱敏器的基础上追加场景自定义规则（JSON数组）
+func SceneRedactor(base *logger.Redactor, patterns string) (*logger.Redactor, error) {
+	if strings.TrimSpace(patterns) == "" {
+		return base, nil
+	}
+	var rules []logger.RedactRuleConf
+	if err := json.Unmarshal([]byte(patterns), &rules); err != nil {
+		return nil, fmt.Errorf("invalid redact_patterns: %w", err)
+	}
+	for _, rule := range rules {
+		if rule.Pattern == "" {
+			return nil, fmt.Errorf("invalid redact_patterns: pattern is required")
+		}
+	}
+	redactor, err := base.With(rules...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redact_patterns: %w", err)
+	}
+	return redactor, nil
+}
+
+// useScene 按场景配置切换落库策略和脱敏规则，场景规则在全局规则之后执行
+// 场景规则无效时沿用全局规则
+func (c *LLMCommon) useScene(scene *model.LlmScene) {
+	policy, err := ParseContentPolicy(scene.ContentPolicy)
+	if err != nil {
+		c.logger.Errorf("Scene %s: %v, using %s", scene.SceneCode, err, ContentPolicyRedact)
+		policy = ContentPolicyRedact
+	}
+	c.contentPolicy = policy
+
+	redactor, err := SceneRedactor(c.svcCtx.Redactor, scene.RedactPatterns.String)
+	if err != nil {
+		c.logger.Errorf("Scene %s: %v, using global redact rules", scene.SceneCode, err)
+		return
+	}
+	c.redactor = redactor
+}
+
+// RedactForLog 返回可以写入日志的内容：不保存内容的场景返回占位文本，其余场景返回脱敏后的内容
+func (c *LLMCommon) RedactForLog(content string) string {
+	if c.contentPolicy == ContentPolicyDiscard {
+		return discardedContent
+	}
+	return c.redactor.Redact(content)
+}
+
+// redactCompletion 按场景落库策略处理问答记录中的提示词、回答、推理过程和错误信息
+func (c *LLMCommon) redactCompletion(completion *model.LlmCompletion) {
+	switch c.contentPolicy {
+	case ContentPolicyRaw:
+		return
+	case ContentPolicyDiscard:
+		completion.Prompt = ""
+		completion.Completion = sql.NullString{}
+		completion.Reasoning = sql.NullString{}
+	default:
+		completion.Prompt = c.redactor.Redact(completion.Prompt)
+		if completion.Completion.Valid {
+			completion.Completion.String = c.redactor.Redact(completion.Completion.String)
+		}
+		if completion.Reasoning.Valid {
+			completion.Reasoning.String = c.redactor.Redact(completion.Reasoning.String)
+		}
+	}
+	// 错误信息可能包含供应商回显的请求内容，除原文保存外都需要脱敏
+	if completion.ErrorMsg.Valid {
+		completion.ErrorMsg.String = c.redactor.Redact(completion.ErrorMsg.String)
+	}
+}
@@ -0,0 +1,51 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	"jxzy/bs/bs_llm/internal/cache"
+	"jxzy/bs/bs_llm/internal/model"
+)
+
+// IsCacheable 本次调用是否使用响应缓存
+// 需要 cache_ttl > 0，且实际使用的温度为0（确定性输出），或显式开启 cache_nondeterministic
+// 不保存内容（content_policy=discard）的场景不缓存响应
+func IsCacheable(sceneConfig *model.LlmScene, temperature float64) bool {
+	if sceneConfig.CacheTtl <= 0 || sceneConfig.ContentPolicy == ContentPolicyDiscard {
+		return false
+	}
+	return temperature == 0 || sceneConfig.CacheNondeterministic == 1
+}
+
+// GetCachedResponse 查询响应缓存，未命中或查询失败时返回 nil
+func (c *LLMCommon) GetCachedResponse(key string) *cache.Entry {
+	entry, err := c.responseCache().Get(c.ctx, key)
+	if err != nil {
+		c.logger.Errorf("Failed to get cached response - Key: %s, Error: %v", key, err)
+		return nil
+	}
+	return entry
+}
+
+// SaveCachedResponse 写入响应缓存，失败时仅记录日志
+func (c *LLMCommon) SaveCachedResponse(key string, sceneConfig *model.LlmScene, entry *cache.Entry) {
+	// 使用独立的 context，避免调用方取消导致缓存写入失败
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ttl := time.Duration(sceneConfig.CacheTtl) * time.Second
+	if err := c.responseCache().Set(ctx, key, sceneConfig.SceneCode, entry, ttl); err != nil {
+		c.logger.Errorf("Failed to save cached response - Key: %s, Error: %v", key, err)
+		return
+	}
+	c.logger.Infof("Cached response saved - SceneCode: %s, Key: %s, TTL: %s", sceneConfig.SceneCode, key, ttl)
+}
+
+// responseCache 原文保存的场景使用配置的缓存后端，其余场景的原始响应只缓存在进程内
+func (c *LLMCommon) responseCache() cache.Cache {
+	if c.contentPolicy == ContentPolicyRaw {
+		return c.svcCtx.ResponseCache
+	}
+	return c.svcCtx.LocalResponseCache
+}
@@ -0,0 +1,136 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/model"
+)
+
+// RouteDefault 场景配置了路由规则但未命中任何规则时记录的路由名称
+const RouteDefault = "default"
+
+// userBuckets 按 user_id 哈希分流的桶数量
+const userBuckets = 100
+
+// RouteRule 场景路由规则（llm_scene.routing_rules 的元素），配置的条件全部满足时命中
+type RouteRule struct {
+	Name            string `json:"name"`                        // 路由名称，记录到 llm_completion.route
+	ProviderCode    string `json:"provider_code"`               // 命中后使用的供应商
+	ModelCode       string `json:"model_code"`                  // 命中后使用的模型
+	MinPromptTokens int64  `json:"min_prompt_tokens,omitempty"` // 提示词token数下限（含），0表示不限
+	MaxPromptTokens int64  `json:"max_prompt_tokens,omitempty"` // 提示词token数上限（含），0表示不限
+	UserBucket      []int  `json:"user_bucket,omitempty"`       // user_id 哈希分桶范围 [start, end)，取值0-100，如[0,10]为10%的用户
+}
+
+// routeRequest 路由依据的请求特征
+type routeRequest struct {
+	messages []*bs_llm.ChatMessage
+	userId   string
+}
+
+// ParseRoutingRules 解析场景路由规则（JSON数组）
+func ParseRoutingRules(rules string) ([]*RouteRule, error) {
+	if strings.TrimSpace(rules) == "" {
+		return nil, nil
+	}
+	var parsed []*RouteRule
+	if err := json.Unmarshal([]byte(rules), &parsed); err != nil {
+		return nil, fmt.Errorf("invalid routing_rules: %w", err)
+	}
+	names := make(map[string]bool, len(parsed))
+	for i, rule := range parsed {
+		if rule == nil || rule.Name == "" || rule.ProviderCode == "" || rule.ModelCode == "" {
+			return nil, fmt.Errorf("invalid routing_rules: rule %d requires name, provider_code and model_code", i)
+		}
+		if rule.Name == RouteDefault || names[rule.Name] {
+			return nil, fmt.Errorf("invalid routing_rules: duplicate or reserved name %q", rule.Name)
+		}
+		names[rule.Name] = true
+		if rule.MinPromptTokens < 0 || rule.MaxPromptTokens < 0 ||
+			(rule.MaxPromptTokens > 0 && rule.MinPromptTokens > rule.MaxPromptTokens) {
+			return nil, fmt.Errorf("invalid routing_rules: rule %q has invalid prompt token range", rule.Name)
+		}
+		if rule.UserBucket != nil {
+			if len(rule.UserBucket) != 2 || rule.UserBucket[0] < 0 || rule.UserBucket[0] >= rule.UserBucket[1] || rule.UserBucket[1] > userBuckets {
+				return nil, fmt.Errorf("invalid routing_rules: rule %q user_bucket must be [start, end) within 0-%d", rule.Name, userBuckets)
+			}
+		}
+	}
+	return parsed, nil
+}
+
+// MatchRoute 按顺序返回第一个命中的路由规则，未命中时返回nil
+// promptTokens 仅在规则配置了提示词长度条件时调用
+func MatchRoute(rules []*RouteRule, sceneCode, userId string, promptTokens func() int64) *RouteRule {
+	var tokens int64 = -1
+	for _, rule := range rules {
+		if rule.MinPromptTokens > 0 || rule.MaxPromptTokens > 0 {
+			if tokens < 0 {
+				tokens = promptTokens()
+			}
+			if tokens < rule.MinPromptTokens || (rule.MaxPromptTokens > 0 && tokens > rule.MaxPromptTokens) {
+				continue
+			}
+		}
+		if rule.UserBucket != nil {
+			bucket := UserBucket(sceneCode, userId)
+			if bucket < rule.UserBucket[0] || bucket >= rule.UserBucket[1] {
+				continue
+			}
+		}
+		return rule
+	}
+	return nil
+}
+
+// UserBucket 返回用户在场景下的分流桶（0-99），同一用户在同一场景下固定，不同场景之间相互独立
+func UserBucket(sceneCode, userId string) int {
+	h := fnv.New32a()
+	h.Write([]byte(sceneCode + ":" + userId))
+	return int(h.Sum32() % userBuckets)
+}
+
+// SetRouteRequest 设置路由依据的请求特征，InitializeCompletion 会自动设置
+func (c *LLMCommon) SetRouteRequest(messages []*bs_llm.ChatMessage, userId string) {
+	c.routeReq = &routeRequest{messages: messages, userId: userId}
+}
+
+// Route 返回最近一次 GetSceneConfig 选择的路由名称，场景未配置路由规则时为空
+func (c *LLMCommon) Route() string {
+	return c.route
+}
+
+// routeScene 按场景路由规则替换主供应商和模型，降级链不变
+// 规则无效时使用场景的默认配置
+func (c *LLMCommon) routeScene(scene *model.LlmScene) {
+	c.route = ""
+	rules, err := ParseRoutingRules(scene.RoutingRules.String)
+	if err != nil {
+		c.logger.Errorf("Scene %s: %v, using default route", scene.SceneCode, err)
+		return
+	}
+	if len(rules) == 0 {
+		return
+	}
+
+	var req routeRequest
+	if c.routeReq != nil {
+		req = *c.routeReq
+	}
+	rule := MatchRoute(rules, scene.SceneCode, req.userId, func() int64 {
+		return c.CountMessageTokens(scene.ModelCode, ConvertToProviderMessages(req.messages))
+	})
+	if rule == nil {
+		c.route = RouteDefault
+		return
+	}
+
+	c.route = rule.Name
+	scene.ProviderCode = rule.ProviderCode
+	scene.ModelCode = rule.ModelCode
+	c.logger.Infof("Scene %s routed to %s - Provider: %s, Model: %s", scene.SceneCode, rule.Name, rule.ProviderCode, rule.ModelCode)
+}
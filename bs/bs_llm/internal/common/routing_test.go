@@ -0,0 +1,73 @@
+package common
+
+import (
+	"testing"
+)
+
+func TestParseRoutingRules(t *testing.T) {
+	rules, err := ParseRoutingRules(`[
+		{"name":"long","min_prompt_tokens":8000,"provider_code":"bailian","model_code":"qwen-long"},
+		{"name":"short","max_prompt_tokens":200,"provider_code":"doubao","model_code":"doubao-lite"},
+		{"name":"b","user_bucket":[0,50],"provider_code":"openai","model_code":"gpt-4o-mini"}
+	]`)
+	if err != nil || len(rules) != 3 {
+		t.Fatalf("ParseRoutingRules failed: %v", err)
+	}
+	if rules, err := ParseRoutingRules(" "); err != nil || rules != nil {
+		t.Errorf("Expected no rules for empty config, got %v, %v", rules, err)
+	}
+
+	invalid := []string{
+		`{`,
+		`[{"name":"long","provider_code":"bailian"}]`,
+		`[{"name":"default","provider_code":"bailian","model_code":"m"}]`,
+		`[{"name":"a","provider_code":"p","model_code":"m"},{"name":"a","provider_code":"p","model_code":"m"}]`,
+		`[{"name":"a","min_prompt_tokens":100,"max_prompt_tokens":10,"provider_code":"p","model_code":"m"}]`,
+		`[{"name":"a","user_bucket":[0,101],"provider_code":"p","model_code":"m"}]`,
+		`[{"name":"a","user_bucket":[10],"provider_code":"p","model_code":"m"}]`,
+	}
+	for _, rules := range invalid {
+		if _, err := ParseRoutingRules(rules); err == nil {
+			t.Errorf("Expected error for %s", rules)
+		}
+	}
+}
+
+func TestMatchRoute(t *testing.T) {
+	rules, _ := ParseRoutingRules(`[
+		{"name":"long","min_prompt_tokens":8000,"provider_code":"bailian","model_code":"qwen-long"},
+		{"name":"short","max_prompt_tokens":200,"provider_code":"doubao","model_code":"doubao-lite"}
+	]`)
+	tokens := func(n int64) func() int64 { return func() int64 { return n } }
+
+	if rule := MatchRoute(rules, "chat", "u1", tokens(10000)); rule == nil || rule.Name != "long" {
+		t.Errorf("Expected long route, got %+v", rule)
+	}
+	if rule := MatchRoute(rules, "chat", "u1", tokens(200)); rule == nil || rule.Name != "short" {
+		t.Errorf("Expected short route, got %+v", rule)
+	}
+	if rule := MatchRoute(rules, "chat", "u1", tokens(1000)); rule != nil {
+		t.Errorf("Expected no route, got %+v", rule)
+	}
+
+	// 未配置长度条件时不计算 token 数
+	ab, _ := ParseRoutingRules(`[{"name":"b","user_bucket":[0,50],"provider_code":"openai","model_code":"gpt-4o-mini"}]`)
+	counted := false
+	matched := 0
+	for i := 0; i < 1000; i++ {
+		userId := "user-" + string(rune('a'+i%26)) + string(rune('a'+i/26))
+		rule := MatchRoute(ab, "chat", userId, func() int64 { counted = true; return 0 })
+		if rule != nil {
+			matched++
+		}
+		if again := MatchRoute(ab, "chat", userId, tokens(0)); (again != nil) != (rule != nil) {
+			t.Fatalf("Expected stable route for %s", userId)
+		}
+	}
+	if counted {
+		t.Error("Expected prompt tokens not to be counted")
+	}
+	if matched < 400 || matched > 600 {
+		t.Errorf("Expected about half of users in bucket [0,50), got %d/1000", matched)
+	}
+}
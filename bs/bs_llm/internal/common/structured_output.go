@@ -0,0 +1,130 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/jsonschema"
+	"jxzy/bs/bs_llm/internal/provider"
+)
+
+// StructuredOutput 结构化输出要求
+type StructuredOutput struct {
+	Format *provider.ResponseFormat
+	schema *jsonschema.Schema // 仅 json_schema 格式有效
+}
+
+// NewStructuredOutput 解析请求的输出格式，普通文本返回 nil
+func NewStructuredOutput(format *bs_llm.ResponseFormat) (*StructuredOutput, error) {
+	if format == nil || format.Type == "" || format.Type == provider.ResponseFormatText {
+		return nil, nil
+	}
+
+	switch format.Type {
+	case provider.ResponseFormatJSONObject:
+		return &StructuredOutput{Format: &provider.ResponseFormat{Type: format.Type}}, nil
+	case provider.ResponseFormatJSONSchema:
+		if format.Schema == "" {
+			return nil, fmt.Errorf("response_format.schema is required for json_schema")
+		}
+		schema, err := jsonschema.Compile([]byte(format.Schema))
+		if err != nil {
+			return nil, fmt.Errorf("invalid response_format.schema: %w", err)
+		}
+		return &StructuredOutput{
+			Format: &provider.ResponseFormat{
+				Type:   format.Type,
+				Name:   format.Name,
+				Schema: json.RawMessage(format.Schema),
+				Strict: format.Strict,
+			},
+			schema: schema,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported response_format.type: %s", format.Type)
+	}
+}
+
+// Instruct 在系统消息中追加输出格式要求，不支持 JSON 模式的供应商依靠提示词约束输出
+func (s *StructuredOutput) Instruct(messages []*provider.ChatMessage) []*provider.ChatMessage {
+	instruction := "请仅输出一个合法的 JSON 对象，不要包含 Markdown 代码块或其他说明文字。"
+	if s.schema != nil {
+		instruction += "输出必须符合以下 JSON Schema：\n" + string(s.Format.Schema)
+	}
+
+	result := make([]*provider.ChatMessage, 0, len(messages)+1)
+	if len(messages) > 0 && messages[0].Role == "system" {
+		system := *messages[0]
+		system.Content = strings.TrimSpace(system.Content + "\n\n" + instruction)
+		if system.Parts != nil {
+			system.Parts = append(append([]*provider.ContentPart{}, system.Parts...),
+				&provider.ContentPart{Type: provider.ContentPartText, Text: instruction})
+		}
+		result = append(result, &system)
+		return append(result, messages[1:]...)
+	}
+	result = append(result, &provider.ChatMessage{Role: "system", Content: instruction})
+	return append(result, messages...)
+}
+
+// Validate 提取并校验模型输出的 JSON，返回去除代码块等多余内容后的 JSON 文本
+func (s *StructuredOutput) Validate(content string) (string, error) {
+	text := ExtractJSON(content)
+	if !json.Valid([]byte(text)) {
+		return "", fmt.Errorf("output is not valid JSON")
+	}
+	if s.schema == nil {
+		if !strings.HasPrefix(text, "{") {
+			return "", fmt.Errorf("output is not a JSON object")
+		}
+		return text, nil
+	}
+	if err := s.schema.ValidateJSON([]byte(text)); err != nil {
+		return "", err
+	}
+	return text, nil
+}
+
+// RepairMessages 构建修复请求：附上不合格的输出及校验错误，要求模型重新输出
+func (s *StructuredOutput) RepairMessages(messages []*provider.ChatMessage, content string, cause error) []*provider.ChatMessage {
+	result := make([]*provider.ChatMessage, 0, len(messages)+2)
+	result = append(result, messages...)
+	return append(result,
+		&provider.ChatMessage{Role: "assistant", Content: content},
+		&provider.ChatMessage{
+			Role:    "user",
+			Content: fmt.Sprintf("上一次的输出不符合格式要求：%v\n请修正后重新输出，仅输出符合要求的 JSON。", cause),
+		},
+	)
+}
+
+// ExtractJSON 去除模型输出中的 Markdown 代码块和前后说明文字
+func ExtractJSON(content string) string {
+	text := strings.TrimSpace(content)
+	if strings.HasPrefix(text, "```") {
+		text = strings.TrimPrefix(text, "```")
+		if newline := strings.Index(text, "\n"); newline >= 0 {
+			text = text[newline+1:] // 去掉语言标记，如 ```json
+		}
+		text = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), "```"))
+	}
+	if strings.HasPrefix(text, "{") || strings.HasPrefix(text, "[") {
+		return text
+	}
+
+	start := strings.IndexAny(text, "{[")
+	if start < 0 {
+		return text
+	}
+	closing := "}"
+	if text[start] == '[' {
+		closing = "]"
+	}
+	end := strings.LastIndex(text, closing)
+	if end < start {
+		return text
+	}
+	return text[start : end+1]
+}
@@ -0,0 +1,88 @@
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/provider"
+)
+
+func TestExtractJSON(t *testing.T) {
+	cases := map[string]string{
+		`{"a":1}`:                 `{"a":1}`,
+		"```json\n{\"a\":1}\n```": `{"a":1}`,
+		"```\n[1,2]\n```":         `[1,2]`,
+		"以下是结果：\n{\"a\":{\"b\":2}}\n希望有帮助": `{"a":{"b":2}}`,
+		"no json here": "no json here",
+	}
+	for input, want := range cases {
+		if got := ExtractJSON(input); got != want {
+			t.Errorf("ExtractJSON(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestNewStructuredOutput(t *testing.T) {
+	for _, format := range []*bs_llm.ResponseFormat{nil, {}, {Type: "text"}} {
+		if s, err := NewStructuredOutput(format); s != nil || err != nil {
+			t.Errorf("Expected nil for %v, got %v, %v", format, s, err)
+		}
+	}
+	for _, format := range []*bs_llm.ResponseFormat{
+		{Type: "xml"},
+		{Type: "json_schema"},
+		{Type: "json_schema", Schema: `{"type": 1}`},
+	} {
+		if _, err := NewStructuredOutput(format); err == nil {
+			t.Errorf("Expected error for %v", format)
+		}
+	}
+}
+
+func TestStructuredOutputValidate(t *testing.T) {
+	s, err := NewStructuredOutput(&bs_llm.ResponseFormat{
+		Type:   "json_schema",
+		Schema: `{"type":"object","properties":{"sentences":{"type":"array","items":{"type":"string"}}},"required":["sentences"]}`,
+	})
+	if err != nil {
+		t.Fatalf("NewStructuredOutput failed: %v", err)
+	}
+
+	content, err := s.Validate("```json\n{\"sentences\":[\"a\",\"b\"]}\n```")
+	if err != nil || content != `{"sentences":["a","b"]}` {
+		t.Errorf("Expected fenced JSON to be accepted, got %q, %v", content, err)
+	}
+	if _, err := s.Validate(`{"sentences":"a"}`); err == nil {
+		t.Error("Expected schema violation")
+	}
+	if _, err := s.Validate(`"sentences": ["a"`); err == nil {
+		t.Error("Expected invalid JSON error")
+	}
+
+	object, _ := NewStructuredOutput(&bs_llm.ResponseFormat{Type: "json_object"})
+	if _, err := object.Validate(`[1]`); err == nil {
+		t.Error("Expected json_object to reject arrays")
+	}
+}
+
+func TestStructuredOutputInstruct(t *testing.T) {
+	s, _ := NewStructuredOutput(&bs_llm.ResponseFormat{Type: "json_schema", Schema: `{"type":"object"}`})
+	messages := []*provider.ChatMessage{
+		{Role: "system", Content: "你是助手"},
+		{Role: "user", Content: "hi"},
+	}
+
+	result := s.Instruct(messages)
+	if len(result) != 2 || !strings.Contains(result[0].Content, `{"type":"object"}`) {
+		t.Errorf("Expected schema appended to system message, got %+v", result[0])
+	}
+	if messages[0].Content != "你是助手" {
+		t.Error("Expected original messages to be unchanged")
+	}
+
+	result = s.Instruct(messages[1:])
+	if len(result) != 2 || result[0].Role != "system" {
+		t.Errorf("Expected system message to be prepended, got %+v", result)
+	}
+}
@@ -1,6 +1,9 @@
 package common
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"jxzy/bs/bs_llm/bs_llm"
 	"jxzy/bs/bs_llm/internal/provider"
 )
@@ -9,10 +12,126 @@ import (
 func ConvertToProviderMessages(messages []*bs_llm.ChatMessage) []*provider.ChatMessage {
 	var result []*provider.ChatMessage
 	for _, msg := range messages {
+		content, parts := convertContentParts(msg)
 		result = append(result, &provider.ChatMessage{
-			Role:    msg.Role,
-			Content: msg.Content,
+			Role:       msg.Role,
+			Content:    content,
+			ToolCalls:  ConvertToProviderToolCalls(msg.ToolCalls),
+			ToolCallID: msg.ToolCallId,
+			Name:       msg.Name,
+			Parts:      parts,
+		})
+	}
+	return result
+}
+
+// ConvertToProviderTools 转换工具定义，参数的JSON Schema必须是合法的JSON
+func ConvertToProviderTools(tools []*bs_llm.Tool) ([]*provider.Tool, error) {
+	var result []*provider.Tool
+	for _, tool := range tools {
+		if tool.Function == nil || tool.Function.Name == "" {
+			return nil, fmt.Errorf("tool function name is required")
+		}
+
+		var parameters json.RawMessage
+		if tool.Function.Parameters != "" {
+			if !json.Valid([]byte(tool.Function.Parameters)) {
+				return nil, fmt.Errorf("invalid parameters schema for tool %s", tool.Function.Name)
+			}
+			parameters = json.RawMessage(tool.Function.Parameters)
+		}
+
+		result = append(result, &provider.Tool{
+			Type: tool.Type,
+			Function: &provider.FunctionDefinition{
+				Name:        tool.Function.Name,
+				Description: tool.Function.Description,
+				Parameters:  parameters,
+			},
+		})
+	}
+	return result, nil
+}
+
+// ConvertToProviderToolCalls 转换工具调用格式
+func ConvertToProviderToolCalls(calls []*bs_llm.ToolCall) []*provider.ToolCall {
+	var result []*provider.ToolCall
+	for _, call := range calls {
+		toolCall := &provider.ToolCall{
+			Index: int(call.Index),
+			ID:    call.Id,
+			Type:  call.Type,
+		}
+		if call.Function != nil {
+			toolCall.Function = provider.FunctionCall{
+				Name:      call.Function.Name,
+				Arguments: call.Function.Arguments,
+			}
+		}
+		result = append(result, toolCall)
+	}
+	return result
+}
+
+// ConvertToRPCToolCalls 将供应商返回的工具调用转换为gRPC格式
+func ConvertToRPCToolCalls(calls []*provider.ToolCall) []*bs_llm.ToolCall {
+	var result []*bs_llm.ToolCall
+	for _, call := range calls {
+		result = append(result, &bs_llm.ToolCall{
+			Index: int32(call.Index),
+			Id:    call.ID,
+			Type:  call.Type,
+			Function: &bs_llm.FunctionCall{
+				Name:      call.Function.Name,
+				Arguments: call.Function.Arguments,
+			},
 		})
 	}
 	return result
 }
+
+// MergeToolCallDeltas 按 Index 拼接流式返回的工具调用增量
+func MergeToolCallDeltas(merged []*provider.ToolCall, deltas []*provider.ToolCall) []*provider.ToolCall {
+	for _, delta := range deltas {
+		var target *provider.ToolCall
+		for _, call := range merged {
+			if call.Index == delta.Index {
+				target = call
+				break
+			}
+		}
+		if target == nil {
+			target = &provider.ToolCall{Index: delta.Index}
+			merged = append(merged, target)
+		}
+		if delta.ID != "" {
+			target.ID = delta.ID
+		}
+		if delta.Type != "" {
+			target.Type = delta.Type
+		}
+		target.Function.Name += delta.Function.Name
+		target.Function.Arguments += delta.Function.Arguments
+	}
+	return merged
+}
+
+// FormatToolCalls 将工具调用格式化为文本，用于问答记录
+func FormatToolCalls(calls []*provider.ToolCall) string {
+	if len(calls) == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(calls)
+	return "[tool_calls]: " + string(data)
+}
+
+// BuildCompletionText 构建问答记录中的回答内容，包含模型发起的工具调用
+func BuildCompletionText(content string, calls []*provider.ToolCall) string {
+	if len(calls) == 0 {
+		return content
+	}
+	if content == "" {
+		return FormatToolCalls(calls)
+	}
+	return content + "\n" + FormatToolCalls(calls)
+}
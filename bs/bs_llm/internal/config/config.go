@@ -1,14 +1,100 @@
 package config
 
-import "github.com/zeromicro/go-zero/zrpc"
+import (
+	"jxzy/common/logger"
+
+	"github.com/zeromicro/go-zero/zrpc"
+)
 
 type Config struct {
 	zrpc.RpcServerConf
-	MySQL         MysqlConf `json:",optional"`
-	DoubaoAPIKey  string    `json:",optional"`
-	BailianAPIKey string    `json:",optional"`
+	MySQL                  MysqlConf              `json:",optional"`
+	DoubaoAPIKey           string                 `json:",optional"`
+	BailianAPIKey          string                 `json:",optional"`
+	Providers              []ProviderConf         `json:",optional"`
+	OpenAICompatible       []OpenAICompatibleConf `json:",optional"`   // 旧配置，等同于 ProviderType 为 openai 的 Providers
+	ProviderReloadInterval int                    `json:",default=30"` // llm_provider表热加载间隔（秒），0表示不热加载
+	Quota                  QuotaConf              `json:",optional"`
+	Tokenizers             []TokenizerConf        `json:",optional"`
+	ResponseCache          ResponseCacheConf      `json:",optional"`
+	SceneCacheExpire       int                    `json:",optional"`  // llm_scene 配置缓存时间（秒），默认0表示不缓存；多实例部署时其他实例在缓存过期前仍使用旧配置
+	MaxRepairAttempts      int                    `json:",default=2"` // 结构化输出不符合 response_format 时的修复重试次数
+	Batch                  BatchConf              `json:",optional"`
+	MaxEmbedTexts          int                    `json:",default=1000"` // 单次 Embed 的最大文本数
+	ProviderHealth         ProviderHealthConf     `json:",optional"`
+	PricingReloadInterval  int                    `json:",default=300"` // llm_model_price表热加载间隔（秒），0表示不热加载
+	Redact                 logger.RedactConf      `json:",optional"`    // 日志和问答记录的脱敏规则，场景可通过 llm_scene.redact_patterns 追加
 }
 
 type MysqlConf struct {
 	DataSource string
 }
+
+// ProviderConf 供应商配置，同名时 llm_provider 表中的配置优先
+type ProviderConf struct {
+	ProviderCode  string            // 对应 llm_scene.provider_code
+	ProviderType  string            // 协议类型: doubao/bailian/openai/fake
+	APIEndpoint   string            `json:",optional"` // openai类型填写基础地址，如 http://127.0.0.1:8000/v1；fake类型填写应答脚本路径
+	APIKey        string            `json:",optional"`
+	Headers       map[string]string `json:",optional"`
+	DefaultParams map[string]string `json:",optional"`
+	Timeout       int32             `json:",default=120"`
+	RetryCount    int32             `json:",default=3"`
+}
+
+// OpenAICompatibleConf OpenAI兼容协议供应商配置（vLLM、Ollama、DeepSeek等），新配置请使用 Providers
+type OpenAICompatibleConf struct {
+	ProviderCode string // 对应 llm_scene.provider_code
+	BaseURL      string // 如 http://127.0.0.1:8000/v1
+	APIKey       string `json:",optional"`
+	Timeout      int32  `json:",default=120"`
+}
+
+// QuotaConf 调用配额配置，按 user_id + scene_code 统计，0表示不限制
+type QuotaConf struct {
+	RequestsPerMinute int         `json:",optional"`   // 默认每分钟请求数
+	TokensPerDay      int64       `json:",optional"`   // 默认每日token数
+	SyncInterval      int         `json:",default=60"` // 与 llm_completion 实际用量对账的间隔（秒）
+	Rules             []QuotaRule `json:",optional"`   // 覆盖默认配额的规则，以最具体的匹配为准
+}
+
+// QuotaRule 配额规则
+type QuotaRule struct {
+	SceneCode         string `json:",optional"` // 为空匹配所有场景
+	UserId            string `json:",optional"` // 为空匹配所有用户
+	RequestsPerMinute int    `json:",optional"`
+	TokensPerDay      int64  `json:",optional"`
+}
+
+// TokenizerConf 分词器配置，用于供应商未返回 usage 时计算 token 数量，未匹配的模型按字符数估算
+type TokenizerConf struct {
+	Name       string   // 分词器名称，如 cl100k_base
+	VocabFile  string   // tiktoken 格式的词表文件路径
+	Pattern    string   `json:",default=cl100k"` // 预分词规则: cl100k/qwen
+	ModelCodes []string // 适用的 model_code，以 * 结尾表示前缀匹配
+}
+
+// ResponseCacheConf 非流式响应缓存配置，场景通过 llm_scene.cache_ttl 开启
+type ResponseCacheConf struct {
+	Backend         string `json:",default=memory,options=memory|mysql"` // memory: 进程内LRU；mysql: llm_response_cache表，多实例共享
+	Capacity        int    `json:",default=10000"`                       // memory 后端的最大条目数
+	CleanupInterval int    `json:",default=600"`                         // mysql 后端清理过期缓存的间隔（秒），0表示不清理
+}
+
+// BatchConf 批量调用配置
+type BatchConf struct {
+	MaxItems       int `json:",default=1000"` // 单次 BatchLLM 的最大请求数
+	Concurrency    int `json:",default=4"`    // 每个供应商的默认并发数
+	MaxConcurrency int `json:",default=16"`   // 请求指定的每个供应商并发数上限
+	Workers        int `json:",default=32"`   // 单次 BatchLLM 同时处理的请求数上限，0表示与供应商并发数相同
+}
+
+// ProviderHealthConf 供应商健康检查及熔断配置
+type ProviderHealthConf struct {
+	CheckInterval      int     `json:",default=30"`  // HealthCheck 探测间隔（秒），0表示不主动探测
+	CheckTimeout       int     `json:",default=5"`   // 单次 HealthCheck 的超时时间（秒）
+	Window             int     `json:",default=60"`  // 错误率统计窗口（秒）
+	MinRequests        int     `json:",default=10"`  // 窗口内调用次数达到该值才计算错误率
+	ErrorRateThreshold float64 `json:",default=0.5"` // 错误率达到该值时熔断，0表示不熔断
+	OpenDuration       int     `json:",default=30"`  // 熔断持续时间（秒），到期后放行一次试探调用
+}
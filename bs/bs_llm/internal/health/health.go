@@ -0,0 +1,436 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"jxzy/bs/bs_llm/internal/provider"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 熔断状态
+const (
+	StateClosed   = "closed"    // 正常放行
+	StateOpen     = "open"      // 熔断中，直接拒绝
+	StateHalfOpen = "half_open" // 熔断到期，放行一次试探调用
+)
+
+// 滑动窗口的分桶数
+const windowBuckets = 10
+
+// ErrCircuitOpen 供应商处于熔断状态
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// Options 熔断参数
+type Options struct {
+	Window             time.Duration // 错误率统计窗口
+	MinRequests        int           // 窗口内调用次数达到该值才计算错误率
+	ErrorRateThreshold float64       // 错误率达到该值时熔断，0表示不熔断
+	OpenDuration       time.Duration // 熔断持续时间，到期后进入半开状态
+	CheckTimeout       time.Duration // 单次 HealthCheck 的超时时间
+}
+
+// Status 供应商健康状态快照
+type Status struct {
+	ProviderCode   string
+	State          string
+	ErrorRate      float64 // 窗口内的错误率
+	Requests       int     // 窗口内的调用次数
+	Failures       int     // 窗口内的失败次数
+	LastError      string
+	LastFailureAt  time.Time
+	LastCheckAt    time.Time
+	LastCheckError string
+	OpenedAt       time.Time
+}
+
+// Healthy 未处于熔断状态即视为健康
+func (s *Status) Healthy() bool {
+	return s.State != StateOpen
+}
+
+// Listener 健康状态变化或探测完成时的回调，参数为全部供应商的状态
+type Listener func(statuses []*Status)
+
+// Monitor 供应商健康监控
+// 根据真实调用结果统计滑动窗口内的错误率，错误率过高时熔断，熔断到期后放行一次试探调用，
+// 试探成功则恢复，失败则继续熔断。后台定期调用 Provider.HealthCheck 探测，探测结果与真实调用一样计入滑动窗口，
+// 供应商没有流量时连续的探测失败同样可以触发熔断。
+type Monitor struct {
+	manager *provider.Manager
+	opts    Options
+	now     func() time.Time
+	logger  logx.Logger
+
+	mu        sync.Mutex
+	states    map[string]*state
+	listeners []Listener
+	stopCh    chan struct{}
+}
+
+type state struct {
+	circuit        string
+	openedAt       time.Time
+	trial          bool // 半开状态下的试探调用是否已放行
+	buckets        []bucket
+	lastError      string
+	lastFailureAt  time.Time
+	lastCheckAt    time.Time
+	lastCheckError string
+}
+
+type bucket struct {
+	start    time.Time
+	requests int
+	failures int
+}
+
+// NewMonitor 创建供应商健康监控
+func NewMonitor(manager *provider.Manager, opts Options) *Monitor {
+	if opts.Window <= 0 {
+		opts.Window = time.Minute
+	}
+	if opts.MinRequests <= 0 {
+		opts.MinRequests = 1
+	}
+	if opts.OpenDuration <= 0 {
+		opts.OpenDuration = 30 * time.Second
+	}
+	if opts.CheckTimeout <= 0 {
+		opts.CheckTimeout = 5 * time.Second
+	}
+	return &Monitor{
+		manager: manager,
+		opts:    opts,
+		now:     time.Now,
+		logger:  logx.WithContext(context.Background()),
+		states:  make(map[string]*state),
+	}
+}
+
+// OnChange 注册健康状态回调
+func (m *Monitor) OnChange(listener Listener) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.listeners = append(m.listeners, listener)
+}
+
+// Allow 判断是否可以调用供应商，熔断中返回 ErrCircuitOpen
+// 返回 nil 时调用方必须通过 Record 上报调用结果，以便释放半开状态的试探名额
+func (m *Monitor) Allow(providerCode string) error {
+	m.mu.Lock()
+	s := m.state(providerCode)
+	now := m.now()
+	changed := false
+	switch s.circuit {
+	case StateOpen:
+		if now.Sub(s.openedAt) < m.opts.OpenDuration {
+			m.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		s.circuit = StateHalfOpen
+		s.trial = true
+		changed = true
+		m.logger.Infof("Provider %s circuit half-open, allowing trial call", providerCode)
+	case StateHalfOpen:
+		if s.trial {
+			m.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		s.trial = true
+	}
+	m.mu.Unlock()
+
+	if changed {
+		m.notify()
+	}
+	return nil
+}
+
+// Record 上报一次真实调用的结果
+func (m *Monitor) Record(providerCode string, err error) {
+	m.mu.Lock()
+	s := m.state(providerCode)
+	now := m.now()
+	before := s.circuit
+
+	// 调用方取消的请求不代表供应商的状态
+	if errors.Is(err, context.Canceled) {
+		s.trial = false
+		m.mu.Unlock()
+		return
+	}
+
+	failed := IsFailure(err)
+	if failed {
+		s.lastError = err.Error()
+		s.lastFailureAt = now
+	}
+
+	switch s.circuit {
+	case StateHalfOpen:
+		s.trial = false
+		if failed {
+			m.open(providerCode, s, now)
+		} else {
+			s.circuit = StateClosed
+			s.buckets = nil
+			m.logger.Infof("Provider %s circuit closed after successful trial call", providerCode)
+		}
+	case StateClosed:
+		m.observe(providerCode, s, now, failed)
+	}
+	changed := s.circuit != before
+	m.mu.Unlock()
+
+	if changed {
+		m.notify()
+	}
+}
+
+// Check 对所有已注册的供应商执行一次 HealthCheck
+func (m *Monitor) Check(ctx context.Context) {
+	codes := m.manager.ListProviders()
+
+	var wg sync.WaitGroup
+	results := make([]error, len(codes))
+	for i, code := range codes {
+		llmProvider := m.manager.GetProvider(code)
+		if llmProvider == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, llmProvider provider.Provider) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, m.opts.CheckTimeout)
+			defer cancel()
+			results[i] = llmProvider.HealthCheck(checkCtx)
+		}(i, llmProvider)
+	}
+	wg.Wait()
+
+	m.mu.Lock()
+	now := m.now()
+	registered := make(map[string]bool, len(codes))
+	for i, code := range codes {
+		registered[code] = true
+		s := m.state(code)
+		s.lastCheckAt = now
+		s.lastCheckError = ""
+		failed := probeFailed(results[i])
+		if err := results[i]; err != nil {
+			s.lastCheckError = err.Error()
+			m.logger.Errorf("Provider %s health check failed: %v", code, err)
+		}
+		if failed {
+			s.lastError = results[i].Error()
+			s.lastFailureAt = now
+		}
+		// 熔断和半开状态由真实调用的试探结果决定
+		if s.circuit == StateClosed {
+			m.observe(code, s, now, failed)
+		}
+	}
+	// 移除已摘除的供应商
+	for code := range m.states {
+		if !registered[code] {
+			delete(m.states, code)
+		}
+	}
+	m.mu.Unlock()
+
+	m.notify()
+}
+
+// Start 启动定期健康检查
+func (m *Monitor) Start(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	if m.stopCh != nil {
+		m.mu.Unlock()
+		return
+	}
+	stopCh := make(chan struct{})
+	m.stopCh = stopCh
+	m.mu.Unlock()
+
+	go func() {
+		m.Check(context.Background())
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.Check(context.Background())
+			case <-stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止定期健康检查
+func (m *Monitor) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stopCh != nil {
+		close(m.stopCh)
+		m.stopCh = nil
+	}
+}
+
+// Status 获取单个供应商的健康状态
+func (m *Monitor) Status(providerCode string) *Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.snapshot(providerCode, m.state(providerCode))
+}
+
+// List 获取所有已注册供应商的健康状态，按供应商编码排序
+func (m *Monitor) List() []*Status {
+	codes := m.manager.ListProviders()
+	sort.Strings(codes)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	statuses := make([]*Status, 0, len(codes))
+	for _, code := range codes {
+		statuses = append(statuses, m.snapshot(code, m.state(code)))
+	}
+	return statuses
+}
+
+// IsFailure 判断调用错误是否计入供应商的错误率：可重试错误（429、5xx、超时、网络错误）及鉴权失败
+// 请求参数错误等其他4xx说明供应商可以正常响应，不计入
+func IsFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if provider.IsRetryable(err) {
+		return true
+	}
+	var statusErr *provider.StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusUnauthorized || statusErr.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
+// probeFailed 判断探测错误是否计入错误率，除参数错误等说明供应商可以正常响应的4xx外都计入
+func probeFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+	var statusErr *provider.StatusError
+	return IsFailure(err) || !errors.As(err, &statusErr)
+}
+
+// observe 将一次调用或探测结果计入滑动窗口，错误率达到阈值时熔断，调用方需持有锁
+func (m *Monitor) observe(providerCode string, s *state, now time.Time, failed bool) {
+	b := m.bucket(s, now)
+	b.requests++
+	if failed {
+		b.failures++
+	}
+	if m.opts.ErrorRateThreshold > 0 {
+		requests, failures := m.count(s, now)
+		if requests >= m.opts.MinRequests && float64(failures)/float64(requests) >= m.opts.ErrorRateThreshold {
+			m.open(providerCode, s, now)
+		}
+	}
+}
+
+// state 获取供应商状态，调用方需持有锁
+func (m *Monitor) state(providerCode string) *state {
+	s, ok := m.states[providerCode]
+	if !ok {
+		s = &state{circuit: StateClosed}
+		m.states[providerCode] = s
+	}
+	return s
+}
+
+// open 熔断供应商，调用方需持有锁
+func (m *Monitor) open(providerCode string, s *state, now time.Time) {
+	s.circuit = StateOpen
+	s.openedAt = now
+	s.trial = false
+	m.logger.Errorf("Provider %s circuit opened, last error: %s", providerCode, s.lastError)
+}
+
+// bucket 获取当前时间所在的统计桶，并清理窗口外的桶，调用方需持有锁
+func (m *Monitor) bucket(s *state, now time.Time) *bucket {
+	width := m.opts.Window / windowBuckets
+	start := now.Truncate(width)
+	m.prune(s, now)
+	if n := len(s.buckets); n > 0 && s.buckets[n-1].start.Equal(start) {
+		return &s.buckets[n-1]
+	}
+	s.buckets = append(s.buckets, bucket{start: start})
+	return &s.buckets[len(s.buckets)-1]
+}
+
+// count 统计窗口内的调用次数和失败次数，调用方需持有锁
+func (m *Monitor) count(s *state, now time.Time) (int, int) {
+	m.prune(s, now)
+	requests, failures := 0, 0
+	for _, b := range s.buckets {
+		requests += b.requests
+		failures += b.failures
+	}
+	return requests, failures
+}
+
+// prune 清理窗口外的统计桶，调用方需持有锁
+func (m *Monitor) prune(s *state, now time.Time) {
+	width := m.opts.Window / windowBuckets
+	cutoff := now.Add(-m.opts.Window)
+	i := 0
+	for i < len(s.buckets) && !s.buckets[i].start.Add(width).After(cutoff) {
+		i++
+	}
+	s.buckets = s.buckets[i:]
+}
+
+// snapshot 生成状态快照，调用方需持有锁
+func (m *Monitor) snapshot(providerCode string, s *state) *Status {
+	requests, failures := m.count(s, m.now())
+	status := &Status{
+		ProviderCode:   providerCode,
+		State:          s.circuit,
+		Requests:       requests,
+		Failures:       failures,
+		LastError:      s.lastError,
+		LastFailureAt:  s.lastFailureAt,
+		LastCheckAt:    s.lastCheckAt,
+		LastCheckError: s.lastCheckError,
+		OpenedAt:       s.openedAt,
+	}
+	if requests > 0 {
+		status.ErrorRate = float64(failures) / float64(requests)
+	}
+	return status
+}
+
+// notify 通知所有回调
+func (m *Monitor) notify() {
+	m.mu.Lock()
+	listeners := append([]Listener(nil), m.listeners...)
+	m.mu.Unlock()
+	if len(listeners) == 0 {
+		return
+	}
+
+	statuses := m.List()
+	for _, listener := range listeners {
+		listener(statuses)
+	}
+}
@@ -0,0 +1,134 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"jxzy/bs/bs_llm/internal/provider"
+)
+
+// checkProvider HealthCheck 返回预设错误的测试供应商
+type checkProvider struct {
+	provider.Provider
+	err error
+}
+
+func (p *checkProvider) HealthCheck(ctx context.Context) error { return p.err }
+
+func newTestMonitor(manager *provider.Manager) (*Monitor, *time.Time) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := NewMonitor(manager, Options{
+		Window:             time.Minute,
+		MinRequests:        4,
+		ErrorRateThreshold: 0.5,
+		OpenDuration:       30 * time.Second,
+	})
+	m.now = func() time.Time { return now }
+	return m, &now
+}
+
+func TestMonitorCircuitBreaker(t *testing.T) {
+	m, now := newTestMonitor(provider.NewManager())
+	serverErr := &provider.StatusError{StatusCode: http.StatusBadGateway}
+
+	// 请求参数错误和调用方取消不计入错误率
+	m.Record("doubao", nil)
+	m.Record("doubao", &provider.StatusError{StatusCode: http.StatusBadRequest})
+	m.Record("doubao", context.Canceled)
+	m.Record("doubao", serverErr)
+	if status := m.Status("doubao"); status.State != StateClosed || status.Requests != 3 || status.Failures != 1 {
+		t.Fatalf("Expected closed circuit with 1/3 failures, got %+v", status)
+	}
+
+	m.Record("doubao", serverErr)
+	if status := m.Status("doubao"); status.State != StateOpen || status.Healthy() {
+		t.Fatalf("Expected circuit to open at 50%% error rate, got %+v", status)
+	}
+	if err := m.Allow("doubao"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got %v", err)
+	}
+
+	// 熔断到期后只放行一次试探调用，试探失败继续熔断
+	*now = now.Add(31 * time.Second)
+	if err := m.Allow("doubao"); err != nil {
+		t.Fatalf("Expected trial call to be allowed, got %v", err)
+	}
+	if err := m.Allow("doubao"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected only one trial call in half-open state, got %v", err)
+	}
+	m.Record("doubao", serverErr)
+	if status := m.Status("doubao"); status.State != StateOpen {
+		t.Fatalf("Expected failed trial to reopen circuit, got %+v", status)
+	}
+
+	// 试探成功后恢复
+	*now = now.Add(31 * time.Second)
+	if err := m.Allow("doubao"); err != nil {
+		t.Fatalf("Expected trial call to be allowed, got %v", err)
+	}
+	m.Record("doubao", nil)
+	if status := m.Status("doubao"); status.State != StateClosed || status.Requests != 0 {
+		t.Errorf("Expected successful trial to close circuit with reset window, got %+v", status)
+	}
+}
+
+func TestMonitorWindow(t *testing.T) {
+	m, now := newTestMonitor(provider.NewManager())
+	serverErr := &provider.StatusError{StatusCode: http.StatusServiceUnavailable}
+
+	m.Record("bailian", serverErr)
+	m.Record("bailian", serverErr)
+	*now = now.Add(2 * time.Minute)
+	m.Record("bailian", nil)
+	m.Record("bailian", serverErr)
+	if status := m.Status("bailian"); status.State != StateClosed || status.Requests != 2 {
+		t.Errorf("Expected failures outside the window to be dropped, got %+v", status)
+	}
+}
+
+func TestMonitorCheck(t *testing.T) {
+	manager := provider.NewManager()
+	manager.Register("healthy", &checkProvider{})
+	manager.Register("broken", &checkProvider{err: errors.New("connection refused")})
+	m, now := newTestMonitor(manager)
+
+	var notified []*Status
+	m.OnChange(func(statuses []*Status) { notified = statuses })
+
+	// 探测失败计入滑动窗口，未达到 MinRequests 前不熔断
+	for i := 0; i < 3; i++ {
+		m.Check(context.Background())
+		*now = now.Add(time.Second)
+	}
+	if len(notified) != 2 {
+		t.Fatalf("Expected 2 provider statuses, got %d", len(notified))
+	}
+	broken, healthy := notified[0], notified[1]
+	if broken.ProviderCode != "broken" || broken.State != StateClosed || broken.Failures != 3 || broken.LastCheckError == "" {
+		t.Errorf("Expected broken provider to stay closed below MinRequests, got %+v", broken)
+	}
+	if healthy.ProviderCode != "healthy" || healthy.State != StateClosed || healthy.Requests != 3 || healthy.LastCheckAt.IsZero() {
+		t.Errorf("Expected healthy provider to stay closed, got %+v", healthy)
+	}
+
+	m.Check(context.Background())
+	if broken := notified[0]; broken.State != StateOpen {
+		t.Errorf("Expected broken provider to be open after repeated failed checks, got %+v", broken)
+	}
+}
+
+func TestMonitorCheckWithoutThreshold(t *testing.T) {
+	manager := provider.NewManager()
+	manager.Register("broken", &checkProvider{err: errors.New("connection refused")})
+	m := NewMonitor(manager, Options{MinRequests: 1})
+
+	for i := 0; i < 5; i++ {
+		m.Check(context.Background())
+	}
+	if status := m.Status("broken"); status.State != StateClosed || status.Failures != 5 {
+		t.Errorf("Expected no circuit breaking when threshold is 0, got %+v", status)
+	}
+}
@@ -0,0 +1,453 @@
+// Package jsonschema 实现结构化输出校验所需的 JSON Schema 子集
+//
+// 支持 type、enum、const、properties、required、additionalProperties、items、
+// minItems/maxItems、minLength/maxLength、pattern、minimum/maximum 以及 allOf/anyOf/oneOf，
+// 以及指向 #/definitions、#/$defs 的本地 $ref。
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Schema 编译后的 JSON Schema
+type Schema struct {
+	root *node
+}
+
+// node Schema 节点，boolean Schema 用 always 表示
+type node struct {
+	always *bool
+
+	types                []string
+	enum                 []interface{}
+	constVal             interface{}
+	hasConst             bool
+	properties           map[string]*node
+	required             []string
+	additionalProperties *node
+	items                *node
+	minItems, maxItems   *int
+	minLength, maxLength *int
+	pattern              *regexp.Regexp
+	minimum, maximum     *float64
+	allOf, anyOf, oneOf  []*node
+	ref                  string
+}
+
+// rawNode Schema 的 JSON 表示
+type rawNode struct {
+	Type                 json.RawMessage            `json:"type"`
+	Enum                 []interface{}              `json:"enum"`
+	Const                json.RawMessage            `json:"const"`
+	Properties           map[string]json.RawMessage `json:"properties"`
+	Required             []string                   `json:"required"`
+	AdditionalProperties json.RawMessage            `json:"additionalProperties"`
+	Items                json.RawMessage            `json:"items"`
+	MinItems             *int                       `json:"minItems"`
+	MaxItems             *int                       `json:"maxItems"`
+	MinLength            *int                       `json:"minLength"`
+	MaxLength            *int                       `json:"maxLength"`
+	Pattern              string                     `json:"pattern"`
+	Minimum              *float64                   `json:"minimum"`
+	Maximum              *float64                   `json:"maximum"`
+	AllOf                []json.RawMessage          `json:"allOf"`
+	AnyOf                []json.RawMessage          `json:"anyOf"`
+	OneOf                []json.RawMessage          `json:"oneOf"`
+	Ref                  string                     `json:"$ref"`
+	Definitions          map[string]json.RawMessage `json:"definitions"`
+	Defs                 map[string]json.RawMessage `json:"$defs"`
+}
+
+// compiler 编译上下文，记录本地定义供 $ref 解析
+type compiler struct {
+	defs map[string]*node
+}
+
+// Compile 编译 JSON Schema
+func Compile(data []byte) (*Schema, error) {
+	c := &compiler{defs: make(map[string]*node)}
+	root, err := c.compile(data, "#")
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkRefs(root, make(map[*node]bool)); err != nil {
+		return nil, err
+	}
+	return &Schema{root: root}, nil
+}
+
+func (c *compiler) compile(data []byte, path string) (*node, error) {
+	data = bytes.TrimSpace(data)
+	if string(data) == "true" || string(data) == "false" {
+		always := string(data) == "true"
+		return &node{always: &always}, nil
+	}
+
+	var raw rawNode
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid schema at %s: %w", path, err)
+	}
+
+	n := &node{
+		enum:      raw.Enum,
+		required:  raw.Required,
+		minItems:  raw.MinItems,
+		maxItems:  raw.MaxItems,
+		minLength: raw.MinLength,
+		maxLength: raw.MaxLength,
+		minimum:   raw.Minimum,
+		maximum:   raw.Maximum,
+		ref:       raw.Ref,
+	}
+
+	if len(raw.Type) > 0 {
+		var single string
+		if err := json.Unmarshal(raw.Type, &single); err == nil {
+			n.types = []string{single}
+		} else if err := json.Unmarshal(raw.Type, &n.types); err != nil {
+			return nil, fmt.Errorf("invalid type at %s", path)
+		}
+		for _, t := range n.types {
+			switch t {
+			case "object", "array", "string", "number", "integer", "boolean", "null":
+			default:
+				return nil, fmt.Errorf("unsupported type '%s' at %s", t, path)
+			}
+		}
+	}
+	if len(raw.Const) > 0 {
+		if err := json.Unmarshal(raw.Const, &n.constVal); err != nil {
+			return nil, fmt.Errorf("invalid const at %s: %w", path, err)
+		}
+		n.hasConst = true
+	}
+	if raw.Pattern != "" {
+		re, err := regexp.Compile(raw.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern at %s: %w", path, err)
+		}
+		n.pattern = re
+	}
+
+	for name, sub := range raw.Definitions {
+		def, err := c.compile(sub, path+"/definitions/"+name)
+		if err != nil {
+			return nil, err
+		}
+		c.defs["#/definitions/"+name] = def
+	}
+	for name, sub := range raw.Defs {
+		def, err := c.compile(sub, path+"/$defs/"+name)
+		if err != nil {
+			return nil, err
+		}
+		c.defs["#/$defs/"+name] = def
+	}
+
+	if len(raw.Properties) > 0 {
+		n.properties = make(map[string]*node, len(raw.Properties))
+		for name, sub := range raw.Properties {
+			prop, err := c.compile(sub, path+"/properties/"+name)
+			if err != nil {
+				return nil, err
+			}
+			n.properties[name] = prop
+		}
+	}
+	var err error
+	if len(raw.AdditionalProperties) > 0 {
+		if n.additionalProperties, err = c.compile(raw.AdditionalProperties, path+"/additionalProperties"); err != nil {
+			return nil, err
+		}
+	}
+	if len(raw.Items) > 0 {
+		if n.items, err = c.compile(raw.Items, path+"/items"); err != nil {
+			return nil, err
+		}
+	}
+	if n.allOf, err = c.compileList(raw.AllOf, path+"/allOf"); err != nil {
+		return nil, err
+	}
+	if n.anyOf, err = c.compileList(raw.AnyOf, path+"/anyOf"); err != nil {
+		return nil, err
+	}
+	if n.oneOf, err = c.compileList(raw.OneOf, path+"/oneOf"); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (c *compiler) compileList(list []json.RawMessage, path string) ([]*node, error) {
+	var nodes []*node
+	for i, sub := range list {
+		n, err := c.compile(sub, fmt.Sprintf("%s/%d", path, i))
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+// checkRefs 检查所有 $ref 都指向已定义的本地 Schema
+func (c *compiler) checkRefs(n *node, visited map[*node]bool) error {
+	if n == nil || visited[n] {
+		return nil
+	}
+	visited[n] = true
+	if n.ref != "" {
+		def, ok := c.defs[n.ref]
+		if !ok {
+			return fmt.Errorf("unresolved $ref '%s'", n.ref)
+		}
+		n.allOf = append(n.allOf, def)
+		n.ref = ""
+	}
+	children := append([]*node{n.additionalProperties, n.items}, n.allOf...)
+	children = append(children, n.anyOf...)
+	children = append(children, n.oneOf...)
+	for _, prop := range n.properties {
+		children = append(children, prop)
+	}
+	for _, child := range children {
+		if err := c.checkRefs(child, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidationError 校验失败的详情，每条错误包含 JSON Pointer 形式的位置
+type ValidationError struct {
+	Errors []string
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Errors, "; ")
+}
+
+// Validate 校验已解析的 JSON 值（json.Unmarshal 到 interface{} 的结果）
+func (s *Schema) Validate(v interface{}) error {
+	var errs []string
+	s.root.validate(v, "", &errs)
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+	return nil
+}
+
+// ValidateJSON 解析并校验 JSON 文本
+func (s *Schema) ValidateJSON(data []byte) error {
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+	if decoder.More() {
+		return fmt.Errorf("invalid JSON: unexpected data after top-level value")
+	}
+	return s.Validate(normalize(v))
+}
+
+// normalize 将 json.Number 转为 float64
+func normalize(v interface{}) interface{} {
+	switch val := v.(type) {
+	case json.Number:
+		f, _ := val.Float64()
+		return f
+	case map[string]interface{}:
+		for k, item := range val {
+			val[k] = normalize(item)
+		}
+	case []interface{}:
+		for i, item := range val {
+			val[i] = normalize(item)
+		}
+	}
+	return v
+}
+
+func (n *node) validate(v interface{}, path string, errs *[]string) {
+	if n.always != nil {
+		if !*n.always {
+			*errs = append(*errs, fmt.Sprintf("%s: value is not allowed", pointer(path)))
+		}
+		return
+	}
+
+	if len(n.types) > 0 && !matchesType(v, n.types) {
+		*errs = append(*errs, fmt.Sprintf("%s: expected %s, got %s", pointer(path), strings.Join(n.types, " or "), typeOf(v)))
+		return
+	}
+	if len(n.enum) > 0 && !containsValue(n.enum, v) {
+		*errs = append(*errs, fmt.Sprintf("%s: value must be one of %s", pointer(path), marshal(n.enum)))
+	}
+	if n.hasConst && !equal(n.constVal, v) {
+		*errs = append(*errs, fmt.Sprintf("%s: value must be %s", pointer(path), marshal(n.constVal)))
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		n.validateObject(val, path, errs)
+	case []interface{}:
+		if n.minItems != nil && len(val) < *n.minItems {
+			*errs = append(*errs, fmt.Sprintf("%s: expected at least %d items, got %d", pointer(path), *n.minItems, len(val)))
+		}
+		if n.maxItems != nil && len(val) > *n.maxItems {
+			*errs = append(*errs, fmt.Sprintf("%s: expected at most %d items, got %d", pointer(path), *n.maxItems, len(val)))
+		}
+		if n.items != nil {
+			for i, item := range val {
+				n.items.validate(item, fmt.Sprintf("%s/%d", path, i), errs)
+			}
+		}
+	case string:
+		length := len([]rune(val))
+		if n.minLength != nil && length < *n.minLength {
+			*errs = append(*errs, fmt.Sprintf("%s: expected length >= %d, got %d", pointer(path), *n.minLength, length))
+		}
+		if n.maxLength != nil && length > *n.maxLength {
+			*errs = append(*errs, fmt.Sprintf("%s: expected length <= %d, got %d", pointer(path), *n.maxLength, length))
+		}
+		if n.pattern != nil && !n.pattern.MatchString(val) {
+			*errs = append(*errs, fmt.Sprintf("%s: does not match pattern '%s'", pointer(path), n.pattern.String()))
+		}
+	case float64:
+		if n.minimum != nil && val < *n.minimum {
+			*errs = append(*errs, fmt.Sprintf("%s: expected >= %v, got %v", pointer(path), *n.minimum, val))
+		}
+		if n.maximum != nil && val > *n.maximum {
+			*errs = append(*errs, fmt.Sprintf("%s: expected <= %v, got %v", pointer(path), *n.maximum, val))
+		}
+	}
+
+	for _, sub := range n.allOf {
+		sub.validate(v, path, errs)
+	}
+	if len(n.anyOf) > 0 {
+		matched := false
+		for _, sub := range n.anyOf {
+			if sub.matches(v, path) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			*errs = append(*errs, fmt.Sprintf("%s: value does not match any schema in anyOf", pointer(path)))
+		}
+	}
+	if len(n.oneOf) > 0 {
+		count := 0
+		for _, sub := range n.oneOf {
+			if sub.matches(v, path) {
+				count++
+			}
+		}
+		if count != 1 {
+			*errs = append(*errs, fmt.Sprintf("%s: value must match exactly one schema in oneOf, matched %d", pointer(path), count))
+		}
+	}
+}
+
+func (n *node) validateObject(obj map[string]interface{}, path string, errs *[]string) {
+	for _, name := range n.required {
+		if _, ok := obj[name]; !ok {
+			*errs = append(*errs, fmt.Sprintf("%s: missing required property '%s'", pointer(path), name))
+		}
+	}
+
+	// 按属性名排序，保证错误信息稳定
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		childPath := path + "/" + escape(name)
+		if prop, ok := n.properties[name]; ok {
+			prop.validate(obj[name], childPath, errs)
+		} else if n.additionalProperties != nil {
+			if n.additionalProperties.always != nil && !*n.additionalProperties.always {
+				*errs = append(*errs, fmt.Sprintf("%s: unexpected property '%s'", pointer(path), name))
+				continue
+			}
+			n.additionalProperties.validate(obj[name], childPath, errs)
+		}
+	}
+}
+
+func (n *node) matches(v interface{}, path string) bool {
+	var errs []string
+	n.validate(v, path, &errs)
+	return len(errs) == 0
+}
+
+func matchesType(v interface{}, types []string) bool {
+	actual := typeOf(v)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func typeOf(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if val == math.Trunc(val) && !math.IsInf(val, 0) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func containsValue(list []interface{}, v interface{}) bool {
+	for _, item := range list {
+		if equal(item, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// equal 按 JSON 语义比较两个值
+func equal(a, b interface{}) bool {
+	return marshal(a) == marshal(b)
+}
+
+func marshal(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// pointer 返回 JSON Pointer，根节点为 "/"
+func pointer(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+func escape(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
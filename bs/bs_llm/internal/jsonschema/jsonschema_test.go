@@ -0,0 +1,91 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+)
+
+const sentencesSchema = `{
+	"type": "object",
+	"properties": {
+		"sentences": {
+			"type": "array",
+			"items": {"$ref": "#/$defs/sentence"},
+			"minItems": 1
+		},
+		"language": {"enum": ["zh", "en"]}
+	},
+	"required": ["sentences"],
+	"additionalProperties": false,
+	"$defs": {
+		"sentence": {
+			"type": "object",
+			"properties": {
+				"text": {"type": "string", "minLength": 1},
+				"score": {"type": "number", "minimum": 0, "maximum": 1}
+			},
+			"required": ["text"]
+		}
+	}
+}`
+
+func TestValidateJSON(t *testing.T) {
+	schema, err := Compile([]byte(sentencesSchema))
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	if err := schema.ValidateJSON([]byte(`{"sentences":[{"text":"你好","score":0.5}],"language":"zh"}`)); err != nil {
+		t.Errorf("Expected valid document, got %v", err)
+	}
+
+	cases := map[string]string{
+		`{"sentences":[]}`:                          "at least 1 items",
+		`{"sentences":[{"score":0.5}]}`:             "/sentences/0: missing required property 'text'",
+		`{"sentences":[{"text":"a","score":2}]}`:    "/sentences/0/score: expected <= 1",
+		`{"sentences":[{"text":"a"}],"extra":1}`:    "unexpected property 'extra'",
+		`{"sentences":[{"text":"a"}],"language":1}`: "/language: value must be one of",
+		`{"sentences":"a"}`:                         "/sentences: expected array, got string",
+		`{"sentences":[{"text":"a"}]} trailing`:     "invalid JSON",
+		"```json\n{}\n```":                          "invalid JSON",
+	}
+	for doc, want := range cases {
+		err := schema.ValidateJSON([]byte(doc))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateJSON(%s): expected error containing '%s', got %v", doc, want, err)
+		}
+	}
+}
+
+func TestCompositionAndTypes(t *testing.T) {
+	schema, err := Compile([]byte(`{
+		"type": ["integer", "string"],
+		"oneOf": [{"type": "integer", "minimum": 10}, {"type": "string", "pattern": "^[a-z]+$"}]
+	}`))
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	for _, doc := range []string{`12`, `"abc"`} {
+		if err := schema.ValidateJSON([]byte(doc)); err != nil {
+			t.Errorf("Expected %s to be valid, got %v", doc, err)
+		}
+	}
+	for _, doc := range []string{`1.5`, `3`, `"ABC"`, `null`} {
+		if err := schema.ValidateJSON([]byte(doc)); err == nil {
+			t.Errorf("Expected %s to be invalid", doc)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, doc := range []string{
+		`{"type": "map"}`,
+		`{"$ref": "#/$defs/missing"}`,
+		`{"pattern": "("}`,
+		`not json`,
+	} {
+		if _, err := Compile([]byte(doc)); err == nil {
+			t.Errorf("Expected compile error for %s", doc)
+		}
+	}
+}
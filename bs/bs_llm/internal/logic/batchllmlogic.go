@@ -0,0 +1,169 @@
+package logic
+
+import (
+	"context"
+	"sync"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/common"
+	"jxzy/bs/bs_llm/internal/svc"
+	"jxzy/common/errorx"
+	"jxzy/common/logger"
+
+	"github.com/google/uuid"
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type BatchLLMLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewBatchLLMLogic(ctx context.Context, svcCtx *svc.ServiceContext) *BatchLLMLogic {
+	return &BatchLLMLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logger.WithRedactor(logger.NewServiceLogger("bs-llm").WithContext(ctx), svcCtx.Redactor),
+	}
+}
+
+// 批量LLM调用，按供应商限制并发，按请求顺序流式返回每条结果
+// 每条请求按非流式调用处理并记录到 llm_completion，单条失败不影响其他请求
+func (l *BatchLLMLogic) BatchLLM(in *bs_llm.BatchLLMRequest, stream bs_llm.BsLlmService_BatchLLMServer) error {
+	if len(in.Requests) == 0 {
+		return errorx.NewCodeError(errorx.ErrCodeParamError, "requests is required")
+	}
+	if maxItems := l.svcCtx.Config.Batch.MaxItems; maxItems > 0 && len(in.Requests) > maxItems {
+		return errorx.NewCodeErrorf(errorx.ErrCodeParamError, "too many requests: %d, max %d", len(in.Requests), maxItems)
+	}
+	concurrency := int(in.Concurrency)
+	if concurrency <= 0 {
+		concurrency = l.svcCtx.Config.Batch.Concurrency
+	}
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	if maxConcurrency := l.svcCtx.Config.Batch.MaxConcurrency; maxConcurrency > 0 && concurrency > maxConcurrency {
+		concurrency = maxConcurrency
+	}
+	// 固定数量的 worker 按顺序领取请求，查询场景配置和等待供应商并发名额的请求数都不超过 worker 数
+	workers := l.svcCtx.Config.Batch.Workers
+	if workers < concurrency {
+		workers = concurrency
+	}
+	if workers > len(in.Requests) {
+		workers = len(in.Requests)
+	}
+
+	batchId := uuid.New().String()
+	l.Logger.Infof("BatchLLM called - BatchId: %s, Requests: %d, Concurrency: %d, Workers: %d", batchId, len(in.Requests), concurrency, workers)
+
+	// 客户端断开时取消未完成的请求
+	ctx, cancel := context.WithCancel(l.ctx)
+	defer cancel()
+
+	limiter := newProviderLimiter(concurrency)
+	results := make([]chan *bs_llm.BatchLLMResponse, len(in.Requests))
+	for i := range results {
+		results[i] = make(chan *bs_llm.BatchLLMResponse, 1)
+	}
+	indexes := make(chan int, len(in.Requests))
+	for i := range in.Requests {
+		indexes <- i
+	}
+	close(indexes)
+	for w := 0; w < workers; w++ {
+		go func() {
+			for index := range indexes {
+				if ctx.Err() != nil {
+					return
+				}
+				results[index] <- l.callItem(ctx, limiter, batchId, index, in.Requests[index])
+			}
+		}()
+	}
+
+	// 按请求顺序返回，先完成的结果等待前面的请求
+	for i := range results {
+		var item *bs_llm.BatchLLMResponse
+		select {
+		case item = <-results[i]:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if err := stream.Send(item); err != nil {
+			l.Logger.Errorf("Failed to send batch result %d: %v", i, err)
+			return err
+		}
+	}
+
+	l.Logger.Infof("BatchLLM completed - BatchId: %s", batchId)
+	return nil
+}
+
+// callItem 在场景主供应商的并发限制内执行单条请求
+func (l *BatchLLMLogic) callItem(ctx context.Context, limiter *providerLimiter, batchId string, index int, req *bs_llm.LLMRequest) *bs_llm.BatchLLMResponse {
+	item := &bs_llm.BatchLLMResponse{Index: int32(index), BatchId: batchId}
+
+	// 场景不存在时不占用并发，由 LLMLogic 记录失败；场景配置了路由规则时按路由后的供应商限制并发
+	providerCode := ""
+	llmCommon := common.NewLLMCommon(ctx, l.svcCtx)
+	llmCommon.SetRouteRequest(req.Messages, llmCommon.GetOrDefaultUserId(req.UserId))
+	if scene, err := llmCommon.GetSceneConfig(req.SceneCode); err == nil {
+		providerCode = scene.ProviderCode
+	}
+	if providerCode != "" {
+		release, err := limiter.acquire(ctx, providerCode)
+		if err != nil {
+			item.ErrorCode, item.ErrorMsg = batchError(err)
+			return item
+		}
+		defer release()
+	}
+
+	resp, err := NewLLMLogic(ctx, l.svcCtx).WithBatchId(batchId).LLM(req)
+	if err != nil {
+		item.ErrorCode, item.ErrorMsg = batchError(err)
+		return item
+	}
+	item.Response = resp
+	return item
+}
+
+// batchError 单条请求的错误码和错误信息，非业务错误记为 LLM请求失败
+func batchError(err error) (int32, string) {
+	if codeErr, ok := errorx.FromError(err); ok {
+		return int32(codeErr.Code), codeErr.Msg
+	}
+	return errorx.ErrCodeLLMRequestFailed, err.Error()
+}
+
+// providerLimiter 按供应商限制并发
+type providerLimiter struct {
+	mu          sync.Mutex
+	concurrency int
+	slots       map[string]chan struct{}
+}
+
+func newProviderLimiter(concurrency int) *providerLimiter {
+	return &providerLimiter{concurrency: concurrency, slots: make(map[string]chan struct{})}
+}
+
+// acquire 占用供应商的一个并发名额，返回释放函数
+func (p *providerLimiter) acquire(ctx context.Context, providerCode string) (func(), error) {
+	p.mu.Lock()
+	slot, ok := p.slots[providerCode]
+	if !ok {
+		slot = make(chan struct{}, p.concurrency)
+		p.slots[providerCode] = slot
+	}
+	p.mu.Unlock()
+
+	select {
+	case slot <- struct{}{}:
+		return func() { <-slot }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,138 @@
+package logic
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/provider"
+	"jxzy/common/errorx"
+
+	"google.golang.org/grpc"
+)
+
+// echoProvider 返回最后一条消息的内容，并记录最大并发数
+type echoProvider struct {
+	blockingProvider
+	inflight    int32
+	maxInflight int32
+}
+
+func (p *echoProvider) CallLLM(ctx context.Context, req *provider.LLMRequest) (*provider.LLMResponse, error) {
+	n := atomic.AddInt32(&p.inflight, 1)
+	defer atomic.AddInt32(&p.inflight, -1)
+	for {
+		max := atomic.LoadInt32(&p.maxInflight)
+		if n <= max || atomic.CompareAndSwapInt32(&p.maxInflight, max, n) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	return &provider.LLMResponse{Content: req.Messages[len(req.Messages)-1].Content, ModelCode: req.ModelCode}, nil
+}
+
+type fakeBatchServer struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent []*bs_llm.BatchLLMResponse
+}
+
+func (s *fakeBatchServer) Context() context.Context { return s.ctx }
+
+func (s *fakeBatchServer) Send(resp *bs_llm.BatchLLMResponse) error {
+	s.sent = append(s.sent, resp)
+	return nil
+}
+
+func TestBatchLLM(t *testing.T) {
+	p := &echoProvider{}
+	svcCtx, completions := newStreamTestContext(p)
+	stream := &fakeBatchServer{ctx: context.Background()}
+
+	in := &bs_llm.BatchLLMRequest{Concurrency: 2}
+	for _, content := range []string{"a", "b", "c", "d", "e"} {
+		in.Requests = append(in.Requests, &bs_llm.LLMRequest{
+			SceneCode: "chat_general",
+			Messages:  []*bs_llm.ChatMessage{{Role: "user", Content: content}},
+		})
+	}
+	in.Requests[2].SceneCode = "" // 单条失败不影响其他请求
+
+	if err := NewBatchLLMLogic(context.Background(), svcCtx).BatchLLM(in, stream); err != nil {
+		t.Fatalf("BatchLLM failed: %v", err)
+	}
+
+	if len(stream.sent) != 5 {
+		t.Fatalf("Expected 5 results, got %d", len(stream.sent))
+	}
+	batchId := stream.sent[0].BatchId
+	for i, item := range stream.sent {
+		if int(item.Index) != i || item.BatchId != batchId {
+			t.Errorf("Expected result %d in order with batch id %s, got %+v", i, batchId, item)
+		}
+		if i == 2 {
+			if item.ErrorCode != errorx.ErrCodeLLMRequestFailed || item.Response != nil {
+				t.Errorf("Expected item 2 to fail, got %+v", item)
+			}
+			continue
+		}
+		if item.ErrorCode != 0 || item.Response.Completion != in.Requests[i].Messages[0].Content {
+			t.Errorf("Expected item %d to echo its request, got %+v", i, item)
+		}
+	}
+	if max := atomic.LoadInt32(&p.maxInflight); max > 2 {
+		t.Errorf("Expected at most 2 concurrent provider calls, got %d", max)
+	}
+
+	if len(completions.inserted) != 5 {
+		t.Fatalf("Expected 5 completion records, got %d", len(completions.inserted))
+	}
+	for _, completion := range completions.inserted {
+		if completion.BatchId != batchId {
+			t.Errorf("Expected batch id %s, got %s", batchId, completion.BatchId)
+		}
+	}
+}
+
+func TestBatchLLMValidation(t *testing.T) {
+	svcCtx, _ := newStreamTestContext(&echoProvider{})
+	svcCtx.Config.Batch.MaxItems = 1
+	stream := &fakeBatchServer{ctx: context.Background()}
+
+	for _, in := range []*bs_llm.BatchLLMRequest{
+		{},
+		{Requests: []*bs_llm.LLMRequest{{SceneCode: "a"}, {SceneCode: "b"}}},
+	} {
+		err := NewBatchLLMLogic(context.Background(), svcCtx).BatchLLM(in, stream)
+		if codeErr, ok := errorx.FromError(err); !ok || codeErr.Code != errorx.ErrCodeParamError {
+			t.Errorf("Expected param error, got %v", err)
+		}
+	}
+}
+
+func TestBatchLLMConcurrencyCap(t *testing.T) {
+	p := &echoProvider{}
+	svcCtx, _ := newStreamTestContext(p)
+	svcCtx.Config.Batch.MaxConcurrency = 3
+	svcCtx.Config.Batch.Workers = 4
+	stream := &fakeBatchServer{ctx: context.Background()}
+
+	in := &bs_llm.BatchLLMRequest{Concurrency: 1000}
+	for i := 0; i < 20; i++ {
+		in.Requests = append(in.Requests, &bs_llm.LLMRequest{
+			SceneCode: "chat_general",
+			Messages:  []*bs_llm.ChatMessage{{Role: "user", Content: "hi"}},
+		})
+	}
+	if err := NewBatchLLMLogic(context.Background(), svcCtx).BatchLLM(in, stream); err != nil {
+		t.Fatalf("BatchLLM failed: %v", err)
+	}
+	if len(stream.sent) != 20 {
+		t.Fatalf("Expected 20 results, got %d", len(stream.sent))
+	}
+	if max := atomic.LoadInt32(&p.maxInflight); max > 3 {
+		t.Errorf("Expected concurrency capped at 3, got %d", max)
+	}
+}
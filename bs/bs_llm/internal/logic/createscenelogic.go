@@ -0,0 +1,67 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/bs/bs_llm/internal/svc"
+	"jxzy/common/errorx"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type CreateSceneLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewCreateSceneLogic(ctx context.Context, svcCtx *svc.ServiceContext) *CreateSceneLogic {
+	return &CreateSceneLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 创建场景，同编码的场景已被软删除时复用原记录
+func (l *CreateSceneLogic) CreateScene(in *bs_llm.CreateSceneRequest) (*bs_llm.CreateSceneResponse, error) {
+	if l.svcCtx.LlmSceneModel == nil {
+		return nil, fmt.Errorf("scene model not initialized")
+	}
+	if err := validateScene(l.svcCtx, in.Scene); err != nil {
+		return nil, err
+	}
+	sceneCode := strings.TrimSpace(in.Scene.SceneCode)
+
+	existing, err := l.svcCtx.LlmSceneModel.FindOneBySceneCode(l.ctx, sceneCode)
+	switch {
+	case err == nil && existing.Deleted == 0:
+		return nil, errorx.NewCodeErrorf(errorx.ErrCodeLLMSceneExists, "scene_code %s already exists", sceneCode)
+	case err == nil:
+		toSceneModel(in.Scene, existing)
+		existing.Deleted = 0
+		if err := l.svcCtx.LlmSceneModel.Update(l.ctx, existing); err != nil {
+			return nil, fmt.Errorf("failed to restore scene: %w", err)
+		}
+	case err == model.ErrNotFound:
+		data := &model.LlmScene{}
+		toSceneModel(in.Scene, data)
+		if _, err := l.svcCtx.LlmSceneModel.Insert(l.ctx, data); err != nil {
+			return nil, fmt.Errorf("failed to create scene: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("failed to query scene: %w", err)
+	}
+	l.svcCtx.InvalidateScene(sceneCode)
+
+	created, err := l.svcCtx.LlmSceneModel.FindOneBySceneCode(l.ctx, sceneCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query created scene: %w", err)
+	}
+	l.Infof("Scene created - SceneCode: %s, ProviderCode: %s, ModelCode: %s", created.SceneCode, created.ProviderCode, created.ModelCode)
+	return &bs_llm.CreateSceneResponse{Scene: toRPCScene(created)}, nil
+}
@@ -0,0 +1,195 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/common"
+	"jxzy/bs/bs_llm/internal/provider"
+	"jxzy/common/errorx"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type EmbedLogic struct {
+	common *common.LLMCommon
+	logx.Logger
+}
+
+func NewEmbedLogic(ctx context.Context, svcCtx interface{}) *EmbedLogic {
+	commonLogic := common.NewLLMCommon(ctx, svcCtx)
+
+	return &EmbedLogic{
+		common: commonLogic,
+		Logger: commonLogic.GetLogger(),
+	}
+}
+
+// 文本向量化，使用场景配置的供应商和向量模型，超过供应商单次上限的文本分批调用
+// 每次请求记录一条 llm_completion，提示词为全部文本，usage 只有输入token
+func (l *EmbedLogic) Embed(in *bs_llm.EmbedRequest) (*bs_llm.EmbedResponse, error) {
+	startTime := time.Now()
+
+	l.Logger.Infof("Embed called with scene_code: %s, texts_count: %d", in.SceneCode, len(in.Texts))
+
+	userId := l.common.GetOrDefaultUserId(in.UserId)
+
+	// 每条文本作为一条用户消息记录提示词，场景路由按全部文本的token数匹配
+	messages := make([]*bs_llm.ChatMessage, len(in.Texts))
+	for i, text := range in.Texts {
+		messages[i] = &bs_llm.ChatMessage{Role: "user", Content: text}
+	}
+	completion, requestId := l.common.InitializeCompletion(in.SceneCode, messages, userId)
+
+	defer func() {
+		responseTime := time.Since(startTime).Seconds()
+		completion.ResponseTime = sql.NullFloat64{Float64: responseTime, Valid: true}
+		l.Logger.Infof("Saving completion record - RequestId: %s, ResponseTime: %.2fs", requestId, responseTime)
+		l.common.SaveCompletion(completion)
+	}()
+
+	// 1. 验证参数
+	if err := l.validate(in); err != nil {
+		completion.ErrorMsg = sql.NullString{String: err.Error(), Valid: true}
+		return nil, err
+	}
+
+	// 2. 获取场景配置
+	sceneConfig, err := l.common.GetSceneConfig(in.SceneCode)
+	if err != nil {
+		completion.ErrorMsg = sql.NullString{String: err.Error(), Valid: true}
+		return nil, err
+	}
+
+	if err := l.common.CheckQuota(in.SceneCode, userId); err != nil {
+		completion.ErrorMsg = sql.NullString{String: err.Error(), Valid: true}
+		return nil, err
+	}
+
+	completion.ModelCode = sceneConfig.ModelCode
+	completion.ProviderCode = sceneConfig.ProviderCode
+
+	candidates, err := l.common.GetCandidates(sceneConfig)
+	if err != nil {
+		completion.ErrorMsg = sql.NullString{String: err.Error(), Valid: true}
+		return nil, err
+	}
+
+	// 3. 调用向量接口，失败时按场景配置重试和降级
+	// 同一请求的全部分批在同一个候选上完成，避免不同模型的向量混在一起
+	var result *provider.EmbeddingResponse
+	call := func(ctx context.Context, candidate *common.LLMCandidate, llmProvider provider.Provider, providerConfig *provider.ProviderConfig) error {
+		embedder, ok := llmProvider.(provider.Embedder)
+		if !ok {
+			return fmt.Errorf("provider %s does not support embeddings", candidate.ProviderCode)
+		}
+		resp, err := l.embedBatches(ctx, embedder, &provider.EmbeddingRequest{
+			SceneCode:  sceneConfig.SceneCode,
+			Texts:      in.Texts,
+			ModelCode:  candidate.ModelCode,
+			Dimensions: in.Dimensions,
+			Config:     providerConfig,
+		})
+		if err != nil {
+			return err
+		}
+		result = resp
+		return nil
+	}
+	candidate, attempt, err := l.common.ExecuteWithFallback(candidates, call)
+
+	completion.Attempt = int64(attempt)
+	if candidate != nil {
+		completion.ProviderCode = candidate.ProviderCode
+		completion.ModelCode = candidate.ModelCode
+	}
+	if err != nil {
+		l.Logger.Errorf("Failed to call embedding: %v", err)
+		completion.ErrorMsg = sql.NullString{String: fmt.Sprintf("failed to call embedding: %v", err), Valid: true}
+		return nil, fmt.Errorf("failed to call embedding: %w", err)
+	}
+
+	completion.InputTokens = result.PromptTokens
+	completion.TotalTokens = result.TotalTokens
+	completion.Status = 1 // 成功
+
+	embeddings := make([]*bs_llm.Embedding, len(result.Embeddings))
+	for i, values := range result.Embeddings {
+		embeddings[i] = &bs_llm.Embedding{Index: int32(i), Values: values}
+	}
+	l.Logger.Infof("Embed completed - Model: %s, Texts: %d, Dimensions: %d, Tokens: %d",
+		candidate.ModelCode, len(embeddings), len(result.Embeddings[0]), result.TotalTokens)
+
+	return &bs_llm.EmbedResponse{
+		Embeddings: embeddings,
+		ModelId:    candidate.ModelCode,
+		Usage: &bs_llm.LLMUsage{
+			PromptTokens: result.PromptTokens,
+			TotalTokens:  result.TotalTokens,
+		},
+	}, nil
+}
+
+// validate 校验文本数量、空文本及向量维度
+func (l *EmbedLogic) validate(in *bs_llm.EmbedRequest) error {
+	if err := l.common.ValidateSceneCode(in.SceneCode); err != nil {
+		return err
+	}
+	if len(in.Texts) == 0 {
+		return errorx.NewCodeError(errorx.ErrCodeParamError, "texts is required")
+	}
+	if maxTexts := l.common.GetServiceContext().Config.MaxEmbedTexts; maxTexts > 0 && len(in.Texts) > maxTexts {
+		return errorx.NewCodeErrorf(errorx.ErrCodeParamError, "too many texts: %d, max %d", len(in.Texts), maxTexts)
+	}
+	for i, text := range in.Texts {
+		if text == "" {
+			return errorx.NewCodeErrorf(errorx.ErrCodeParamError, "texts[%d] is empty", i)
+		}
+	}
+	if in.Dimensions < 0 {
+		return errorx.NewCodeError(errorx.ErrCodeParamError, "dimensions must not be negative")
+	}
+	return nil
+}
+
+// embedBatches 按供应商单次上限分批调用，合并向量和用量
+// 供应商未返回 usage 时使用模型对应的分词器计算
+func (l *EmbedLogic) embedBatches(ctx context.Context, embedder provider.Embedder, req *provider.EmbeddingRequest) (*provider.EmbeddingResponse, error) {
+	batchSize := embedder.MaxEmbeddingBatch()
+	if batchSize <= 0 {
+		batchSize = len(req.Texts)
+	}
+
+	result := &provider.EmbeddingResponse{ModelCode: req.ModelCode}
+	for start := 0; start < len(req.Texts); start += batchSize {
+		end := start + batchSize
+		if end > len(req.Texts) {
+			end = len(req.Texts)
+		}
+		batch := *req
+		batch.Texts = req.Texts[start:end]
+
+		resp, err := embedder.Embed(ctx, &batch)
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Embeddings) != len(batch.Texts) {
+			return nil, fmt.Errorf("expected %d embeddings, got %d", len(batch.Texts), len(resp.Embeddings))
+		}
+		result.Embeddings = append(result.Embeddings, resp.Embeddings...)
+
+		promptTokens, totalTokens := resp.PromptTokens, resp.TotalTokens
+		if promptTokens == 0 && totalTokens == 0 {
+			for _, text := range batch.Texts {
+				promptTokens += l.common.CountTokens(req.ModelCode, text)
+			}
+			totalTokens = promptTokens
+		}
+		result.PromptTokens += promptTokens
+		result.TotalTokens += totalTokens
+	}
+	return result, nil
+}
@@ -0,0 +1,91 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/provider"
+	"jxzy/bs/bs_llm/internal/provider/fake"
+	"jxzy/common/errorx"
+)
+
+// countingEmbedder 记录每次向量接口调用的文本数
+type countingEmbedder struct {
+	*fake.FakeProvider
+	batches []int
+}
+
+func (e *countingEmbedder) Embed(ctx context.Context, req *provider.EmbeddingRequest) (*provider.EmbeddingResponse, error) {
+	e.batches = append(e.batches, len(req.Texts))
+	return e.FakeProvider.Embed(ctx, req)
+}
+
+func TestEmbed(t *testing.T) {
+	p, _ := fake.NewFakeProvider("fake", &fake.Script{Rules: []fake.Rule{
+		{SceneCode: "chat_general", Response: fake.Response{ErrorStatus: 503, FailTimes: 1}},
+	}})
+	embedder := &countingEmbedder{FakeProvider: p}
+	svcCtx, completions := newStreamTestContext(embedder)
+	svcCtx.ProviderManager.RegisterWithConfig("blocking", embedder, &provider.ProviderConfig{RetryCount: 1})
+
+	texts := make([]string, 12)
+	for i := range texts {
+		texts[i] = fmt.Sprintf("文本%d", i)
+	}
+	resp, err := NewEmbedLogic(context.Background(), svcCtx).Embed(&bs_llm.EmbedRequest{
+		SceneCode:  "chat_general",
+		Texts:      texts,
+		Dimensions: 16,
+	})
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+
+	// 第一次调用注入 503，重试后按每批10条分两批
+	if fmt.Sprint(embedder.batches) != "[10 10 2]" {
+		t.Errorf("Unexpected batches: %v", embedder.batches)
+	}
+	if len(resp.Embeddings) != 12 || resp.ModelId != "blocking-model" || resp.Usage.PromptTokens != 38 {
+		t.Fatalf("Unexpected response: %+v", resp)
+	}
+	for i, embedding := range resp.Embeddings {
+		if embedding.Index != int32(i) || len(embedding.Values) != 16 {
+			t.Errorf("Unexpected embedding %d: index %d, dimensions %d", i, embedding.Index, len(embedding.Values))
+		}
+	}
+
+	completion := completions.inserted[0]
+	if completion.Status != 1 || completion.Attempt != 2 || completion.InputTokens != 38 || completion.TotalTokens != 38 ||
+		completion.OutputTokens != 0 || !completion.ResponseTime.Valid {
+		t.Errorf("Unexpected completion record: %+v", completion)
+	}
+}
+
+func TestEmbedValidation(t *testing.T) {
+	svcCtx, completions := newStreamTestContext(&blockingProvider{})
+	svcCtx.Config.MaxEmbedTexts = 2
+
+	cases := []*bs_llm.EmbedRequest{
+		{SceneCode: "chat_general"},
+		{SceneCode: "chat_general", Texts: []string{"a", "b", "c"}},
+		{SceneCode: "chat_general", Texts: []string{"a", ""}},
+		{SceneCode: "chat_general", Texts: []string{"a"}, Dimensions: -1},
+	}
+	for i, req := range cases {
+		_, err := NewEmbedLogic(context.Background(), svcCtx).Embed(req)
+		if codeErr, ok := errorx.FromError(err); !ok || codeErr.Code != errorx.ErrCodeParamError {
+			t.Errorf("case %d: expected param error, got %v", i, err)
+		}
+	}
+
+	// 供应商不支持向量化
+	_, err := NewEmbedLogic(context.Background(), svcCtx).Embed(&bs_llm.EmbedRequest{SceneCode: "chat_general", Texts: []string{"a"}})
+	if err == nil {
+		t.Error("Expected error for provider without embeddings")
+	}
+	if len(completions.inserted) != len(cases)+1 || completions.inserted[len(cases)].Status != 0 {
+		t.Errorf("Expected every call to be recorded, got %d records", len(completions.inserted))
+	}
+}
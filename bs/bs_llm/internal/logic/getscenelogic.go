@@ -0,0 +1,46 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/bs/bs_llm/internal/svc"
+	"jxzy/common/errorx"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type GetSceneLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetSceneLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetSceneLogic {
+	return &GetSceneLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 查询场景，已删除的场景也会返回（deleted=true）
+func (l *GetSceneLogic) GetScene(in *bs_llm.GetSceneRequest) (*bs_llm.GetSceneResponse, error) {
+	if l.svcCtx.LlmSceneModel == nil {
+		return nil, fmt.Errorf("scene model not initialized")
+	}
+	if in.SceneCode == "" {
+		return nil, errorx.NewCodeError(errorx.ErrCodeParamError, "scene_code is required")
+	}
+
+	scene, err := l.svcCtx.LlmSceneModel.FindOneBySceneCode(l.ctx, in.SceneCode)
+	if err == model.ErrNotFound {
+		return nil, errorx.NewCodeErrorf(errorx.ErrCodeLLMSceneNotFound, "scene_code %s not found", in.SceneCode)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scene: %w", err)
+	}
+	return &bs_llm.GetSceneResponse{Scene: toRPCScene(scene)}, nil
+}
@@ -0,0 +1,144 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/bs/bs_llm/internal/svc"
+	"jxzy/common/errorx"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 用量报表行数参数
+const (
+	defaultUsageReportLimit = 1000
+	maxUsageReportLimit     = 10000
+)
+
+// 默认聚合维度
+var defaultUsageGroupBy = []string{model.UsageGroupUser, model.UsageGroupScene, model.UsageGroupModel, model.UsageGroupDay}
+
+type GetUsageReportLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewGetUsageReportLogic(ctx context.Context, svcCtx *svc.ServiceContext) *GetUsageReportLogic {
+	return &GetUsageReportLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 按用户、场景、模型、日期聚合调用量和费用
+func (l *GetUsageReportLogic) GetUsageReport(in *bs_llm.GetUsageReportRequest) (*bs_llm.GetUsageReportResponse, error) {
+	if l.svcCtx.LlmCompletionModel == nil {
+		return nil, fmt.Errorf("completion model not initialized")
+	}
+
+	groupBy := in.GroupBy
+	if len(groupBy) == 0 {
+		groupBy = defaultUsageGroupBy
+	}
+	for _, group := range groupBy {
+		switch group {
+		case model.UsageGroupUser, model.UsageGroupScene, model.UsageGroupProvider, model.UsageGroupModel, model.UsageGroupRoute, model.UsageGroupDay:
+		default:
+			return nil, errorx.NewCodeErrorf(errorx.ErrCodeParamError, "invalid group_by %q, available: user/scene/provider/model/route/day", group)
+		}
+	}
+	if in.StartTime > 0 && in.EndTime > 0 && in.StartTime >= in.EndTime {
+		return nil, errorx.NewCodeError(errorx.ErrCodeParamError, "start_time must be before end_time")
+	}
+	limit := in.Limit
+	if limit <= 0 {
+		limit = defaultUsageReportLimit
+	}
+	if limit > maxUsageReportLimit {
+		limit = maxUsageReportLimit
+	}
+
+	filter := &model.UsageFilter{
+		UserId:    in.UserId,
+		SceneCode: in.SceneCode,
+		ModelCode: in.ModelCode,
+	}
+	if in.StartTime > 0 {
+		filter.Start = time.Unix(in.StartTime, 0)
+	}
+	if in.EndTime > 0 {
+		filter.End = time.Unix(in.EndTime, 0)
+	}
+
+	rows, err := l.svcCtx.LlmCompletionModel.SumUsage(l.ctx, filter, groupBy, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query usage: %w", err)
+	}
+	totals, err := l.svcCtx.LlmCompletionModel.SumUsage(l.ctx, filter, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query usage total: %w", err)
+	}
+
+	resp := &bs_llm.GetUsageReportResponse{
+		Rows:           make([]*bs_llm.UsageReportRow, 0, len(rows)),
+		Total:          &bs_llm.UsageReportRow{},
+		CurrencyTotals: make([]*bs_llm.UsageReportRow, 0, len(totals)),
+	}
+	for _, row := range rows {
+		resp.Rows = append(resp.Rows, toRPCUsageRow(row))
+	}
+	for _, row := range totals {
+		resp.CurrencyTotals = append(resp.CurrencyTotals, toRPCUsageRow(row))
+	}
+	resp.Total = sumUsageTotals(totals)
+	return resp, nil
+}
+
+// sumUsageTotals 合并各币种的汇总，调用次数和 token 用量直接相加；
+// 费用只有一种币种（未配置价格的调用除外）时填写，多种币种的费用不能相加
+func sumUsageTotals(totals []*model.UsageRow) *bs_llm.UsageReportRow {
+	total := &bs_llm.UsageReportRow{}
+	currencies := 0
+	for _, row := range totals {
+		total.Requests += row.Requests
+		total.CacheHits += row.CacheHits
+		total.InputTokens += row.InputTokens
+		total.OutputTokens += row.OutputTokens
+		total.TotalTokens += row.TotalTokens
+		if row.Currency != "" {
+			currencies++
+			total.Cost = row.Cost
+			total.Currency = row.Currency
+		}
+	}
+	if currencies > 1 {
+		total.Cost = 0
+		total.Currency = ""
+	}
+	return total
+}
+
+// toRPCUsageRow 转换用量统计行
+func toRPCUsageRow(row *model.UsageRow) *bs_llm.UsageReportRow {
+	return &bs_llm.UsageReportRow{
+		UserId:       row.UserId,
+		SceneCode:    row.SceneCode,
+		ProviderCode: row.ProviderCode,
+		ModelCode:    row.ModelCode,
+		Route:        row.Route,
+		Day:          row.Day,
+		Requests:     row.Requests,
+		CacheHits:    row.CacheHits,
+		InputTokens:  row.InputTokens,
+		OutputTokens: row.OutputTokens,
+		TotalTokens:  row.TotalTokens,
+		Cost:         row.Cost,
+		Currency:     row.Currency,
+	}
+}
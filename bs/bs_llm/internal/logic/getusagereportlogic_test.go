@@ -0,0 +1,71 @@
+package logic
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/common/errorx"
+)
+
+// usageCompletionModel 记录 SumUsage 的查询参数
+type usageCompletionModel struct {
+	fakeCompletionModel
+	groupBys [][]string
+	limits   []int64
+	totals   []*model.UsageRow
+}
+
+func (m *usageCompletionModel) SumUsage(ctx context.Context, filter *model.UsageFilter, groupBy []string, limit int64) ([]*model.UsageRow, error) {
+	m.groupBys = append(m.groupBys, groupBy)
+	m.limits = append(m.limits, limit)
+	if len(groupBy) == 0 {
+		return m.totals, nil
+	}
+	return []*model.UsageRow{
+		{SceneCode: "chat_general", Day: "2024-01-01", Requests: 2, TotalTokens: 200, Cost: 0.2},
+		{SceneCode: "chat_general", Day: "2024-01-02", Requests: 1, TotalTokens: 100, Cost: 0.1},
+	}, nil
+}
+
+func TestGetUsageReport(t *testing.T) {
+	svcCtx, _ := newStreamTestContext(&blockingProvider{})
+	completions := &usageCompletionModel{totals: []*model.UsageRow{
+		{Requests: 3, TotalTokens: 300, Cost: 0.3, Currency: "CNY"},
+		{Requests: 1, TotalTokens: 50},
+	}}
+	svcCtx.LlmCompletionModel = completions
+
+	resp, err := NewGetUsageReportLogic(context.Background(), svcCtx).GetUsageReport(&bs_llm.GetUsageReportRequest{Limit: 100000})
+	if err != nil {
+		t.Fatalf("GetUsageReport failed: %v", err)
+	}
+	if !reflect.DeepEqual(completions.groupBys[0], defaultUsageGroupBy) || completions.limits[0] != maxUsageReportLimit {
+		t.Errorf("Expected default group_by and capped limit, got %v, %d", completions.groupBys[0], completions.limits[0])
+	}
+	if len(resp.Rows) != 2 || resp.Rows[0].Day != "2024-01-01" || resp.Total.Requests != 4 || resp.Total.Cost != 0.3 || resp.Total.Currency != "CNY" || len(resp.CurrencyTotals) != 2 {
+		t.Errorf("Unexpected report: %+v", resp)
+	}
+
+	// 多个币种的费用不相加
+	completions.totals = append(completions.totals, &model.UsageRow{Requests: 2, TotalTokens: 100, Cost: 0.01, Currency: "USD"})
+	resp, err = NewGetUsageReportLogic(context.Background(), svcCtx).GetUsageReport(&bs_llm.GetUsageReportRequest{})
+	if err != nil {
+		t.Fatalf("GetUsageReport failed: %v", err)
+	}
+	if resp.Total.Requests != 6 || resp.Total.Cost != 0 || resp.Total.Currency != "" || len(resp.CurrencyTotals) != 3 {
+		t.Errorf("Unexpected mixed currency total: %+v", resp.Total)
+	}
+
+	for _, in := range []*bs_llm.GetUsageReportRequest{
+		{GroupBy: []string{"team"}},
+		{StartTime: 200, EndTime: 100},
+	} {
+		_, err := NewGetUsageReportLogic(context.Background(), svcCtx).GetUsageReport(in)
+		if codeErr, ok := errorx.FromError(err); !ok || codeErr.Code != errorx.ErrCodeParamError {
+			t.Errorf("Expected param error for %+v, got %v", in, err)
+		}
+	}
+}
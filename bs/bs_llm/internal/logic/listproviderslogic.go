@@ -0,0 +1,61 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/health"
+	"jxzy/bs/bs_llm/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type ListProvidersLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListProvidersLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListProvidersLogic {
+	return &ListProvidersLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 查询已注册供应商及其健康状态
+func (l *ListProvidersLogic) ListProviders(in *bs_llm.ListProvidersRequest) (*bs_llm.ListProvidersResponse, error) {
+	statuses := l.svcCtx.ProviderHealth.List()
+	providers := make([]*bs_llm.ProviderStatus, 0, len(statuses))
+	for _, status := range statuses {
+		providers = append(providers, toRPCProviderStatus(status))
+	}
+	return &bs_llm.ListProvidersResponse{Providers: providers}, nil
+}
+
+// toRPCProviderStatus 转换供应商健康状态，未发生的时间记为0
+func toRPCProviderStatus(status *health.Status) *bs_llm.ProviderStatus {
+	return &bs_llm.ProviderStatus{
+		ProviderCode:   status.ProviderCode,
+		State:          status.State,
+		Healthy:        status.Healthy(),
+		ErrorRate:      status.ErrorRate,
+		Requests:       int64(status.Requests),
+		Failures:       int64(status.Failures),
+		LastError:      status.LastError,
+		LastFailureAt:  unixOrZero(status.LastFailureAt),
+		LastCheckAt:    unixOrZero(status.LastCheckAt),
+		LastCheckError: status.LastCheckError,
+		OpenedAt:       unixOrZero(status.OpenedAt),
+	}
+}
+
+// unixOrZero 转换为Unix秒，零值时间返回0
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
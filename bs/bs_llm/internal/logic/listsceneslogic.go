@@ -0,0 +1,70 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/bs/bs_llm/internal/svc"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 场景分页参数
+const (
+	defaultScenePageSize = 20
+	maxScenePageSize     = 100
+)
+
+type ListScenesLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewListScenesLogic(ctx context.Context, svcCtx *svc.ServiceContext) *ListScenesLogic {
+	return &ListScenesLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 分页查询场景
+func (l *ListScenesLogic) ListScenes(in *bs_llm.ListScenesRequest) (*bs_llm.ListScenesResponse, error) {
+	if l.svcCtx.LlmSceneModel == nil {
+		return nil, fmt.Errorf("scene model not initialized")
+	}
+
+	page := in.Page
+	if page < 1 {
+		page = 1
+	}
+	pageSize := in.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultScenePageSize
+	}
+	if pageSize > maxScenePageSize {
+		pageSize = maxScenePageSize
+	}
+
+	filter := &model.SceneFilter{
+		ProviderCode:   in.ProviderCode,
+		IncludeDeleted: in.IncludeDeleted,
+	}
+	total, err := l.svcCtx.LlmSceneModel.Count(l.ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count scenes: %w", err)
+	}
+	rows, err := l.svcCtx.LlmSceneModel.FindPage(l.ctx, filter, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scenes: %w", err)
+	}
+
+	scenes := make([]*bs_llm.Scene, 0, len(rows))
+	for _, row := range rows {
+		scenes = append(scenes, toRPCScene(row))
+	}
+	return &bs_llm.ListScenesResponse{Scenes: scenes, Total: total}, nil
+}
@@ -7,15 +7,19 @@ import (
 	"time"
 
 	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/cache"
 	"jxzy/bs/bs_llm/internal/common"
+	"jxzy/bs/bs_llm/internal/model"
 	"jxzy/bs/bs_llm/internal/provider"
+	"jxzy/common/errorx"
 
 	"github.com/google/uuid"
 	"github.com/zeromicro/go-zero/core/logx"
 )
 
 type LLMLogic struct {
-	common *common.LLMCommon
+	common  *common.LLMCommon
+	batchId string // 批量调用ID，记录到 llm_completion.batch_id
 	logx.Logger
 }
 
@@ -28,6 +32,12 @@ func NewLLMLogic(ctx context.Context, svcCtx interface{}) *LLMLogic {
 	}
 }
 
+// WithBatchId 设置批量调用ID
+func (l *LLMLogic) WithBatchId(batchId string) *LLMLogic {
+	l.batchId = batchId
+	return l
+}
+
 // 非流式LLM调用
 func (l *LLMLogic) LLM(in *bs_llm.LLMRequest) (*bs_llm.LLMResponse, error) {
 	startTime := time.Now()
@@ -42,6 +52,7 @@ func (l *LLMLogic) LLM(in *bs_llm.LLMRequest) (*bs_llm.LLMResponse, error) {
 	// 初始化完成记录
 	completion, _ := l.common.InitializeCompletion(in.SceneCode, in.Messages, userId)
 	completion.RequestId = requestId // 使用生成的请求ID
+	completion.BatchId = l.batchId
 
 	// 延迟执行：保存问答记录
 	defer func() {
@@ -65,6 +76,12 @@ func (l *LLMLogic) LLM(in *bs_llm.LLMRequest) (*bs_llm.LLMResponse, error) {
 		return nil, err
 	}
 
+	// 检查调用配额（请求频率、每日token数）
+	if err := l.common.CheckQuota(in.SceneCode, userId); err != nil {
+		completion.ErrorMsg = sql.NullString{String: err.Error(), Valid: true}
+		return nil, err
+	}
+
 	// 更新completion记录中的模型和供应商信息
 	completion.ModelCode = sceneConfig.ModelCode
 	completion.ProviderCode = sceneConfig.ProviderCode
@@ -73,92 +90,214 @@ func (l *LLMLogic) LLM(in *bs_llm.LLMRequest) (*bs_llm.LLMResponse, error) {
 	l.Logger.Infof("Scene config - Temperature: %f, MaxTokens: %d, EnableStream: %d",
 		sceneConfig.Temperature, sceneConfig.MaxTokens, sceneConfig.EnableStream)
 
-	// 3. 获取供应商
-	l.Logger.Infof("Getting provider: %s", sceneConfig.ProviderCode)
-	llmProvider := l.common.GetProviderManager().GetProvider(sceneConfig.ProviderCode)
-	if llmProvider == nil {
-		err := fmt.Errorf("provider %s not supported", sceneConfig.ProviderCode)
+	// 3. 获取候选供应商（主供应商 + 降级链）
+	candidates, err := l.common.GetCandidates(sceneConfig)
+	if err != nil {
 		completion.ErrorMsg = sql.NullString{String: err.Error(), Valid: true}
 		return nil, err
 	}
 
 	// 4. 构建请求
 	l.Logger.Debug("Building LLM request")
-	req := &provider.LLMRequest{
-		Messages:    common.ConvertToProviderMessages(in.Messages),
-		ModelCode:   sceneConfig.ModelCode,
-		Temperature: sceneConfig.Temperature,
-		MaxTokens:   sceneConfig.MaxTokens,
-		Stream:      false, // 非流式调用
-		ExtraParams: in.ExtraParams,
-		Config:      l.common.GetProviderConfig(sceneConfig.ProviderCode),
+	tools, err := common.ConvertToProviderTools(in.Tools)
+	if err != nil {
+		completion.ErrorMsg = sql.NullString{String: err.Error(), Valid: true}
+		return nil, err
+	}
+	if err := common.ValidateContentParts(in.Messages); err != nil {
+		completion.ErrorMsg = sql.NullString{String: err.Error(), Valid: true}
+		return nil, err
+	}
+	messages := common.ConvertToProviderMessages(in.Messages)
+	structured, err := common.NewStructuredOutput(in.ResponseFormat)
+	if err != nil {
+		completion.ErrorMsg = sql.NullString{String: err.Error(), Valid: true}
+		return nil, err
+	}
+	params, err := common.ResolveParams(sceneConfig, in.ExtraParams)
+	if err != nil {
+		completion.ErrorMsg = sql.NullString{String: err.Error(), Valid: true}
+		return nil, err
+	}
+	var responseFormat *provider.ResponseFormat
+	reqMessages := messages
+	if structured != nil {
+		responseFormat = structured.Format
+		reqMessages = structured.Instruct(messages)
 	}
 
-	l.Logger.Infof("LLM request built - Model: %s, Temperature: %f, MaxTokens: %d, Messages: %d",
-		req.ModelCode, req.Temperature, req.MaxTokens, len(req.Messages))
+	// 5. 查询响应缓存（仅开启缓存的场景），命中时不调用供应商
+	var cacheKey string
+	if common.IsCacheable(sceneConfig, params.Temperature) {
+		cacheKey = cache.Key(sceneConfig.SceneCode, &provider.LLMRequest{
+			Messages:       messages,
+			ModelCode:      sceneConfig.ModelCode,
+			Temperature:    params.Temperature,
+			MaxTokens:      params.MaxTokens,
+			ExtraParams:    in.ExtraParams,
+			Tools:          tools,
+			ToolChoice:     in.ToolChoice,
+			ResponseFormat: responseFormat,
+		})
+		if entry := l.common.GetCachedResponse(cacheKey); entry != nil {
+			l.Logger.Infof("Response cache hit - SceneCode: %s, Key: %s", sceneConfig.SceneCode, cacheKey)
+			completion.CacheHit = 1
+			completion.ProviderCode = entry.ProviderCode
+			completion.ModelCode = entry.ModelCode
+			completion.Completion = sql.NullString{String: common.BuildCompletionText(entry.Content, entry.ToolCalls), Valid: true}
+			completion.Reasoning = sql.NullString{String: entry.ReasoningContent, Valid: entry.ReasoningContent != ""}
+			completion.Status = 1 // 成功
+			completion.InputTokens = entry.PromptTokens
+			completion.OutputTokens = entry.CompletionTokens
+			completion.TotalTokens = entry.TotalTokens
+			completion.ReasoningTokens = entry.ReasoningTokens
+			return &bs_llm.LLMResponse{
+				Completion:       entry.Content,
+				ModelId:          entry.ModelCode,
+				FinishReason:     entry.FinishReason,
+				ToolCalls:        common.ConvertToRPCToolCalls(entry.ToolCalls),
+				ReasoningContent: entry.ReasoningContent,
+				Usage: &bs_llm.LLMUsage{
+					PromptTokens:     entry.PromptTokens,
+					CompletionTokens: entry.CompletionTokens,
+					TotalTokens:      entry.TotalTokens,
+					ReasoningTokens:  entry.ReasoningTokens,
+				},
+			}, nil
+		}
+	}
 
-	// 5. 调用非流式LLM
+	// 6. 调用非流式LLM，失败时按场景配置重试和降级
 	l.Logger.Debug("Calling non-stream LLM")
-	providerResp, err := llmProvider.CallLLM(l.common.GetContext(), req)
+	var providerResp *provider.LLMResponse
+	call := func(ctx context.Context, candidate *common.LLMCandidate, llmProvider provider.Provider, providerConfig *provider.ProviderConfig) error {
+		req := &provider.LLMRequest{
+			SceneCode:      sceneConfig.SceneCode,
+			Messages:       reqMessages,
+			ModelCode:      candidate.ModelCode,
+			Stream:         false, // 非流式调用
+			ExtraParams:    l.common.MergeExtraParams(providerConfig, in.ExtraParams),
+			Tools:          tools,
+			ToolChoice:     in.ToolChoice,
+			ResponseFormat: responseFormat,
+			Config:         providerConfig,
+		}
+		params.Apply(req)
+
+		l.Logger.Infof("LLM request built - Model: %s, Temperature: %f, MaxTokens: %d, Messages: %d, Tools: %d",
+			req.ModelCode, req.Temperature, req.MaxTokens, len(req.Messages), len(req.Tools))
+
+		resp, err := llmProvider.CallLLM(ctx, req)
+		if err != nil {
+			return err
+		}
+		providerResp = resp
+		return nil
+	}
+	candidate, attempt, err := l.common.ExecuteWithFallback(candidates, call)
+
+	// 记录实际调用的供应商、模型及尝试序号
+	completion.Attempt = int64(attempt)
+	if candidate != nil {
+		completion.ProviderCode = candidate.ProviderCode
+		completion.ModelCode = candidate.ModelCode
+	}
 	if err != nil {
 		l.Logger.Errorf("Failed to call non-stream LLM: %v", err)
 		completion.ErrorMsg = sql.NullString{String: fmt.Sprintf("failed to call non-stream LLM: %v", err), Valid: true}
 		return nil, fmt.Errorf("failed to call non-stream LLM: %w", err)
 	}
 
-	l.Logger.Infof("LLM response received - Completion: %s, FinishReason: %s", providerResp.Content, providerResp.FinishReason)
+	l.Logger.Infof("LLM response received - Completion: %s, FinishReason: %s, ToolCalls: %d",
+		l.common.RedactForLog(providerResp.Content), providerResp.FinishReason, len(providerResp.ToolCalls))
+	l.addUsage(completion, candidate.ModelCode, reqMessages, providerResp)
 
-	// 6. 更新completion记录为成功状态
-	completion.Completion = sql.NullString{String: providerResp.Content, Valid: true}
-	completion.Status = 1 // 成功
+	// 7. 校验结构化输出，不符合时附上校验错误让同一供应商修复，模型选择调用工具时不校验
+	if structured != nil && len(providerResp.ToolCalls) == 0 {
+		maxRepairs := l.common.GetServiceContext().Config.MaxRepairAttempts
+		for repair := 0; ; repair++ {
+			content, verr := structured.Validate(providerResp.Content)
+			if verr == nil {
+				providerResp.Content = content
+				break
+			}
+			l.Logger.Errorf("Structured output validation failed (repair %d/%d): %v", repair, maxRepairs, verr)
+			if repair >= maxRepairs {
+				completion.Completion = sql.NullString{String: providerResp.Content, Valid: true}
+				completion.ErrorMsg = sql.NullString{String: fmt.Sprintf("invalid structured output: %v", verr), Valid: true}
+				return nil, errorx.NewCodeErrorf(errorx.ErrCodeLLMInvalidOutput, "LLM输出不符合response_format: %v", verr)
+			}
 
-	// 设置 token 使用情况
-	if providerResp.PromptTokens > 0 || providerResp.CompletionTokens > 0 || providerResp.TotalTokens > 0 {
-		completion.InputTokens = providerResp.PromptTokens
-		completion.OutputTokens = providerResp.CompletionTokens
-		completion.TotalTokens = providerResp.TotalTokens
-		l.Logger.Infof("LLM provided usage - Input: %d, Output: %d, Total: %d",
-			providerResp.PromptTokens, providerResp.CompletionTokens, providerResp.TotalTokens)
-	} else {
-		// 如果没有 usage 信息，自己计算 token 数量
-		inputTokens := l.common.EstimateTokens(completion.Prompt)
-		outputTokens := l.common.EstimateTokens(providerResp.Content)
-		totalTokens := inputTokens + outputTokens
+			reqMessages = structured.RepairMessages(reqMessages, providerResp.Content, verr)
+			if _, _, err := l.common.ExecuteWithFallback([]*common.LLMCandidate{candidate}, call); err != nil {
+				l.Logger.Errorf("Failed to repair structured output: %v", err)
+				completion.ErrorMsg = sql.NullString{String: fmt.Sprintf("failed to repair structured output: %v", err), Valid: true}
+				return nil, fmt.Errorf("failed to repair structured output: %w", err)
+			}
+			l.addUsage(completion, candidate.ModelCode, reqMessages, providerResp)
+		}
+	}
+
+	// 更新completion记录为成功状态
+	completionText := common.BuildCompletionText(providerResp.Content, providerResp.ToolCalls)
+	completion.Completion = sql.NullString{String: completionText, Valid: true}
+	completion.Reasoning = sql.NullString{String: providerResp.ReasoningContent, Valid: providerResp.ReasoningContent != ""}
+	completion.Status = 1 // 成功
 
-		completion.InputTokens = inputTokens
-		completion.OutputTokens = outputTokens
-		completion.TotalTokens = totalTokens
-		l.Logger.Infof("Estimated token usage - Input: %d, Output: %d, Total: %d",
-			inputTokens, outputTokens, totalTokens)
+	// 8. 写入响应缓存
+	if cacheKey != "" {
+		l.common.SaveCachedResponse(cacheKey, sceneConfig, &cache.Entry{
+			Content:          providerResp.Content,
+			FinishReason:     providerResp.FinishReason,
+			ToolCalls:        providerResp.ToolCalls,
+			ProviderCode:     candidate.ProviderCode,
+			ModelCode:        candidate.ModelCode,
+			PromptTokens:     completion.InputTokens,
+			CompletionTokens: completion.OutputTokens,
+			TotalTokens:      completion.TotalTokens,
+			ReasoningContent: providerResp.ReasoningContent,
+			ReasoningTokens:  completion.ReasoningTokens,
+		})
 	}
 
-	// 7. 构建gRPC响应
+	// 9. 构建gRPC响应，usage 为供应商返回的或分词器计算的token使用情况，含结构化输出的修复调用
 	llmResp := &bs_llm.LLMResponse{
-		Completion:   providerResp.Content,
-		ModelId:      sceneConfig.ModelCode,
-		FinishReason: providerResp.FinishReason,
+		Completion:       providerResp.Content,
+		ModelId:          candidate.ModelCode,
+		FinishReason:     providerResp.FinishReason,
+		ToolCalls:        common.ConvertToRPCToolCalls(providerResp.ToolCalls),
+		ReasoningContent: providerResp.ReasoningContent,
+		Usage: &bs_llm.LLMUsage{
+			PromptTokens:     completion.InputTokens,
+			CompletionTokens: completion.OutputTokens,
+			TotalTokens:      completion.TotalTokens,
+			ReasoningTokens:  completion.ReasoningTokens,
+		},
 	}
 
-	// 添加usage信息
-	if providerResp.PromptTokens > 0 || providerResp.CompletionTokens > 0 || providerResp.TotalTokens > 0 {
-		llmResp.Usage = &bs_llm.LLMUsage{
-			PromptTokens:     providerResp.PromptTokens,
-			CompletionTokens: providerResp.CompletionTokens,
-			TotalTokens:      providerResp.TotalTokens,
-		}
+	l.Logger.Info("LLM logic completed successfully")
+	return llmResp, nil
+}
+
+// addUsage 累加一次供应商调用的 token 使用情况，供应商未返回 usage 时使用模型对应的分词器计算，推理过程计入输出
+func (l *LLMLogic) addUsage(completion *model.LlmCompletion, modelCode string, messages []*provider.ChatMessage, resp *provider.LLMResponse) {
+	inputTokens, outputTokens, totalTokens := resp.PromptTokens, resp.CompletionTokens, resp.TotalTokens
+	reasoningTokens := resp.ReasoningTokens
+	if inputTokens > 0 || outputTokens > 0 || totalTokens > 0 {
+		l.Logger.Infof("LLM provided usage - Input: %d, Output: %d, Total: %d, Reasoning: %d",
+			inputTokens, outputTokens, totalTokens, reasoningTokens)
 	} else {
-		// 估算token使用情况
-		inputTokens := l.common.EstimateTokens(completion.Prompt)
-		outputTokens := l.common.EstimateTokens(providerResp.Content)
-		totalTokens := inputTokens + outputTokens
-
-		llmResp.Usage = &bs_llm.LLMUsage{
-			PromptTokens:     inputTokens,
-			CompletionTokens: outputTokens,
-			TotalTokens:      totalTokens,
+		if resp.ReasoningContent != "" {
+			reasoningTokens = l.common.CountTokens(modelCode, resp.ReasoningContent)
 		}
+		inputTokens = l.common.CountMessageTokens(modelCode, messages)
+		outputTokens = l.common.CountTokens(modelCode, common.BuildCompletionText(resp.Content, resp.ToolCalls)) + reasoningTokens
+		totalTokens = inputTokens + outputTokens
+		l.Logger.Infof("Counted token usage - Input: %d, Output: %d, Total: %d, Reasoning: %d",
+			inputTokens, outputTokens, totalTokens, reasoningTokens)
 	}
-
-	l.Logger.Info("LLM logic completed successfully")
-	return llmResp, nil
+	completion.InputTokens += inputTokens
+	completion.OutputTokens += outputTokens
+	completion.TotalTokens += totalTokens
+	completion.ReasoningTokens += reasoningTokens
 }
@@ -0,0 +1,224 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/cache"
+	"jxzy/bs/bs_llm/internal/pricing"
+	"jxzy/bs/bs_llm/internal/provider"
+	"jxzy/common/errorx"
+)
+
+// scriptedProvider 按顺序返回预设的非流式响应
+type scriptedProvider struct {
+	blockingProvider
+	contents []string
+	requests []*provider.LLMRequest
+}
+
+func (p *scriptedProvider) CallLLM(ctx context.Context, req *provider.LLMRequest) (*provider.LLMResponse, error) {
+	p.requests = append(p.requests, req)
+	content := p.contents[0]
+	if len(p.contents) > 1 {
+		p.contents = p.contents[1:]
+	}
+	return &provider.LLMResponse{Content: content, ModelCode: req.ModelCode, PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}, nil
+}
+
+const sentencesFormat = `{"type":"object","properties":{"sentences":{"type":"array","items":{"type":"string"}}},"required":["sentences"]}`
+
+func TestLLMStructuredOutputRepair(t *testing.T) {
+	p := &scriptedProvider{contents: []string{`"sentences": ["a"]`, "```json\n{\"sentences\":[\"a\"]}\n```"}}
+	svcCtx, completions := newStreamTestContext(p)
+	svcCtx.Config.MaxRepairAttempts = 2
+
+	resp, err := NewLLMLogic(context.Background(), svcCtx).LLM(&bs_llm.LLMRequest{
+		SceneCode:      "chat_general",
+		Messages:       []*bs_llm.ChatMessage{{Role: "user", Content: "hi"}},
+		ResponseFormat: &bs_llm.ResponseFormat{Type: "json_schema", Schema: sentencesFormat},
+	})
+	if err != nil {
+		t.Fatalf("LLM failed: %v", err)
+	}
+	if resp.Completion != `{"sentences":["a"]}` {
+		t.Errorf("Expected repaired JSON, got %q", resp.Completion)
+	}
+	if len(p.requests) != 2 {
+		t.Fatalf("Expected 1 repair request, got %d requests", len(p.requests))
+	}
+	repair := p.requests[1]
+	if repair.ResponseFormat == nil || repair.ResponseFormat.Type != provider.ResponseFormatJSONSchema {
+		t.Errorf("Expected response format to be passed to provider, got %+v", repair.ResponseFormat)
+	}
+	if last := repair.Messages[len(repair.Messages)-1]; last.Role != "user" {
+		t.Errorf("Expected repair instruction as last message, got %+v", last)
+	}
+	if resp.Usage.TotalTokens != 30 || completions.inserted[0].TotalTokens != 30 {
+		t.Errorf("Expected usage of both calls to be counted, got %d", resp.Usage.TotalTokens)
+	}
+}
+
+func TestLLMStructuredOutputGivesUp(t *testing.T) {
+	p := &scriptedProvider{contents: []string{"not json"}}
+	svcCtx, completions := newStreamTestContext(p)
+	svcCtx.Config.MaxRepairAttempts = 1
+
+	_, err := NewLLMLogic(context.Background(), svcCtx).LLM(&bs_llm.LLMRequest{
+		SceneCode:      "chat_general",
+		Messages:       []*bs_llm.ChatMessage{{Role: "user", Content: "hi"}},
+		ResponseFormat: &bs_llm.ResponseFormat{Type: "json_object"},
+	})
+	codeErr, ok := errorx.FromError(err)
+	if !ok || codeErr.Code != errorx.ErrCodeLLMInvalidOutput {
+		t.Fatalf("Expected ErrCodeLLMInvalidOutput, got %v", err)
+	}
+	if len(p.requests) != 2 {
+		t.Errorf("Expected 2 requests, got %d", len(p.requests))
+	}
+	if completions.inserted[0].Status != 0 {
+		t.Errorf("Expected failed status, got %d", completions.inserted[0].Status)
+	}
+}
+
+func TestLLMRecordsCost(t *testing.T) {
+	p := &scriptedProvider{contents: []string{"hello"}}
+	svcCtx, completions := newStreamTestContext(p)
+	svcCtx.Pricing.Set([]*pricing.Price{{
+		ProviderCode: "blocking",
+		ModelCode:    "blocking-model",
+		InputPrice:   0.4,
+		OutputPrice:  1.2,
+		EffectiveAt:  time.Now().Add(-time.Hour),
+	}})
+
+	if _, err := NewLLMLogic(context.Background(), svcCtx).LLM(&bs_llm.LLMRequest{
+		SceneCode: "chat_general",
+		Messages:  []*bs_llm.ChatMessage{{Role: "user", Content: "hi"}},
+	}); err != nil {
+		t.Fatalf("LLM failed: %v", err)
+	}
+	// 10 个输入token、5 个输出token
+	if cost := completions.inserted[0].Cost; math.Abs(cost-0.01) > 1e-9 {
+		t.Errorf("Expected cost 0.01, got %v", cost)
+	}
+}
+
+func TestLLMRedactsCompletion(t *testing.T) {
+	cases := []struct {
+		policy         string
+		patterns       string
+		wantPrompt     string
+		wantCompletion string
+	}{
+		{"", "", "[user]: 我的电话 [PHONE]，订单 ORD123456", "已记录 [EMAIL]"},
+		{"redact", `[{"name":"order","pattern":"ORD[0-9]{6}","replacement":"[ORDER]"}]`, "[user]: 我的电话 [PHONE]，订单 [ORDER]", "已记录 [EMAIL]"},
+		{"raw", "", "[user]: 我的电话 13812345678，订单 ORD123456", "已记录 a@b.com"},
+		{"discard", "", "", ""},
+	}
+	for _, tc := range cases {
+		p := &scriptedProvider{contents: []string{"已记录 a@b.com"}}
+		svcCtx, completions := newStreamTestContext(p)
+		scene := svcCtx.LlmSceneModel.(*fakeSceneModel).scene
+		scene.ContentPolicy = tc.policy
+		scene.RedactPatterns = sql.NullString{String: tc.patterns, Valid: tc.patterns != ""}
+
+		resp, err := NewLLMLogic(context.Background(), svcCtx).LLM(&bs_llm.LLMRequest{
+			SceneCode: "chat_general",
+			Messages:  []*bs_llm.ChatMessage{{Role: "user", Content: "我的电话 13812345678，订单 ORD123456"}},
+		})
+		if err != nil {
+			t.Fatalf("%q: LLM failed: %v", tc.policy, err)
+		}
+		// 返回给调用方的内容不脱敏
+		if resp.Completion != "已记录 a@b.com" {
+			t.Errorf("%q: unexpected response %q", tc.policy, resp.Completion)
+		}
+		saved := completions.inserted[0]
+		if saved.Prompt != tc.wantPrompt || saved.Completion.String != tc.wantCompletion {
+			t.Errorf("%q: unexpected saved content, prompt %q, completion %q", tc.policy, saved.Prompt, saved.Completion.String)
+		}
+		if saved.TotalTokens != 15 {
+			t.Errorf("%q: expected usage to be saved, got %d", tc.policy, saved.TotalTokens)
+		}
+	}
+}
+
+func TestLLMRoutesByPromptLength(t *testing.T) {
+	p := &scriptedProvider{contents: []string{"ok"}}
+	svcCtx, completions := newStreamTestContext(p)
+	scene := svcCtx.LlmSceneModel.(*fakeSceneModel).scene
+	scene.RoutingRules = sql.NullString{Valid: true,
+		String: `[{"name":"long","min_prompt_tokens":100,"provider_code":"blocking","model_code":"long-model"}]`}
+
+	for _, tc := range []struct {
+		content   string
+		wantModel string
+		wantRoute string
+	}{
+		{"hi", "blocking-model", "default"},
+		{strings.Repeat("长文本", 100), "long-model", "long"},
+	} {
+		_, err := NewLLMLogic(context.Background(), svcCtx).LLM(&bs_llm.LLMRequest{
+			SceneCode: "chat_general",
+			Messages:  []*bs_llm.ChatMessage{{Role: "user", Content: tc.content}},
+		})
+		if err != nil {
+			t.Fatalf("LLM failed: %v", err)
+		}
+		req := p.requests[len(p.requests)-1]
+		saved := completions.inserted[len(completions.inserted)-1]
+		if req.ModelCode != tc.wantModel || saved.ModelCode != tc.wantModel || saved.Route != tc.wantRoute {
+			t.Errorf("Expected model %s route %s, got request model %s, saved model %s route %q",
+				tc.wantModel, tc.wantRoute, req.ModelCode, saved.ModelCode, saved.Route)
+		}
+	}
+}
+
+// recordingCache 记录写入次数的响应缓存
+type recordingCache struct {
+	sets int
+}
+
+func (c *recordingCache) Get(ctx context.Context, key string) (*cache.Entry, error) { return nil, nil }
+
+func (c *recordingCache) Set(ctx context.Context, key, sceneCode string, entry *cache.Entry, ttl time.Duration) error {
+	c.sets++
+	return nil
+}
+
+func TestLLMResponseCacheFollowsContentPolicy(t *testing.T) {
+	cases := []struct {
+		policy     string
+		wantShared int
+		wantLocal  int
+	}{
+		{"discard", 0, 0},
+		{"redact", 0, 1},
+		{"raw", 1, 0},
+	}
+	for _, tc := range cases {
+		svcCtx, _ := newStreamTestContext(&scriptedProvider{contents: []string{"已记录 a@b.com"}})
+		shared, local := &recordingCache{}, &recordingCache{}
+		svcCtx.ResponseCache, svcCtx.LocalResponseCache = shared, local
+		scene := svcCtx.LlmSceneModel.(*fakeSceneModel).scene
+		scene.ContentPolicy = tc.policy
+		scene.CacheTtl = 3600
+
+		if _, err := NewLLMLogic(context.Background(), svcCtx).LLM(&bs_llm.LLMRequest{
+			SceneCode: "chat_general",
+			Messages:  []*bs_llm.ChatMessage{{Role: "user", Content: "我的电话 13812345678"}},
+		}); err != nil {
+			t.Fatalf("%q: LLM failed: %v", tc.policy, err)
+		}
+		if shared.sets != tc.wantShared || local.sets != tc.wantLocal {
+			t.Errorf("%q: expected %d shared and %d local cache writes, got %d and %d",
+				tc.policy, tc.wantShared, tc.wantLocal, shared.sets, local.sets)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package logic
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/common"
+	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/bs/bs_llm/internal/svc"
+	"jxzy/common/errorx"
+)
+
+// 场景参数范围
+const (
+	minSceneTemperature = 0
+	maxSceneTemperature = 2
+	minSceneMaxTokens   = 1
+	maxSceneMaxTokens   = 131072
+)
+
+// validateScene 校验场景配置：必填字段、供应商已注册、温度和最大token数在范围内、降级链、脱敏规则、可覆盖参数和路由规则格式正确
+func validateScene(svcCtx *svc.ServiceContext, scene *bs_llm.Scene) error {
+	if scene == nil {
+		return errorx.NewCodeError(errorx.ErrCodeParamError, "scene is required")
+	}
+	if strings.TrimSpace(scene.SceneCode) == "" {
+		return errorx.NewCodeError(errorx.ErrCodeParamError, "scene_code is required")
+	}
+	if scene.ModelCode == "" {
+		return errorx.NewCodeError(errorx.ErrCodeParamError, "model_code is required")
+	}
+	if svcCtx.ProviderManager.GetProvider(scene.ProviderCode) == nil {
+		return errorx.NewCodeErrorf(errorx.ErrCodeParamError, "provider_code %q is not registered, available: %v",
+			scene.ProviderCode, svcCtx.ProviderManager.ListProviders())
+	}
+	if scene.Temperature < minSceneTemperature || scene.Temperature > maxSceneTemperature {
+		return errorx.NewCodeErrorf(errorx.ErrCodeParamError, "temperature must be between %d and %d", minSceneTemperature, maxSceneTemperature)
+	}
+	if scene.MaxTokens < minSceneMaxTokens || scene.MaxTokens > maxSceneMaxTokens {
+		return errorx.NewCodeErrorf(errorx.ErrCodeParamError, "max_tokens must be between %d and %d", minSceneMaxTokens, maxSceneMaxTokens)
+	}
+	if scene.CacheTtl < 0 {
+		return errorx.NewCodeError(errorx.ErrCodeParamError, "cache_ttl must not be negative")
+	}
+	if _, err := common.ParseContentPolicy(scene.ContentPolicy); err != nil {
+		return errorx.NewCodeError(errorx.ErrCodeParamError, err.Error())
+	}
+	if _, err := common.SceneRedactor(nil, scene.RedactPatterns); err != nil {
+		return errorx.NewCodeError(errorx.ErrCodeParamError, err.Error())
+	}
+	if _, err := common.ParseParamOverrides(scene.ParamOverrides); err != nil {
+		return errorx.NewCodeError(errorx.ErrCodeParamError, err.Error())
+	}
+	rules, err := common.ParseRoutingRules(scene.RoutingRules)
+	if err != nil {
+		return errorx.NewCodeError(errorx.ErrCodeParamError, err.Error())
+	}
+	for _, rule := range rules {
+		if svcCtx.ProviderManager.GetProvider(rule.ProviderCode) == nil {
+			return errorx.NewCodeErrorf(errorx.ErrCodeParamError, "routing rule %q provider_code %q is not registered", rule.Name, rule.ProviderCode)
+		}
+	}
+
+	if strings.TrimSpace(scene.FallbackProviders) != "" {
+		var fallbacks []*common.LLMCandidate
+		if err := json.Unmarshal([]byte(scene.FallbackProviders), &fallbacks); err != nil {
+			return errorx.NewCodeErrorf(errorx.ErrCodeParamError, "invalid fallback_providers: %v", err)
+		}
+		for _, fallback := range fallbacks {
+			if fallback == nil || fallback.ProviderCode == "" || fallback.ModelCode == "" {
+				return errorx.NewCodeError(errorx.ErrCodeParamError, "invalid fallback_providers: provider_code and model_code are required")
+			}
+			if svcCtx.ProviderManager.GetProvider(fallback.ProviderCode) == nil {
+				return errorx.NewCodeErrorf(errorx.ErrCodeParamError, "fallback provider_code %q is not registered", fallback.ProviderCode)
+			}
+		}
+	}
+	return nil
+}
+
+// toSceneModel 将 RPC 场景配置写入数据库模型，保留 Id、Deleted 和时间字段
+func toSceneModel(scene *bs_llm.Scene, data *model.LlmScene) {
+	data.SceneCode = strings.TrimSpace(scene.SceneCode)
+	data.SceneName = scene.SceneName
+	data.ProviderCode = scene.ProviderCode
+	data.ProviderName = scene.ProviderName
+	data.ModelCode = scene.ModelCode
+	data.ModelName = scene.ModelName
+	data.ModelDescription = nullString(scene.ModelDescription)
+	data.SceneDescription = nullString(scene.SceneDescription)
+	data.Temperature = scene.Temperature
+	data.MaxTokens = scene.MaxTokens
+	data.EnableStream = boolToInt(scene.EnableStream)
+	data.FallbackProviders = nullString(strings.TrimSpace(scene.FallbackProviders))
+	data.CacheTtl = scene.CacheTtl
+	data.CacheNondeterministic = boolToInt(scene.CacheNondeterministic)
+	data.ContentPolicy, _ = common.ParseContentPolicy(scene.ContentPolicy)
+	data.RedactPatterns = nullString(strings.TrimSpace(scene.RedactPatterns))
+	data.ParamOverrides = nullString(strings.TrimSpace(scene.ParamOverrides))
+	data.RoutingRules = nullString(strings.TrimSpace(scene.RoutingRules))
+}
+
+// toRPCScene 将数据库模型转换为 RPC 场景配置
+func toRPCScene(data *model.LlmScene) *bs_llm.Scene {
+	return &bs_llm.Scene{
+		Id:                    data.Id,
+		SceneCode:             data.SceneCode,
+		SceneName:             data.SceneName,
+		ProviderCode:          data.ProviderCode,
+		ProviderName:          data.ProviderName,
+		ModelCode:             data.ModelCode,
+		ModelName:             data.ModelName,
+		ModelDescription:      data.ModelDescription.String,
+		SceneDescription:      data.SceneDescription.String,
+		Temperature:           data.Temperature,
+		MaxTokens:             data.MaxTokens,
+		EnableStream:          data.EnableStream == 1,
+		FallbackProviders:     data.FallbackProviders.String,
+		CacheTtl:              data.CacheTtl,
+		CacheNondeterministic: data.CacheNondeterministic == 1,
+		ContentPolicy:         data.ContentPolicy,
+		RedactPatterns:        data.RedactPatterns.String,
+		ParamOverrides:        data.ParamOverrides.String,
+		RoutingRules:          data.RoutingRules.String,
+		Deleted:               data.Deleted == 1,
+		CreatedAt:             data.CreatedAt.Unix(),
+		UpdatedAt:             data.UpdatedAt.Unix(),
+	}
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
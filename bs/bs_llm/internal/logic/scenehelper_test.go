@@ -0,0 +1,77 @@
+package logic
+
+import (
+	"testing"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/config"
+	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/bs/bs_llm/internal/svc"
+	"jxzy/common/errorx"
+)
+
+func newValidScene() *bs_llm.Scene {
+	return &bs_llm.Scene{
+		SceneCode:    "chat_general",
+		ProviderCode: "doubao",
+		ModelCode:    "doubao-pro",
+		Temperature:  0.7,
+		MaxTokens:    1000,
+	}
+}
+
+func TestValidateScene(t *testing.T) {
+	svcCtx := svc.NewServiceContext(config.Config{})
+
+	if err := validateScene(svcCtx, newValidScene()); err != nil {
+		t.Fatalf("Expected valid scene, got %v", err)
+	}
+
+	cases := map[string]func(s *bs_llm.Scene){
+		"missing scene_code":    func(s *bs_llm.Scene) { s.SceneCode = " " },
+		"unknown provider":      func(s *bs_llm.Scene) { s.ProviderCode = "unknown" },
+		"temperature too high":  func(s *bs_llm.Scene) { s.Temperature = 2.5 },
+		"negative temperature":  func(s *bs_llm.Scene) { s.Temperature = -0.1 },
+		"max_tokens zero":       func(s *bs_llm.Scene) { s.MaxTokens = 0 },
+		"max_tokens too large":  func(s *bs_llm.Scene) { s.MaxTokens = maxSceneMaxTokens + 1 },
+		"invalid fallback json": func(s *bs_llm.Scene) { s.FallbackProviders = "{" },
+		"unknown fallback":      func(s *bs_llm.Scene) { s.FallbackProviders = `[{"provider_code":"unknown","model_code":"m"}]` },
+		"unknown policy":        func(s *bs_llm.Scene) { s.ContentPolicy = "encrypt" },
+		"invalid redact regex":  func(s *bs_llm.Scene) { s.RedactPatterns = `[{"name":"bad","pattern":"("}]` },
+		"empty redact pattern":  func(s *bs_llm.Scene) { s.RedactPatterns = `[{"name":"empty"}]` },
+		"unknown override":      func(s *bs_llm.Scene) { s.ParamOverrides = `["top_p","logit_bias"]` },
+		"unknown route provider": func(s *bs_llm.Scene) {
+			s.RoutingRules = `[{"name":"long","provider_code":"unknown","model_code":"m"}]`
+		},
+		"invalid user bucket": func(s *bs_llm.Scene) {
+			s.RoutingRules = `[{"name":"b","provider_code":"doubao","model_code":"m","user_bucket":[50,20]}]`
+		},
+	}
+	for name, mutate := range cases {
+		scene := newValidScene()
+		mutate(scene)
+		err := validateScene(svcCtx, scene)
+		codeErr, ok := err.(*errorx.CodeError)
+		if !ok || codeErr.Code != errorx.ErrCodeParamError {
+			t.Errorf("%s: expected param error, got %v", name, err)
+		}
+	}
+}
+
+func TestSceneConversion(t *testing.T) {
+	scene := newValidScene()
+	scene.EnableStream = true
+	scene.CacheTtl = 3600
+	scene.FallbackProviders = `[{"provider_code":"bailian","model_code":"qwen-plus"}]`
+
+	data := &model.LlmScene{Id: 7, Deleted: 0}
+	toSceneModel(scene, data)
+	if data.Id != 7 || data.EnableStream != 1 || !data.FallbackProviders.Valid || data.ModelDescription.Valid || data.ContentPolicy != "redact" {
+		t.Errorf("Unexpected model: %+v", data)
+	}
+
+	back := toRPCScene(data)
+	if back.SceneCode != scene.SceneCode || !back.EnableStream || back.CacheTtl != 3600 || back.FallbackProviders != scene.FallbackProviders {
+		t.Errorf("Unexpected round trip: %+v", back)
+	}
+}
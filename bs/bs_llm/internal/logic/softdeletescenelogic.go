@@ -0,0 +1,53 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/bs/bs_llm/internal/svc"
+	"jxzy/common/errorx"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type SoftDeleteSceneLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewSoftDeleteSceneLogic(ctx context.Context, svcCtx *svc.ServiceContext) *SoftDeleteSceneLogic {
+	return &SoftDeleteSceneLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 软删除场景，删除后 LLM/StreamLLM 调用该场景返回不存在
+func (l *SoftDeleteSceneLogic) SoftDeleteScene(in *bs_llm.SoftDeleteSceneRequest) (*bs_llm.SoftDeleteSceneResponse, error) {
+	if l.svcCtx.LlmSceneModel == nil {
+		return nil, fmt.Errorf("scene model not initialized")
+	}
+	if in.SceneCode == "" {
+		return nil, errorx.NewCodeError(errorx.ErrCodeParamError, "scene_code is required")
+	}
+
+	existing, err := l.svcCtx.LlmSceneModel.FindOneBySceneCode(l.ctx, in.SceneCode)
+	if err == model.ErrNotFound || (err == nil && existing.Deleted == 1) {
+		return nil, errorx.NewCodeErrorf(errorx.ErrCodeLLMSceneNotFound, "scene_code %s not found", in.SceneCode)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scene: %w", err)
+	}
+
+	if err := l.svcCtx.LlmSceneModel.SoftDelete(l.ctx, in.SceneCode); err != nil {
+		return nil, fmt.Errorf("failed to delete scene: %w", err)
+	}
+	l.svcCtx.InvalidateScene(in.SceneCode)
+
+	l.Infof("Scene deleted - SceneCode: %s", in.SceneCode)
+	return &bs_llm.SoftDeleteSceneResponse{Success: true}, nil
+}
@@ -10,7 +10,9 @@ import (
 
 	"jxzy/bs/bs_llm/bs_llm"
 	"jxzy/bs/bs_llm/internal/common"
+	"jxzy/bs/bs_llm/internal/model"
 	"jxzy/bs/bs_llm/internal/provider"
+	"jxzy/common/errorx"
 
 	"github.com/google/uuid"
 	"github.com/zeromicro/go-zero/core/logx"
@@ -18,14 +20,18 @@ import (
 
 type StreamLLMLogic struct {
 	common *common.LLMCommon
+	cancel context.CancelFunc // 取消上游供应商请求
 	logx.Logger
 }
 
 func NewStreamLLMLogic(ctx context.Context, svcCtx interface{}) *StreamLLMLogic {
+	// 供应商请求使用可取消的 context，客户端断开时立即取消上游请求
+	ctx, cancel := context.WithCancel(ctx)
 	commonLogic := common.NewLLMCommon(ctx, svcCtx)
 
 	return &StreamLLMLogic{
 		common: commonLogic,
+		cancel: cancel,
 		Logger: commonLogic.GetLogger(),
 	}
 }
@@ -34,6 +40,7 @@ func NewStreamLLMLogic(ctx context.Context, svcCtx interface{}) *StreamLLMLogic
 func (l *StreamLLMLogic) StreamLLM(in *bs_llm.LLMRequest, stream bs_llm.BsLlmService_StreamLLMServer) error {
 	startTime := time.Now()
 	requestId := uuid.New().String() // 生成请求ID
+	defer l.cancel()
 
 	l.Logger.Infof("StreamLLM called with scene_code: %s, request_id: %s, messages_count: %d",
 		in.SceneCode, requestId, len(in.Messages))
@@ -67,6 +74,12 @@ func (l *StreamLLMLogic) StreamLLM(in *bs_llm.LLMRequest, stream bs_llm.BsLlmSer
 		return err
 	}
 
+	// 检查调用配额（请求频率、每日token数）
+	if err := l.common.CheckQuota(in.SceneCode, userId); err != nil {
+		completion.ErrorMsg = sql.NullString{String: err.Error(), Valid: true}
+		return err
+	}
+
 	// 更新completion记录中的模型和供应商信息
 	completion.ModelCode = sceneConfig.ModelCode
 	completion.ProviderCode = sceneConfig.ProviderCode
@@ -82,33 +95,75 @@ func (l *StreamLLMLogic) StreamLLM(in *bs_llm.LLMRequest, stream bs_llm.BsLlmSer
 		return err
 	}
 
-	// 4. 获取供应商
-	l.Logger.Infof("Getting provider: %s", sceneConfig.ProviderCode)
-	llmProvider := l.common.GetProviderManager().GetProvider(sceneConfig.ProviderCode)
-	if llmProvider == nil {
-		err := fmt.Errorf("provider %s not supported", sceneConfig.ProviderCode)
+	// 4. 获取候选供应商（主供应商 + 降级链）
+	candidates, err := l.common.GetCandidates(sceneConfig)
+	if err != nil {
 		completion.ErrorMsg = sql.NullString{String: err.Error(), Valid: true}
 		return err
 	}
 
 	// 5. 构建请求
 	l.Logger.Debug("Building LLM request")
-	req := &provider.LLMRequest{
-		Messages:    common.ConvertToProviderMessages(in.Messages),
-		ModelCode:   sceneConfig.ModelCode,
-		Temperature: sceneConfig.Temperature,
-		MaxTokens:   sceneConfig.MaxTokens,
-		Stream:      true,
-		ExtraParams: in.ExtraParams,
-		Config:      l.common.GetProviderConfig(sceneConfig.ProviderCode),
+	tools, err := common.ConvertToProviderTools(in.Tools)
+	if err != nil {
+		completion.ErrorMsg = sql.NullString{String: err.Error(), Valid: true}
+		return err
+	}
+	if err := common.ValidateContentParts(in.Messages); err != nil {
+		completion.ErrorMsg = sql.NullString{String: err.Error(), Valid: true}
+		return err
+	}
+	messages := common.ConvertToProviderMessages(in.Messages)
+	structured, err := common.NewStructuredOutput(in.ResponseFormat)
+	if err != nil {
+		completion.ErrorMsg = sql.NullString{String: err.Error(), Valid: true}
+		return err
+	}
+	params, err := common.ResolveParams(sceneConfig, in.ExtraParams)
+	if err != nil {
+		completion.ErrorMsg = sql.NullString{String: err.Error(), Valid: true}
+		return err
+	}
+	var responseFormat *provider.ResponseFormat
+	if structured != nil {
+		responseFormat = structured.Format
+		messages = structured.Instruct(messages)
 	}
 
-	l.Logger.Infof("LLM request built - Model: %s, Temperature: %f, MaxTokens: %d, Messages: %d",
-		req.ModelCode, req.Temperature, req.MaxTokens, len(req.Messages))
-
-	// 6. 调用流式LLM
+	// 6. 调用流式LLM，第一个增量发送之前失败时按场景配置重试和降级
 	l.Logger.Debug("Calling stream LLM")
-	streamReader, err := llmProvider.StreamLLM(l.common.GetContext(), req)
+	var streamReader provider.StreamReader
+	candidate, attempt, err := l.common.ExecuteWithFallback(candidates, func(ctx context.Context, candidate *common.LLMCandidate, llmProvider provider.Provider, providerConfig *provider.ProviderConfig) error {
+		req := &provider.LLMRequest{
+			SceneCode:      sceneConfig.SceneCode,
+			Messages:       messages,
+			ModelCode:      candidate.ModelCode,
+			Stream:         true,
+			ExtraParams:    l.common.MergeExtraParams(providerConfig, in.ExtraParams),
+			Tools:          tools,
+			ToolChoice:     in.ToolChoice,
+			ResponseFormat: responseFormat,
+			Config:         providerConfig,
+		}
+		params.Apply(req)
+
+		l.Logger.Infof("LLM request built - Model: %s, Temperature: %f, MaxTokens: %d, Messages: %d, Tools: %d",
+			req.ModelCode, req.Temperature, req.MaxTokens, len(req.Messages), len(req.Tools))
+
+		reader, err := common.OpenStream(ctx, llmProvider, req)
+		if err != nil {
+			return err
+		}
+		streamReader = reader
+		return nil
+	})
+
+	// 记录实际调用的供应商、模型及尝试序号
+	completion.Attempt = int64(attempt)
+	if candidate != nil {
+		completion.ProviderCode = candidate.ProviderCode
+		completion.ModelCode = candidate.ModelCode
+	}
 	if err != nil {
 		l.Logger.Errorf("Failed to call stream LLM: %v", err)
 		completion.ErrorMsg = sql.NullString{String: fmt.Sprintf("failed to call stream LLM: %v", err), Valid: true}
@@ -121,7 +176,9 @@ func (l *StreamLLMLogic) StreamLLM(in *bs_llm.LLMRequest, stream bs_llm.BsLlmSer
 	responseCount := 0
 	// 7. 处理流式响应
 	var completionText strings.Builder
+	var reasoningText strings.Builder // 推理模型的思考内容，与回答分开保存
 	var finalUsage *bs_llm.LLMUsage
+	var toolCalls []*provider.ToolCall
 
 	for {
 		response, err := streamReader.Read()
@@ -130,25 +187,35 @@ func (l *StreamLLMLogic) StreamLLM(in *bs_llm.LLMRequest, stream bs_llm.BsLlmSer
 				l.Logger.Infof("LLM stream ended normally after %d responses", responseCount)
 				break
 			}
+			if l.common.GetContext().Err() != nil {
+				// 客户端已断开，上游请求随 context 取消
+				l.Logger.Infof("Client canceled stream after %d responses", responseCount)
+				l.recordCancelled(completion, candidate.ModelCode, messages, completionText.String(), reasoningText.String(), toolCalls, err)
+				return fmt.Errorf("stream canceled by client: %w", err)
+			}
 			l.Logger.Errorf("Failed to read stream response: %v", err)
 			completion.ErrorMsg = sql.NullString{String: fmt.Sprintf("failed to read stream response: %v", err), Valid: true}
 			return fmt.Errorf("failed to read stream response: %w", err)
 		}
 
 		responseCount++
-		l.Logger.Infof("Received LLM response %d - Delta: %s, Finished: %v", responseCount, response.Delta(), response.Finished())
+		l.Logger.Debugf("Received LLM response %d - Delta: %s, Finished: %v", responseCount, l.common.RedactForLog(response.Delta()), response.Finished())
 
-		// 累积完整的回答内容
+		// 累积完整的回答内容和推理过程
 		if response.Delta() != "" {
 			completionText.WriteString(response.Delta())
 		}
+		reasoningText.WriteString(response.ReasoningDelta())
+		toolCalls = common.MergeToolCallDeltas(toolCalls, response.ToolCalls())
 
 		// 构建gRPC流式响应
 		streamResp := &bs_llm.StreamLLMResponse{
-			Delta:        response.Delta(),
-			ModelId:      sceneConfig.ModelCode,
-			Finished:     response.Finished(),
-			FinishReason: response.FinishReason(),
+			Delta:          response.Delta(),
+			ModelId:        candidate.ModelCode,
+			Finished:       response.Finished(),
+			FinishReason:   response.FinishReason(),
+			ToolCalls:      common.ConvertToRPCToolCalls(response.ToolCalls()),
+			ReasoningDelta: response.ReasoningDelta(),
 		}
 
 		// 如果已完成，保存usage信息
@@ -160,22 +227,26 @@ func (l *StreamLLMLogic) StreamLLM(in *bs_llm.LLMRequest, stream bs_llm.BsLlmSer
 		}
 
 		// 发送响应
+		// 发送失败说明客户端已断开，立即取消上游请求并保存已生成的部分内容
 		if err := stream.Send(streamResp); err != nil {
 			l.Logger.Errorf("Failed to send stream response: %v", err)
-			completion.ErrorMsg = sql.NullString{String: fmt.Sprintf("failed to send stream response: %v", err), Valid: true}
+			l.cancel()
+			l.recordCancelled(completion, candidate.ModelCode, messages, completionText.String(), reasoningText.String(), toolCalls, err)
 			return fmt.Errorf("failed to send stream response: %w", err)
 		}
 
 		// 如果已完成，退出循环
 		if response.Finished() {
 			l.Logger.Infof("Stream completed for scene: %s, model: %s, total responses: %d",
-				sceneConfig.SceneCode, sceneConfig.ModelCode, responseCount)
+				sceneConfig.SceneCode, candidate.ModelCode, responseCount)
 			break
 		}
 	}
 
 	// 8. 更新completion记录为成功状态
-	completion.Completion = sql.NullString{String: completionText.String(), Valid: true}
+	completionRecord := common.BuildCompletionText(completionText.String(), toolCalls)
+	completion.Completion = sql.NullString{String: completionRecord, Valid: true}
+	completion.Reasoning = sql.NullString{String: reasoningText.String(), Valid: reasoningText.Len() > 0}
 	completion.Status = 1 // 成功
 
 	// 设置 token 使用情况
@@ -184,21 +255,51 @@ func (l *StreamLLMLogic) StreamLLM(in *bs_llm.LLMRequest, stream bs_llm.BsLlmSer
 		completion.InputTokens = finalUsage.PromptTokens
 		completion.OutputTokens = finalUsage.CompletionTokens
 		completion.TotalTokens = finalUsage.TotalTokens
-		l.Logger.Infof("Using LLM provided usage - Input: %d, Output: %d, Total: %d",
-			finalUsage.PromptTokens, finalUsage.CompletionTokens, finalUsage.TotalTokens)
+		completion.ReasoningTokens = finalUsage.ReasoningTokens
+		l.Logger.Infof("Using LLM provided usage - Input: %d, Output: %d, Total: %d, Reasoning: %d",
+			finalUsage.PromptTokens, finalUsage.CompletionTokens, finalUsage.TotalTokens, finalUsage.ReasoningTokens)
 	} else {
-		// 如果没有 usage 信息，自己计算 token 数量
-		inputTokens := l.common.EstimateTokens(completion.Prompt)
-		outputTokens := l.common.EstimateTokens(completionText.String())
-		totalTokens := inputTokens + outputTokens
+		// 如果没有 usage 信息，使用模型对应的分词器计算 token 数量，推理过程计入输出
+		l.countUsage(completion, candidate.ModelCode, messages, completionRecord, reasoningText.String())
+		l.Logger.Infof("Counted token usage - Input: %d, Output: %d, Total: %d, Reasoning: %d",
+			completion.InputTokens, completion.OutputTokens, completion.TotalTokens, completion.ReasoningTokens)
+	}
 
-		completion.InputTokens = inputTokens
-		completion.OutputTokens = outputTokens
-		completion.TotalTokens = totalTokens
-		l.Logger.Infof("Estimated token usage - Input: %d, Output: %d, Total: %d",
-			inputTokens, outputTokens, totalTokens)
+	// 9. 流式输出已发送给客户端，无法修复，仅校验结构化输出并在不符合时返回错误
+	if structured != nil && len(toolCalls) == 0 {
+		if _, err := structured.Validate(completionText.String()); err != nil {
+			l.Logger.Errorf("Structured output validation failed: %v", err)
+			completion.Status = 0 // 失败
+			completion.ErrorMsg = sql.NullString{String: fmt.Sprintf("invalid structured output: %v", err), Valid: true}
+			return errorx.NewCodeErrorf(errorx.ErrCodeLLMInvalidOutput, "LLM输出不符合response_format: %v", err)
+		}
 	}
 
 	l.Logger.Info("StreamLLM logic completed successfully")
 	return nil
 }
+
+// recordCancelled 将客户端取消的请求记录为取消状态，保存已生成的部分内容及估算的 token 用量
+func (l *StreamLLMLogic) recordCancelled(completion *model.LlmCompletion, modelCode string, messages []*provider.ChatMessage,
+	text, reasoning string, toolCalls []*provider.ToolCall, cause error) {
+	completionRecord := common.BuildCompletionText(text, toolCalls)
+	completion.Completion = sql.NullString{String: completionRecord, Valid: completionRecord != ""}
+	completion.Reasoning = sql.NullString{String: reasoning, Valid: reasoning != ""}
+	completion.Status = 3 // 客户端取消
+	completion.ErrorMsg = sql.NullString{String: fmt.Sprintf("stream canceled by client: %v", cause), Valid: true}
+	l.countUsage(completion, modelCode, messages, completionRecord, reasoning)
+	l.Logger.Infof("Recorded canceled stream - Input: %d, Output: %d, Total: %d",
+		completion.InputTokens, completion.OutputTokens, completion.TotalTokens)
+}
+
+// countUsage 使用模型对应的分词器计算 token 用量，推理过程计入输出 token
+func (l *StreamLLMLogic) countUsage(completion *model.LlmCompletion, modelCode string, messages []*provider.ChatMessage,
+	completionRecord, reasoning string) {
+	completion.ReasoningTokens = 0
+	if reasoning != "" {
+		completion.ReasoningTokens = l.common.CountTokens(modelCode, reasoning)
+	}
+	completion.InputTokens = l.common.CountMessageTokens(modelCode, messages)
+	completion.OutputTokens = l.common.CountTokens(modelCode, completionRecord) + completion.ReasoningTokens
+	completion.TotalTokens = completion.InputTokens + completion.OutputTokens
+}
@@ -0,0 +1,247 @@
+package logic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/config"
+	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/bs/bs_llm/internal/provider"
+	"jxzy/bs/bs_llm/internal/provider/fake"
+	"jxzy/bs/bs_llm/internal/svc"
+
+	"google.golang.org/grpc"
+)
+
+type fakeSceneModel struct {
+	model.LlmSceneModel
+	scene *model.LlmScene
+}
+
+func (m *fakeSceneModel) FindOneBySceneCode(ctx context.Context, sceneCode string) (*model.LlmScene, error) {
+	return m.scene, nil
+}
+
+type fakeCompletionModel struct {
+	model.LlmCompletionModel
+	mu       sync.Mutex
+	inserted []*model.LlmCompletion
+}
+
+func (m *fakeCompletionModel) Insert(ctx context.Context, data *model.LlmCompletion) (sql.Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inserted = append(m.inserted, data)
+	return nil, nil
+}
+
+// blockingProvider 先返回预设增量，之后阻塞直到请求 context 被取消
+type blockingProvider struct {
+	deltas []string
+	ctx    context.Context
+	opened chan struct{}
+}
+
+func (p *blockingProvider) Name() string { return "blocking" }
+
+func (p *blockingProvider) CallLLM(ctx context.Context, req *provider.LLMRequest) (*provider.LLMResponse, error) {
+	return nil, errors.New("not supported")
+}
+
+func (p *blockingProvider) StreamLLM(ctx context.Context, req *provider.LLMRequest) (provider.StreamReader, error) {
+	p.ctx = ctx
+	if p.opened != nil {
+		close(p.opened)
+	}
+	return &blockingStreamReader{ctx: ctx, deltas: append([]string(nil), p.deltas...)}, nil
+}
+
+func (p *blockingProvider) HealthCheck(ctx context.Context) error { return nil }
+
+type blockingStreamReader struct {
+	ctx    context.Context
+	deltas []string
+}
+
+func (r *blockingStreamReader) Read() (provider.StreamResponse, error) {
+	if len(r.deltas) > 0 {
+		delta := r.deltas[0]
+		r.deltas = r.deltas[1:]
+		return provider.NewStreamResponse(delta, false, nil, "", nil), nil
+	}
+	<-r.ctx.Done()
+	return nil, r.ctx.Err()
+}
+
+func (r *blockingStreamReader) Close() error { return nil }
+
+// fakeStreamServer 发送指定次数后返回错误，模拟客户端断开
+type fakeStreamServer struct {
+	grpc.ServerStream
+	ctx     context.Context
+	sendOK  int
+	sent    []*bs_llm.StreamLLMResponse
+	sendErr error
+}
+
+func (s *fakeStreamServer) Context() context.Context { return s.ctx }
+
+func (s *fakeStreamServer) Send(resp *bs_llm.StreamLLMResponse) error {
+	if len(s.sent) >= s.sendOK {
+		return s.sendErr
+	}
+	s.sent = append(s.sent, resp)
+	return nil
+}
+
+func newStreamTestContext(p provider.Provider) (*svc.ServiceContext, *fakeCompletionModel) {
+	svcCtx := svc.NewServiceContext(config.Config{})
+	svcCtx.ProviderManager.RegisterWithConfig("blocking", p, &provider.ProviderConfig{})
+	svcCtx.LlmSceneModel = &fakeSceneModel{scene: &model.LlmScene{
+		SceneCode:    "chat_general",
+		ProviderCode: "blocking",
+		ModelCode:    "blocking-model",
+		EnableStream: 1,
+	}}
+	completions := &fakeCompletionModel{}
+	svcCtx.LlmCompletionModel = completions
+	return svcCtx, completions
+}
+
+func TestStreamLLMSendFailureCancelsUpstream(t *testing.T) {
+	p := &blockingProvider{deltas: []string{"hel", "lo"}}
+	svcCtx, completions := newStreamTestContext(p)
+	stream := &fakeStreamServer{ctx: context.Background(), sendOK: 1, sendErr: errors.New("transport is closing")}
+
+	err := NewStreamLLMLogic(stream.Context(), svcCtx).StreamLLM(&bs_llm.LLMRequest{
+		SceneCode: "chat_general",
+		Messages:  []*bs_llm.ChatMessage{{Role: "user", Content: "hi"}},
+	}, stream)
+	if err == nil {
+		t.Fatal("Expected error when send fails")
+	}
+	if p.ctx.Err() == nil {
+		t.Error("Expected upstream context to be canceled")
+	}
+
+	if len(completions.inserted) != 1 {
+		t.Fatalf("Expected 1 completion record, got %d", len(completions.inserted))
+	}
+	completion := completions.inserted[0]
+	if completion.Status != 3 {
+		t.Errorf("Expected canceled status 3, got %d", completion.Status)
+	}
+	if completion.Completion.String != "hello" {
+		t.Errorf("Expected partial completion 'hello', got '%s'", completion.Completion.String)
+	}
+	if completion.OutputTokens == 0 || completion.TotalTokens != completion.InputTokens+completion.OutputTokens {
+		t.Errorf("Expected counted tokens, got input=%d output=%d total=%d",
+			completion.InputTokens, completion.OutputTokens, completion.TotalTokens)
+	}
+}
+
+func TestStreamLLMClientCancel(t *testing.T) {
+	p := &blockingProvider{deltas: []string{"partial"}, opened: make(chan struct{})}
+	svcCtx, completions := newStreamTestContext(p)
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &fakeStreamServer{ctx: ctx, sendOK: 10}
+
+	// 收到第一个增量后客户端断开
+	go func() {
+		<-p.opened
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	err := NewStreamLLMLogic(stream.Context(), svcCtx).StreamLLM(&bs_llm.LLMRequest{
+		SceneCode: "chat_general",
+		Messages:  []*bs_llm.ChatMessage{{Role: "user", Content: "hi"}},
+	}, stream)
+	if err == nil {
+		t.Fatal("Expected error when client cancels")
+	}
+	if len(completions.inserted) != 1 {
+		t.Fatalf("Expected 1 completion record, got %d", len(completions.inserted))
+	}
+	completion := completions.inserted[0]
+	if completion.Status != 3 || completion.Completion.String != "partial" {
+		t.Errorf("Expected canceled record with partial text, got status=%d completion='%s'",
+			completion.Status, completion.Completion.String)
+	}
+}
+
+func TestStreamLLMWithFakeProvider(t *testing.T) {
+	p, err := fake.NewFakeProvider("fake", &fake.Script{Rules: []fake.Rule{
+		{SceneCode: "chat_general", Pattern: "^flaky", Response: fake.Response{
+			Content: "你好，世界", ChunkSize: 2, ErrorStatus: 503, FailTimes: 1, PromptTokens: 8,
+		}},
+	}})
+	if err != nil {
+		t.Fatalf("NewFakeProvider failed: %v", err)
+	}
+	svcCtx, completions := newStreamTestContext(p)
+	svcCtx.ProviderManager.RegisterWithConfig("blocking", p, &provider.ProviderConfig{RetryCount: 1})
+	stream := &fakeStreamServer{ctx: context.Background(), sendOK: 10}
+
+	// 第一次调用注入 503，重试后成功
+	err = NewStreamLLMLogic(stream.Context(), svcCtx).StreamLLM(&bs_llm.LLMRequest{
+		SceneCode: "chat_general",
+		Messages:  []*bs_llm.ChatMessage{{Role: "user", Content: "flaky request"}},
+	}, stream)
+	if err != nil {
+		t.Fatalf("StreamLLM failed: %v", err)
+	}
+
+	var deltas []string
+	for _, resp := range stream.sent {
+		deltas = append(deltas, resp.Delta)
+	}
+	if strings.Join(deltas, "|") != "你好|，世|界|" {
+		t.Errorf("Unexpected deltas: %q", deltas)
+	}
+	completion := completions.inserted[0]
+	if completion.Status != 1 || completion.Completion.String != "你好，世界" || completion.Attempt != 2 || completion.TotalTokens != 13 {
+		t.Errorf("Unexpected completion record: %+v", completion)
+	}
+}
+
+func TestStreamLLMReasoning(t *testing.T) {
+	p, _ := fake.NewFakeProvider("fake", &fake.Script{Default: fake.Response{
+		Content: "答案是42", Reasoning: "先算一下", ChunkSize: 2, PromptTokens: 5,
+	}})
+	svcCtx, completions := newStreamTestContext(p)
+	stream := &fakeStreamServer{ctx: context.Background(), sendOK: 10}
+
+	err := NewStreamLLMLogic(stream.Context(), svcCtx).StreamLLM(&bs_llm.LLMRequest{
+		SceneCode: "chat_general",
+		Messages:  []*bs_llm.ChatMessage{{Role: "user", Content: "hi"}},
+	}, stream)
+	if err != nil {
+		t.Fatalf("StreamLLM failed: %v", err)
+	}
+
+	var reasoning, deltas strings.Builder
+	for _, resp := range stream.sent {
+		reasoning.WriteString(resp.ReasoningDelta)
+		deltas.WriteString(resp.Delta)
+	}
+	if reasoning.String() != "先算一下" || deltas.String() != "答案是42" {
+		t.Errorf("Unexpected stream: reasoning %q, content %q", reasoning.String(), deltas.String())
+	}
+	last := stream.sent[len(stream.sent)-1]
+	if last.Usage == nil || last.Usage.ReasoningTokens != 4 {
+		t.Errorf("Expected reasoning tokens in final usage, got %+v", last.Usage)
+	}
+
+	completion := completions.inserted[0]
+	if completion.Completion.String != "答案是42" || completion.Reasoning.String != "先算一下" || completion.ReasoningTokens != 4 {
+		t.Errorf("Expected reasoning stored separately, got completion %q reasoning %q tokens %d",
+			completion.Completion.String, completion.Reasoning.String, completion.ReasoningTokens)
+	}
+}
@@ -0,0 +1,60 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/bs/bs_llm/internal/svc"
+	"jxzy/common/errorx"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+type UpdateSceneLogic struct {
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+	logx.Logger
+}
+
+func NewUpdateSceneLogic(ctx context.Context, svcCtx *svc.ServiceContext) *UpdateSceneLogic {
+	return &UpdateSceneLogic{
+		ctx:    ctx,
+		svcCtx: svcCtx,
+		Logger: logx.WithContext(ctx),
+	}
+}
+
+// 按 scene_code 整体更新场景
+func (l *UpdateSceneLogic) UpdateScene(in *bs_llm.UpdateSceneRequest) (*bs_llm.UpdateSceneResponse, error) {
+	if l.svcCtx.LlmSceneModel == nil {
+		return nil, fmt.Errorf("scene model not initialized")
+	}
+	if err := validateScene(l.svcCtx, in.Scene); err != nil {
+		return nil, err
+	}
+	sceneCode := strings.TrimSpace(in.Scene.SceneCode)
+
+	existing, err := l.svcCtx.LlmSceneModel.FindOneBySceneCode(l.ctx, sceneCode)
+	if err == model.ErrNotFound || (err == nil && existing.Deleted == 1) {
+		return nil, errorx.NewCodeErrorf(errorx.ErrCodeLLMSceneNotFound, "scene_code %s not found", sceneCode)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scene: %w", err)
+	}
+
+	toSceneModel(in.Scene, existing)
+	if err := l.svcCtx.LlmSceneModel.Update(l.ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to update scene: %w", err)
+	}
+	l.svcCtx.InvalidateScene(sceneCode)
+
+	updated, err := l.svcCtx.LlmSceneModel.FindOneBySceneCode(l.ctx, sceneCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query updated scene: %w", err)
+	}
+	l.Infof("Scene updated - SceneCode: %s, ProviderCode: %s, ModelCode: %s", updated.SceneCode, updated.ProviderCode, updated.ModelCode)
+	return &bs_llm.UpdateSceneResponse{Scene: toRPCScene(updated)}, nil
+}
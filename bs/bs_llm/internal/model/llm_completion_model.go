@@ -1,6 +1,13 @@
 package model
 
-import "github.com/zeromicro/go-zero/core/stores/sqlx"
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
 
 var _ LlmCompletionModel = (*customLlmCompletionModel)(nil)
 
@@ -9,16 +16,155 @@ type (
 	// and implement the added methods in customLlmCompletionModel.
 	LlmCompletionModel interface {
 		llmCompletionModel
+		SumTokensSince(ctx context.Context, userId, sceneCode string, since time.Time) (int64, error)
+		SumUsage(ctx context.Context, filter *UsageFilter, groupBy []string, limit int64) ([]*UsageRow, error)
 	}
 
 	customLlmCompletionModel struct {
 		*defaultLlmCompletionModel
 	}
+
+	// UsageFilter 用量统计条件，字段为空时不过滤
+	UsageFilter struct {
+		Start     time.Time // 开始时间（含）
+		End       time.Time // 结束时间（不含）
+		UserId    string
+		SceneCode string
+		ModelCode string
+	}
+
+	// UsageRow 用量统计结果，未参与聚合的维度为空
+	UsageRow struct {
+		UserId       string  `db:"user_id"`
+		SceneCode    string  `db:"scene_code"`
+		ProviderCode string  `db:"provider_code"`
+		ModelCode    string  `db:"model_code"`
+		Route        string  `db:"route"`
+		Day          string  `db:"day"`
+		Requests     int64   `db:"requests"`
+		CacheHits    int64   `db:"cache_hits"`
+		InputTokens  int64   `db:"input_tokens"`
+		OutputTokens int64   `db:"output_tokens"`
+		TotalTokens  int64   `db:"total_tokens"`
+		Cost         float64 `db:"cost"`
+		Currency     string  `db:"currency"`
+	}
+)
+
+// 用量统计的聚合维度
+const (
+	UsageGroupUser     = "user"
+	UsageGroupScene    = "scene"
+	UsageGroupProvider = "provider"
+	UsageGroupModel    = "model"
+	UsageGroupRoute    = "route"
+	UsageGroupDay      = "day"
 )
 
+// usageGroupColumns 聚合维度对应的列，按结果列的顺序排列
+var usageGroupColumns = []struct {
+	group  string
+	column string
+	expr   string
+}{
+	{UsageGroupUser, "user_id", "`user_id`"},
+	{UsageGroupScene, "scene_code", "`scene_code`"},
+	{UsageGroupProvider, "provider_code", "`provider_code`"},
+	{UsageGroupModel, "model_code", "`model_code`"},
+	{UsageGroupRoute, "route", "`route`"},
+	{UsageGroupDay, "day", "date_format(`created_at`, '%Y-%m-%d')"},
+}
+
 // NewLlmCompletionModel returns a model for the database table.
 func NewLlmCompletionModel(conn sqlx.SqlConn) LlmCompletionModel {
 	return &customLlmCompletionModel{
 		defaultLlmCompletionModel: newLlmCompletionModel(conn),
 	}
 }
+
+// SumTokensSince 统计用户在场景下自 since 起消耗的 token 总数，命中响应缓存的调用不计入
+func (m *customLlmCompletionModel) SumTokensSince(ctx context.Context, userId, sceneCode string, since time.Time) (int64, error) {
+	query := fmt.Sprintf("select coalesce(sum(`total_tokens`), 0) from %s where `user_id` = ? and `scene_code` = ? and `created_at` >= ? and `cache_hit` = 0", m.table)
+	var total int64
+	if err := m.conn.QueryRowCtx(ctx, &total, query, userId, sceneCode, since); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// SumUsage 按维度聚合调用次数、token 用量和费用，按日期升序、费用降序排列
+// 命中响应缓存的调用计入调用次数，不计入 token 用量；不同币种的费用不能相加，总是额外按币种分组，
+// groupBy 为空时每个币种返回一行汇总
+func (m *customLlmCompletionModel) SumUsage(ctx context.Context, filter *UsageFilter, groupBy []string, limit int64) ([]*UsageRow, error) {
+	grouped := make(map[string]bool, len(groupBy))
+	for _, group := range groupBy {
+		grouped[group] = true
+	}
+
+	columns := []string{"`currency`"}
+	groups := []string{"`currency`"}
+	for _, c := range usageGroupColumns {
+		if grouped[c.group] {
+			columns = append(columns, fmt.Sprintf("%s as `%s`", c.expr, c.column))
+			groups = append(groups, "`"+c.column+"`")
+		} else {
+			columns = append(columns, fmt.Sprintf("'' as `%s`", c.column))
+		}
+	}
+	columns = append(columns,
+		"count(*) as `requests`",
+		"cast(coalesce(sum(`cache_hit`), 0) as signed) as `cache_hits`",
+		"cast(coalesce(sum(if(`cache_hit` = 0, `input_tokens`, 0)), 0) as signed) as `input_tokens`",
+		"cast(coalesce(sum(if(`cache_hit` = 0, `output_tokens`, 0)), 0) as signed) as `output_tokens`",
+		"cast(coalesce(sum(if(`cache_hit` = 0, `total_tokens`, 0)), 0) as signed) as `total_tokens`",
+		"coalesce(sum(`cost`), 0) as `cost`",
+	)
+
+	where, args := filter.where()
+	query := fmt.Sprintf("select %s from %s%s", strings.Join(columns, ", "), m.table, where)
+	order := "`cost` desc, `currency`"
+	if grouped[UsageGroupDay] {
+		order = "`day`, " + order
+	}
+	query += fmt.Sprintf(" group by %s order by %s", strings.Join(groups, ", "), order)
+	if limit > 0 {
+		query += " limit ?"
+		args = append(args, limit)
+	}
+
+	var resp []*UsageRow
+	if err := m.conn.QueryRowsCtx(ctx, &resp, query, args...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// where 构建查询条件
+func (f *UsageFilter) where() (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	if f != nil && !f.Start.IsZero() {
+		conditions = append(conditions, "`created_at` >= ?")
+		args = append(args, f.Start)
+	}
+	if f != nil && !f.End.IsZero() {
+		conditions = append(conditions, "`created_at` < ?")
+		args = append(args, f.End)
+	}
+	if f != nil && f.UserId != "" {
+		conditions = append(conditions, "`user_id` = ?")
+		args = append(args, f.UserId)
+	}
+	if f != nil && f.SceneCode != "" {
+		conditions = append(conditions, "`scene_code` = ?")
+		args = append(args, f.SceneCode)
+	}
+	if f != nil && f.ModelCode != "" {
+		conditions = append(conditions, "`model_code` = ?")
+		args = append(args, f.ModelCode)
+	}
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " where " + strings.Join(conditions, " and "), args
+}
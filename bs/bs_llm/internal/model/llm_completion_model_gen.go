@@ -36,21 +36,29 @@ type (
 	}
 
 	LlmCompletion struct {
-		Id           int64           `db:"id"`
-		SceneCode    string          `db:"scene_code"`
-		Prompt       string          `db:"prompt"`
-		Completion   sql.NullString  `db:"completion"`
-		InputTokens  int64           `db:"input_tokens"`
-		OutputTokens int64           `db:"output_tokens"`
-		TotalTokens  int64           `db:"total_tokens"`
-		ModelCode    string          `db:"model_code"`
-		ProviderCode string          `db:"provider_code"`
-		RequestId    string          `db:"request_id"`
-		Status       int64           `db:"status"`
-		ErrorMsg     sql.NullString  `db:"error_msg"`
-		ResponseTime sql.NullFloat64 `db:"response_time"`
-		UserId       string          `db:"user_id"`
-		CreatedAt    time.Time       `db:"created_at"`
+		Id              int64           `db:"id"`
+		SceneCode       string          `db:"scene_code"`
+		Prompt          string          `db:"prompt"`
+		Completion      sql.NullString  `db:"completion"`
+		Reasoning       sql.NullString  `db:"reasoning"`
+		InputTokens     int64           `db:"input_tokens"`
+		OutputTokens    int64           `db:"output_tokens"`
+		TotalTokens     int64           `db:"total_tokens"`
+		ReasoningTokens int64           `db:"reasoning_tokens"`
+		ModelCode       string          `db:"model_code"`
+		ProviderCode    string          `db:"provider_code"`
+		RequestId       string          `db:"request_id"`
+		Status          int64           `db:"status"`
+		ErrorMsg        sql.NullString  `db:"error_msg"`
+		ResponseTime    sql.NullFloat64 `db:"response_time"`
+		UserId          string          `db:"user_id"`
+		Attempt         int64           `db:"attempt"`
+		CacheHit        int64           `db:"cache_hit"`
+		BatchId         string          `db:"batch_id"`
+		Cost            float64         `db:"cost"`
+		Currency        string          `db:"currency"`
+		Route           string          `db:"route"`
+		CreatedAt       time.Time       `db:"created_at"`
 	}
 )
 
@@ -82,14 +90,14 @@ func (m *defaultLlmCompletionModel) FindOne(ctx context.Context, id int64) (*Llm
 }
 
 func (m *defaultLlmCompletionModel) Insert(ctx context.Context, data *LlmCompletion) (sql.Result, error) {
-	query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", m.table, llmCompletionRowsExpectAutoSet)
-	ret, err := m.conn.ExecCtx(ctx, query, data.SceneCode, data.Prompt, data.Completion, data.InputTokens, data.OutputTokens, data.TotalTokens, data.ModelCode, data.ProviderCode, data.RequestId, data.Status, data.ErrorMsg, data.ResponseTime, data.UserId)
+	query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", m.table, llmCompletionRowsExpectAutoSet)
+	ret, err := m.conn.ExecCtx(ctx, query, data.SceneCode, data.Prompt, data.Completion, data.Reasoning, data.InputTokens, data.OutputTokens, data.TotalTokens, data.ReasoningTokens, data.ModelCode, data.ProviderCode, data.RequestId, data.Status, data.ErrorMsg, data.ResponseTime, data.UserId, data.Attempt, data.CacheHit, data.BatchId, data.Cost, data.Currency, data.Route)
 	return ret, err
 }
 
 func (m *defaultLlmCompletionModel) Update(ctx context.Context, data *LlmCompletion) error {
 	query := fmt.Sprintf("update %s set %s where `id` = ?", m.table, llmCompletionRowsWithPlaceHolder)
-	_, err := m.conn.ExecCtx(ctx, query, data.SceneCode, data.Prompt, data.Completion, data.InputTokens, data.OutputTokens, data.TotalTokens, data.ModelCode, data.ProviderCode, data.RequestId, data.Status, data.ErrorMsg, data.ResponseTime, data.UserId, data.Id)
+	_, err := m.conn.ExecCtx(ctx, query, data.SceneCode, data.Prompt, data.Completion, data.Reasoning, data.InputTokens, data.OutputTokens, data.TotalTokens, data.ReasoningTokens, data.ModelCode, data.ProviderCode, data.RequestId, data.Status, data.ErrorMsg, data.ResponseTime, data.UserId, data.Attempt, data.CacheHit, data.BatchId, data.Cost, data.Currency, data.Route, data.Id)
 	return err
 }
 
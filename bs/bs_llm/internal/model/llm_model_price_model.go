@@ -0,0 +1,40 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ LlmModelPriceModel = (*customLlmModelPriceModel)(nil)
+
+type (
+	// LlmModelPriceModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customLlmModelPriceModel.
+	LlmModelPriceModel interface {
+		llmModelPriceModel
+		FindAll(ctx context.Context) ([]*LlmModelPrice, error)
+	}
+
+	customLlmModelPriceModel struct {
+		*defaultLlmModelPriceModel
+	}
+)
+
+// NewLlmModelPriceModel returns a model for the database table.
+func NewLlmModelPriceModel(conn sqlx.SqlConn) LlmModelPriceModel {
+	return &customLlmModelPriceModel{
+		defaultLlmModelPriceModel: newLlmModelPriceModel(conn),
+	}
+}
+
+// FindAll 查询所有模型价格，包括尚未生效的价格
+func (m *customLlmModelPriceModel) FindAll(ctx context.Context) ([]*LlmModelPrice, error) {
+	query := fmt.Sprintf("select %s from %s order by `effective_at`", llmModelPriceRows, m.table)
+	var resp []*LlmModelPrice
+	if err := m.conn.QueryRowsCtx(ctx, &resp, query); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
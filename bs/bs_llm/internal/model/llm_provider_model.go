@@ -0,0 +1,40 @@
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ LlmProviderModel = (*customLlmProviderModel)(nil)
+
+type (
+	// LlmProviderModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customLlmProviderModel.
+	LlmProviderModel interface {
+		llmProviderModel
+		FindAllEnabled(ctx context.Context) ([]*LlmProvider, error)
+	}
+
+	customLlmProviderModel struct {
+		*defaultLlmProviderModel
+	}
+)
+
+// NewLlmProviderModel returns a model for the database table.
+func NewLlmProviderModel(conn sqlx.SqlConn) LlmProviderModel {
+	return &customLlmProviderModel{
+		defaultLlmProviderModel: newLlmProviderModel(conn),
+	}
+}
+
+// FindAllEnabled 查询所有启用且未删除的供应商配置
+func (m *customLlmProviderModel) FindAllEnabled(ctx context.Context) ([]*LlmProvider, error) {
+	query := fmt.Sprintf("select %s from %s where `enabled` = 1 and `deleted` = 0", llmProviderRows, m.table)
+	var resp []*LlmProvider
+	if err := m.conn.QueryRowsCtx(ctx, &resp, query); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
+
+var _ LlmResponseCacheModel = (*customLlmResponseCacheModel)(nil)
+
+type (
+	// LlmResponseCacheModel is an interface to be customized, add more methods here,
+	// and implement the added methods in customLlmResponseCacheModel.
+	LlmResponseCacheModel interface {
+		llmResponseCacheModel
+		Upsert(ctx context.Context, data *LlmResponseCache) error
+		DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+	}
+
+	customLlmResponseCacheModel struct {
+		*defaultLlmResponseCacheModel
+	}
+)
+
+// NewLlmResponseCacheModel returns a model for the database table.
+func NewLlmResponseCacheModel(conn sqlx.SqlConn) LlmResponseCacheModel {
+	return &customLlmResponseCacheModel{
+		defaultLlmResponseCacheModel: newLlmResponseCacheModel(conn),
+	}
+}
+
+// Upsert 写入缓存，缓存键已存在时覆盖响应和过期时间
+func (m *customLlmResponseCacheModel) Upsert(ctx context.Context, data *LlmResponseCache) error {
+	query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?) on duplicate key update `scene_code` = values(`scene_code`), `response` = values(`response`), `expires_at` = values(`expires_at`)",
+		m.table, llmResponseCacheRowsExpectAutoSet)
+	_, err := m.conn.ExecCtx(ctx, query, data.CacheKey, data.SceneCode, data.Response, data.ExpiresAt)
+	return err
+}
+
+// DeleteExpired 删除过期时间早于 before 的缓存，返回删除的行数
+func (m *customLlmResponseCacheModel) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	query := fmt.Sprintf("delete from %s where `expires_at` < ?", m.table)
+	ret, err := m.conn.ExecCtx(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return ret.RowsAffected()
+}
@@ -1,6 +1,12 @@
 package model
 
-import "github.com/zeromicro/go-zero/core/stores/sqlx"
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/zeromicro/go-zero/core/stores/sqlx"
+)
 
 var _ LlmSceneModel = (*customLlmSceneModel)(nil)
 
@@ -9,11 +15,20 @@ type (
 	// and implement the added methods in customLlmSceneModel.
 	LlmSceneModel interface {
 		llmSceneModel
+		FindPage(ctx context.Context, filter *SceneFilter, offset, limit int64) ([]*LlmScene, error)
+		Count(ctx context.Context, filter *SceneFilter) (int64, error)
+		SoftDelete(ctx context.Context, sceneCode string) error
 	}
 
 	customLlmSceneModel struct {
 		*defaultLlmSceneModel
 	}
+
+	// SceneFilter 场景查询条件
+	SceneFilter struct {
+		ProviderCode   string // 为空时不过滤
+		IncludeDeleted bool
+	}
 )
 
 // NewLlmSceneModel returns a model for the database table.
@@ -22,3 +37,49 @@ func NewLlmSceneModel(conn sqlx.SqlConn) LlmSceneModel {
 		defaultLlmSceneModel: newLlmSceneModel(conn),
 	}
 }
+
+// FindPage 按条件分页查询场景，按ID升序
+func (m *customLlmSceneModel) FindPage(ctx context.Context, filter *SceneFilter, offset, limit int64) ([]*LlmScene, error) {
+	where, args := filter.where()
+	query := fmt.Sprintf("select %s from %s%s order by `id` limit ?, ?", llmSceneRows, m.table, where)
+	var resp []*LlmScene
+	if err := m.conn.QueryRowsCtx(ctx, &resp, query, append(args, offset, limit)...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Count 按条件统计场景数量
+func (m *customLlmSceneModel) Count(ctx context.Context, filter *SceneFilter) (int64, error) {
+	where, args := filter.where()
+	query := fmt.Sprintf("select count(*) from %s%s", m.table, where)
+	var count int64
+	if err := m.conn.QueryRowCtx(ctx, &count, query, args...); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// SoftDelete 软删除场景
+func (m *customLlmSceneModel) SoftDelete(ctx context.Context, sceneCode string) error {
+	query := fmt.Sprintf("update %s set `deleted` = 1 where `scene_code` = ?", m.table)
+	_, err := m.conn.ExecCtx(ctx, query, sceneCode)
+	return err
+}
+
+// where 构建查询条件
+func (f *SceneFilter) where() (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	if f != nil && f.ProviderCode != "" {
+		conditions = append(conditions, "`provider_code` = ?")
+		args = append(args, f.ProviderCode)
+	}
+	if f == nil || !f.IncludeDeleted {
+		conditions = append(conditions, "`deleted` = 0")
+	}
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " where " + strings.Join(conditions, " and "), args
+}
@@ -37,21 +37,28 @@ type (
 	}
 
 	LlmScene struct {
-		Id               int64          `db:"id"`
-		SceneCode        string         `db:"scene_code"`
-		SceneName        string         `db:"scene_name"`
-		ProviderCode     string         `db:"provider_code"`
-		ProviderName     string         `db:"provider_name"`
-		ModelCode        string         `db:"model_code"`
-		ModelName        string         `db:"model_name"`
-		ModelDescription sql.NullString `db:"model_description"`
-		SceneDescription sql.NullString `db:"scene_description"`
-		Temperature      float64        `db:"temperature"`
-		MaxTokens        int64          `db:"max_tokens"`
-		EnableStream     int64          `db:"enable_stream"`
-		Deleted          int64          `db:"deleted"`
-		CreatedAt        time.Time      `db:"created_at"`
-		UpdatedAt        time.Time      `db:"updated_at"`
+		Id                    int64          `db:"id"`
+		SceneCode             string         `db:"scene_code"`
+		SceneName             string         `db:"scene_name"`
+		ProviderCode          string         `db:"provider_code"`
+		ProviderName          string         `db:"provider_name"`
+		ModelCode             string         `db:"model_code"`
+		ModelName             string         `db:"model_name"`
+		ModelDescription      sql.NullString `db:"model_description"`
+		SceneDescription      sql.NullString `db:"scene_description"`
+		Temperature           float64        `db:"temperature"`
+		MaxTokens             int64          `db:"max_tokens"`
+		EnableStream          int64          `db:"enable_stream"`
+		FallbackProviders     sql.NullString `db:"fallback_providers"`
+		CacheTtl              int64          `db:"cache_ttl"`
+		CacheNondeterministic int64          `db:"cache_nondeterministic"`
+		ContentPolicy         string         `db:"content_policy"`
+		RedactPatterns        sql.NullString `db:"redact_patterns"`
+		ParamOverrides        sql.NullString `db:"param_overrides"`
+		RoutingRules          sql.NullString `db:"routing_rules"`
+		Deleted               int64          `db:"deleted"`
+		CreatedAt             time.Time      `db:"created_at"`
+		UpdatedAt             time.Time      `db:"updated_at"`
 	}
 )
 
@@ -97,14 +104,14 @@ func (m *defaultLlmSceneModel) FindOneBySceneCode(ctx context.Context, sceneCode
 }
 
 func (m *defaultLlmSceneModel) Insert(ctx context.Context, data *LlmScene) (sql.Result, error) {
-	query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", m.table, llmSceneRowsExpectAutoSet)
-	ret, err := m.conn.ExecCtx(ctx, query, data.SceneCode, data.SceneName, data.ProviderCode, data.ProviderName, data.ModelCode, data.ModelName, data.ModelDescription, data.SceneDescription, data.Temperature, data.MaxTokens, data.EnableStream, data.Deleted)
+	query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", m.table, llmSceneRowsExpectAutoSet)
+	ret, err := m.conn.ExecCtx(ctx, query, data.SceneCode, data.SceneName, data.ProviderCode, data.ProviderName, data.ModelCode, data.ModelName, data.ModelDescription, data.SceneDescription, data.Temperature, data.MaxTokens, data.EnableStream, data.FallbackProviders, data.CacheTtl, data.CacheNondeterministic, data.ContentPolicy, data.RedactPatterns, data.ParamOverrides, data.RoutingRules, data.Deleted)
 	return ret, err
 }
 
 func (m *defaultLlmSceneModel) Update(ctx context.Context, newData *LlmScene) error {
 	query := fmt.Sprintf("update %s set %s where `id` = ?", m.table, llmSceneRowsWithPlaceHolder)
-	_, err := m.conn.ExecCtx(ctx, query, newData.SceneCode, newData.SceneName, newData.ProviderCode, newData.ProviderName, newData.ModelCode, newData.ModelName, newData.ModelDescription, newData.SceneDescription, newData.Temperature, newData.MaxTokens, newData.EnableStream, newData.Deleted, newData.Id)
+	_, err := m.conn.ExecCtx(ctx, query, newData.SceneCode, newData.SceneName, newData.ProviderCode, newData.ProviderName, newData.ModelCode, newData.ModelName, newData.ModelDescription, newData.SceneDescription, newData.Temperature, newData.MaxTokens, newData.EnableStream, newData.FallbackProviders, newData.CacheTtl, newData.CacheNondeterministic, newData.ContentPolicy, newData.RedactPatterns, newData.ParamOverrides, newData.RoutingRules, newData.Deleted, newData.Id)
 	return err
 }
 
@@ -3,18 +3,40 @@ CREATE TABLE llm_completion (
     scene_code VARCHAR(50) NOT NULL COMMENT '关联的场景编码，对应llm_scene表的scene_code',
     prompt TEXT NOT NULL COMMENT '用户输入的提示词',
     completion TEXT COMMENT 'LLM返回的回答内容',
+    reasoning MEDIUMTEXT COMMENT '推理模型的思考内容，与回答内容分开保存',
     input_tokens INT UNSIGNED NOT NULL COMMENT '输入的token数量',
     output_tokens INT UNSIGNED NOT NULL COMMENT '输出的token数量',
     total_tokens INT UNSIGNED NOT NULL COMMENT '总token数量（input_tokens + output_tokens）',
+    reasoning_tokens INT UNSIGNED NOT NULL DEFAULT 0 COMMENT '推理token数量，已包含在output_tokens中',
     model_code VARCHAR(50) NOT NULL COMMENT '实际调用的模型编码',
     provider_code VARCHAR(50) NOT NULL COMMENT '实际调用的提供商编码',
     request_id VARCHAR(100) NOT NULL COMMENT '请求唯一标识（如API返回的request-id）',
-    status TINYINT NOT NULL COMMENT '请求状态（1-成功，0-失败，2-超时）',
+    status TINYINT NOT NULL COMMENT '请求状态（1-成功，0-失败，2-超时，3-客户端取消）',
     error_msg TEXT COMMENT '错误信息（状态为失败时记录）',
     response_time DECIMAL(10,3) COMMENT '响应时间（秒）',
     user_id VARCHAR(50) NOT NULL COMMENT '调用用户ID（如有）',
+    attempt INT UNSIGNED NOT NULL DEFAULT 1 COMMENT '成功（或最终失败）的尝试序号，含重试和降级，从1开始',
+    cache_hit TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否命中响应缓存（1-命中，未调用供应商，0-未命中）',
+    batch_id VARCHAR(100) NOT NULL DEFAULT '' COMMENT '批量调用ID，同一次BatchLLM调用的记录相同，非批量调用为空',
+    cost DECIMAL(16,6) NOT NULL DEFAULT 0 COMMENT '调用费用，按调用时生效的llm_model_price计算，命中缓存或未配置价格时为0',
+    currency VARCHAR(10) NOT NULL DEFAULT '' COMMENT '费用币种，取自计费的llm_model_price.currency，未产生费用时为空',
+    route VARCHAR(50) NOT NULL DEFAULT '' COMMENT '场景路由规则选择的路由名称，未命中规则为default，场景未配置路由规则时为空',
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间（问答发生时间）',
     INDEX idx_scene_code (scene_code),
     INDEX idx_created_at (created_at),
-    INDEX idx_request_id (request_id)
-) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='LLM问答记录明细表';
\ No newline at end of file
+    INDEX idx_request_id (request_id),
+    INDEX idx_user_scene_created (user_id, scene_code, created_at),
+    INDEX idx_batch_id (batch_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='LLM问答记录明细表';
+
+-- 已有表升级
+-- ALTER TABLE llm_completion ADD COLUMN attempt INT UNSIGNED NOT NULL DEFAULT 1 COMMENT '成功（或最终失败）的尝试序号，含重试和降级，从1开始' AFTER user_id;
+-- ALTER TABLE llm_completion ADD INDEX idx_user_scene_created (user_id, scene_code, created_at);
+-- ALTER TABLE llm_completion ADD COLUMN cache_hit TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否命中响应缓存（1-命中，未调用供应商，0-未命中）' AFTER attempt;
+-- ALTER TABLE llm_completion MODIFY COLUMN status TINYINT NOT NULL COMMENT '请求状态（1-成功，0-失败，2-超时，3-客户端取消）';
+-- ALTER TABLE llm_completion ADD COLUMN batch_id VARCHAR(100) NOT NULL DEFAULT '' COMMENT '批量调用ID，同一次BatchLLM调用的记录相同，非批量调用为空' AFTER cache_hit, ADD INDEX idx_batch_id (batch_id);
+-- ALTER TABLE llm_completion ADD COLUMN cost DECIMAL(16,6) NOT NULL DEFAULT 0 COMMENT '调用费用，按调用时生效的llm_model_price计算，命中缓存或未配置价格时为0' AFTER batch_id;
+-- ALTER TABLE llm_completion ADD COLUMN route VARCHAR(50) NOT NULL DEFAULT '' COMMENT '场景路由规则选择的路由名称，未命中规则为default，场景未配置路由规则时为空' AFTER cost;
+-- ALTER TABLE llm_completion ADD COLUMN reasoning MEDIUMTEXT COMMENT '推理模型的思考内容，与回答内容分开保存' AFTER completion, ADD COLUMN reasoning_tokens INT UNSIGNED NOT NULL DEFAULT 0 COMMENT '推理token数量，已包含在output_tokens中' AFTER total_tokens;
+-- ALTER TABLE llm_completion ADD COLUMN currency VARCHAR(10) NOT NULL DEFAULT '' COMMENT '费用币种，取自计费的llm_model_price.currency，未产生费用时为空' AFTER cost;
+-- UPDATE llm_completion SET currency = 'CNY' WHERE cost > 0 AND currency = '';  -- 升级前的价格均为人民币时执行
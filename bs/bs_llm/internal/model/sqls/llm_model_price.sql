@@ -0,0 +1,16 @@
+-- 模型价格表，按生效时间记录每个模型的输入、输出单价，调用记录按调用时已生效的最新价格计算费用。
+CREATE TABLE llm_model_price (
+    id INT AUTO_INCREMENT PRIMARY KEY COMMENT '主键ID',
+    provider_code VARCHAR(50) NOT NULL DEFAULT '' COMMENT '供应商编码，为空表示适用于所有供应商的同名模型',
+    model_code VARCHAR(50) NOT NULL DEFAULT '' COMMENT '模型编码（对应llm_completion表的model_code）',
+    input_price DECIMAL(12,6) NOT NULL DEFAULT 0 COMMENT '输入价格（每1K tokens）',
+    output_price DECIMAL(12,6) NOT NULL DEFAULT 0 COMMENT '输出价格（每1K tokens）',
+    currency VARCHAR(10) NOT NULL DEFAULT 'CNY' COMMENT '币种',
+    effective_at DATETIME NOT NULL COMMENT '生效时间',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    UNIQUE KEY u_provider_model_effective (provider_code, model_code, effective_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='LLM模型价格表';
+
+-- 示例
+-- INSERT INTO llm_model_price (provider_code, model_code, input_price, output_price, effective_at) VALUES ('bailian', 'qwen-plus', 0.0008, 0.002, '2024-01-01 00:00:00');
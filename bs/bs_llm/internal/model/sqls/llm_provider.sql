@@ -0,0 +1,18 @@
+-- LLM供应商配置表，bs_llm 启动时加载并定期热加载，运维可以通过修改该表新增供应商或轮换密钥而无需重启服务。
+CREATE TABLE llm_provider (
+    id INT AUTO_INCREMENT PRIMARY KEY COMMENT '主键ID',
+    provider_code VARCHAR(50) NOT NULL DEFAULT '' COMMENT '供应商编码（对应llm_scene表的provider_code）',
+    provider_name VARCHAR(100) NOT NULL DEFAULT '' COMMENT '供应商名称',
+    provider_type VARCHAR(50) NOT NULL DEFAULT '' COMMENT '供应商协议类型（doubao、bailian、openai）',
+    api_endpoint VARCHAR(500) NOT NULL DEFAULT '' COMMENT 'API地址（openai类型填写基础地址，如http://127.0.0.1:8000/v1）',
+    api_key VARCHAR(500) NOT NULL DEFAULT '' COMMENT 'API密钥',
+    headers TEXT COMMENT '自定义请求头（JSON对象）',
+    default_params TEXT COMMENT '默认请求参数（JSON对象）',
+    timeout INT NOT NULL DEFAULT 120 COMMENT '请求超时时间（秒）',
+    retry_count INT NOT NULL DEFAULT 3 COMMENT '重试次数',
+    enabled TINYINT(1) NOT NULL DEFAULT 1 COMMENT '是否启用（1-启用，0-禁用）',
+    deleted TINYINT NOT NULL DEFAULT 0 COMMENT '是否删除（1-删除，0-未删除）',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    UNIQUE KEY u_provider_code (provider_code)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='LLM供应商配置表';
@@ -0,0 +1,12 @@
+-- LLM响应缓存表，ResponseCache.Backend 为 mysql 时使用，缓存开启了 cache_ttl 的场景的非流式响应，多实例共享。
+CREATE TABLE llm_response_cache (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY COMMENT '主键ID',
+    cache_key CHAR(64) NOT NULL DEFAULT '' COMMENT '缓存键（场景、模型、温度及消息的SHA-256）',
+    scene_code VARCHAR(50) NOT NULL DEFAULT '' COMMENT '场景编码',
+    response MEDIUMTEXT NOT NULL COMMENT '缓存的响应（JSON）',
+    expires_at DATETIME NOT NULL COMMENT '过期时间',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+    UNIQUE KEY u_cache_key (cache_key),
+    INDEX idx_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='LLM响应缓存表';
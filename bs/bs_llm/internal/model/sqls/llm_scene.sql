@@ -12,9 +12,25 @@ CREATE TABLE llm_scene (
     temperature DECIMAL(3,2) DEFAULT 0.70 COMMENT '温度参数（0.00-1.00），控制生成内容的随机性',
     max_tokens INT DEFAULT 1000 COMMENT '最大token数，限制单次生成的最大长度',
     enable_stream TINYINT(1) DEFAULT 1 COMMENT '是否启用流式输出（1-启用，0-禁用）',
+    fallback_providers TEXT COMMENT '降级链（JSON数组，按顺序尝试），如[{"provider_code":"bailian","model_code":"qwen-plus"}]',
+    cache_ttl INT NOT NULL DEFAULT 0 COMMENT '非流式响应缓存有效期（秒），0表示不缓存',
+    cache_nondeterministic TINYINT(1) NOT NULL DEFAULT 0 COMMENT '温度非0时是否仍缓存（1-缓存，0-仅温度为0时缓存）',
+    content_policy VARCHAR(20) NOT NULL DEFAULT 'redact' COMMENT '问答内容落库策略（redact-脱敏后保存，raw-原文保存，discard-不保存内容）',
+    redact_patterns TEXT COMMENT '场景自定义脱敏规则（JSON数组），如[{"name":"order","pattern":"ORD[0-9]{6}","replacement":"[ORDER]"}]',
+    param_overrides TEXT COMMENT '调用方可通过extra_params覆盖的参数（JSON数组），如["top_p","stop","seed","max_tokens"]',
+    routing_rules TEXT COMMENT '路由规则（JSON数组，按顺序匹配，未命中时使用provider_code/model_code），如[{"name":"long","min_prompt_tokens":8000,"provider_code":"bailian","model_code":"qwen-long"}]',
     deleted TINYINT NOT NULL DEFAULT 0 COMMENT '是否删除（1-删除，0-未删除）',
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
     UNIQUE KEY u_scene_code (scene_code),
     INDEX idx_provider_model (provider_code, model_code)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='LLM场景映射表（关联场景与对应的LLM提供商及模型）';
+
+-- 已有表升级
+-- ALTER TABLE llm_scene ADD COLUMN fallback_providers TEXT COMMENT '降级链（JSON数组，按顺序尝试），如[{"provider_code":"bailian","model_code":"qwen-plus"}]' AFTER enable_stream;
+-- ALTER TABLE llm_scene ADD COLUMN cache_ttl INT NOT NULL DEFAULT 0 COMMENT '非流式响应缓存有效期（秒），0表示不缓存' AFTER fallback_providers;
+-- ALTER TABLE llm_scene ADD COLUMN cache_nondeterministic TINYINT(1) NOT NULL DEFAULT 0 COMMENT '温度非0时是否仍缓存（1-缓存，0-仅温度为0时缓存）' AFTER cache_ttl;
+-- ALTER TABLE llm_scene ADD COLUMN content_policy VARCHAR(20) NOT NULL DEFAULT 'redact' COMMENT '问答内容落库策略（redact-脱敏后保存，raw-原文保存，discard-不保存内容）' AFTER cache_nondeterministic;
+-- ALTER TABLE llm_scene ADD COLUMN redact_patterns TEXT COMMENT '场景自定义脱敏规则（JSON数组）' AFTER content_policy;
+-- ALTER TABLE llm_scene ADD COLUMN param_overrides TEXT COMMENT '调用方可通过extra_params覆盖的参数（JSON数组）' AFTER redact_patterns;
+-- ALTER TABLE llm_scene ADD COLUMN routing_rules TEXT COMMENT '路由规则（JSON数组，按顺序匹配，未命中时使用provider_code/model_code）' AFTER param_overrides;
@@ -0,0 +1,175 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"jxzy/bs/bs_llm/internal/model"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// Price 模型价格，单价为每1K tokens的价格
+type Price struct {
+	ProviderCode string // 为空表示适用于所有供应商的同名模型
+	ModelCode    string
+	InputPrice   float64
+	OutputPrice  float64
+	Currency     string
+	EffectiveAt  time.Time
+}
+
+// Loader 模型价格加载函数
+type Loader func(ctx context.Context) ([]*Price, error)
+
+// Table 模型价格表
+// 按 供应商+模型 匹配调用时已生效的最新价格，未找到时匹配供应商为空的同名模型价格。
+// 通过 Start 定期重新加载，价格调整无需重启服务。
+type Table struct {
+	load   Loader
+	logger logx.Logger
+
+	mu     sync.RWMutex
+	prices map[string][]*Price // 供应商编码+模型编码 -> 按生效时间升序排列的价格
+	stopCh chan struct{}
+}
+
+// NewTable 创建模型价格表，load 为空时所有调用的费用为0
+func NewTable(load Loader) *Table {
+	return &Table{
+		load:   load,
+		logger: logx.WithContext(context.Background()),
+		prices: make(map[string][]*Price),
+	}
+}
+
+// Reload 重新加载模型价格，加载失败时沿用上一次的结果
+func (t *Table) Reload(ctx context.Context) error {
+	if t.load == nil {
+		return nil
+	}
+	prices, err := t.load(ctx)
+	if err != nil {
+		t.logger.Errorf("Failed to load model prices: %v", err)
+		return err
+	}
+	t.Set(prices)
+	return nil
+}
+
+// Set 使用新的价格列表整体替换当前价格
+func (t *Table) Set(prices []*Price) {
+	byKey := make(map[string][]*Price)
+	for _, price := range prices {
+		k := key(price.ProviderCode, price.ModelCode)
+		byKey[k] = append(byKey[k], price)
+	}
+	for _, list := range byKey {
+		sort.Slice(list, func(i, j int) bool { return list[i].EffectiveAt.Before(list[j].EffectiveAt) })
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.prices = byKey
+}
+
+// Lookup 查询模型在 at 时刻生效的价格，未配置时返回nil
+func (t *Table) Lookup(providerCode, modelCode string, at time.Time) *Price {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if price := effective(t.prices[key(providerCode, modelCode)], at); price != nil {
+		return price
+	}
+	return effective(t.prices[key("", modelCode)], at)
+}
+
+// Cost 计算一次调用的费用及币种，未配置价格时返回0和空币种
+func (t *Table) Cost(providerCode, modelCode string, at time.Time, inputTokens, outputTokens int64) (float64, string) {
+	price := t.Lookup(providerCode, modelCode, at)
+	if price == nil {
+		return 0, ""
+	}
+	cost := float64(inputTokens)/1000*price.InputPrice + float64(outputTokens)/1000*price.OutputPrice
+	// 与 llm_completion.cost 的精度一致
+	return math.Round(cost*1e6) / 1e6, price.Currency
+}
+
+// Start 启动定期热加载
+func (t *Table) Start(interval time.Duration) {
+	if interval <= 0 || t.load == nil {
+		return
+	}
+
+	t.mu.Lock()
+	if t.stopCh != nil {
+		t.mu.Unlock()
+		return
+	}
+	stopCh := make(chan struct{})
+	t.stopCh = stopCh
+	t.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				_ = t.Reload(ctx)
+				cancel()
+			case <-stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止定期热加载
+func (t *Table) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stopCh != nil {
+		close(t.stopCh)
+		t.stopCh = nil
+	}
+}
+
+// ModelLoader 返回从 llm_model_price 表加载价格的 Loader
+func ModelLoader(priceModel model.LlmModelPriceModel) Loader {
+	return func(ctx context.Context) ([]*Price, error) {
+		rows, err := priceModel.FindAll(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query llm_model_price: %w", err)
+		}
+		prices := make([]*Price, 0, len(rows))
+		for _, row := range rows {
+			prices = append(prices, &Price{
+				ProviderCode: row.ProviderCode,
+				ModelCode:    row.ModelCode,
+				InputPrice:   row.InputPrice,
+				OutputPrice:  row.OutputPrice,
+				Currency:     row.Currency,
+				EffectiveAt:  row.EffectiveAt,
+			})
+		}
+		return prices, nil
+	}
+}
+
+// effective 返回 at 时刻已生效的最新价格
+func effective(prices []*Price, at time.Time) *Price {
+	i := sort.Search(len(prices), func(i int) bool { return prices[i].EffectiveAt.After(at) })
+	if i == 0 {
+		return nil
+	}
+	return prices[i-1]
+}
+
+func key(providerCode, modelCode string) string {
+	return providerCode + "\x00" + modelCode
+}
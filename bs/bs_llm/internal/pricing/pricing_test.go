@@ -0,0 +1,73 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTableCost(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	mar := time.Date(2024, 3, 1, 0, 0, 0, 0, time.Local)
+	table := NewTable(nil)
+	table.Set([]*Price{
+		{ProviderCode: "bailian", ModelCode: "qwen-plus", InputPrice: 0.002, OutputPrice: 0.006, Currency: "CNY", EffectiveAt: mar},
+		{ProviderCode: "bailian", ModelCode: "qwen-plus", InputPrice: 0.004, OutputPrice: 0.012, EffectiveAt: jan},
+		{ModelCode: "deepseek-chat", InputPrice: 0.001, OutputPrice: 0.002, Currency: "USD", EffectiveAt: jan},
+	})
+
+	cases := []struct {
+		name     string
+		provider string
+		model    string
+		at       time.Time
+		want     float64
+	}{
+		{"before any price", "bailian", "qwen-plus", jan.Add(-time.Hour), 0},
+		{"first price", "bailian", "qwen-plus", jan.AddDate(0, 1, 0), 1500*0.004/1000 + 500*0.012/1000},
+		{"price change", "bailian", "qwen-plus", mar, 1500*0.002/1000 + 500*0.006/1000},
+		{"any provider", "deepseek", "deepseek-chat", mar, 1500*0.001/1000 + 500*0.002/1000},
+		{"unpriced model", "doubao", "doubao-pro", mar, 0},
+	}
+	for _, tc := range cases {
+		if got, _ := table.Cost(tc.provider, tc.model, tc.at, 1500, 500); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("%s: expected cost %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestTableCostCurrency(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	table := NewTable(nil)
+	table.Set([]*Price{{ModelCode: "deepseek-chat", InputPrice: 0.001, Currency: "USD", EffectiveAt: jan}})
+
+	if _, currency := table.Cost("deepseek", "deepseek-chat", jan, 1000, 0); currency != "USD" {
+		t.Errorf("Expected USD, got %q", currency)
+	}
+	if cost, currency := table.Cost("doubao", "doubao-pro", jan, 1000, 0); cost != 0 || currency != "" {
+		t.Errorf("Expected no cost for unpriced model, got %v %q", cost, currency)
+	}
+}
+
+func TestTableReloadKeepsPricesOnError(t *testing.T) {
+	var loadErr error
+	table := NewTable(func(ctx context.Context) ([]*Price, error) {
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		return []*Price{{ModelCode: "m", InputPrice: 1, OutputPrice: 1}}, nil
+	})
+	if err := table.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	loadErr = errors.New("database unavailable")
+	if err := table.Reload(context.Background()); err == nil {
+		t.Error("Expected reload error")
+	}
+	if table.Lookup("any", "m", time.Now()) == nil {
+		t.Error("Expected prices from the last successful load to be kept")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,22 +19,34 @@ import (
 )
 
 const (
-	DefaultAPIEndpoint = "https://dashscope.aliyuncs.com/api/v1/services/aigc/text-generation/generation"
-	ProviderName       = "bailian"
+	DefaultAPIEndpoint    = "https://dashscope.aliyuncs.com/api/v1/services/aigc/text-generation/generation"
+	MultimodalAPIEndpoint = "https://dashscope.aliyuncs.com/api/v1/services/aigc/multimodal-generation/generation"
+	ProviderName          = "bailian"
 )
 
+// resultFormatMessage 以 OpenAI 兼容的 choices 格式返回，推理过程和工具调用只在该格式中返回
+const resultFormatMessage = "message"
+
+// paramEnableThinking 开启或关闭混合推理模型思考模式的额外参数
+const paramEnableThinking = "enable_thinking"
+
 // BailianProvider 百炼供应商实现
 type BailianProvider struct {
 	client *http.Client
+	config *provider.ProviderConfig
 	logger logx.Logger
 }
 
-// NewBailianProvider 创建百炼供应商
-func NewBailianProvider() *BailianProvider {
+// NewBailianProvider 创建百炼供应商，config 为该供应商的默认配置，用于健康检查
+func NewBailianProvider(config *provider.ProviderConfig) *BailianProvider {
+	if config == nil {
+		config = &provider.ProviderConfig{}
+	}
 	return &BailianProvider{
 		client: &http.Client{
 			Timeout: 120 * time.Second,
 		},
+		config: config,
 		logger: logx.WithContext(context.Background()),
 	}
 }
@@ -57,33 +70,36 @@ func (p *BailianProvider) createHTTPClient(config *provider.ProviderConfig) *htt
 
 // CallLLM 非流式调用
 func (p *BailianProvider) CallLLM(ctx context.Context, req *provider.LLMRequest) (*provider.LLMResponse, error) {
-	// 构建百炼API请求
+	// 构建百炼API请求，含图片、音频时使用多模态接口
+	multimodal := hasMedia(req.Messages)
 	apiReq := &BailianRequest{
 		Model: req.ModelCode,
 		Input: &BailianInput{
-			Messages: convertMessages(req.Messages),
+			Messages: convertMessages(req.Messages, multimodal),
 		},
 		Parameters: &BailianParameters{
-			Temperature: req.Temperature,
-			MaxTokens:   req.MaxTokens,
-			TopP:        0.8,
-			TopK:        50,
+			Temperature:  req.Temperature,
+			MaxTokens:    req.MaxTokens,
+			TopP:         0.8,
+			TopK:         50,
+			ResultFormat: resultFormatMessage,
 		},
 	}
+	applySampling(apiReq.Parameters, req)
+	applyThinking(apiReq.Parameters, req)
+	applyTools(apiReq.Parameters, req)
+	applyResponseFormat(apiReq.Parameters, req)
 
 	reqBody, err := json.Marshal(apiReq)
 	if err != nil {
 		return nil, fmt.Errorf("marshal request failed: %w", err)
 	}
 
-	endpoint := req.Config.APIEndpoint
-	if endpoint == "" {
-		endpoint = DefaultAPIEndpoint
-	}
+	endpoint := resolveEndpoint(req.Config.APIEndpoint, multimodal)
 
-	// 添加调试日志
-	p.logger.Infof("Bailian API Request - Endpoint: %s, Model: %s", endpoint, req.ModelCode)
-	p.logger.Infof("Bailian API Request Body: %s", string(reqBody))
+	// 请求体包含用户输入，只记录元信息
+	p.logger.Infof("Bailian API Request - Endpoint: %s, Model: %s, Messages: %d, Multimodal: %v",
+		endpoint, req.ModelCode, len(req.Messages), multimodal)
 
 	// 创建HTTP客户端
 	client := p.createHTTPClient(req.Config)
@@ -112,11 +128,10 @@ func (p *BailianProvider) CallLLM(ctx context.Context, req *provider.LLMRequest)
 		return nil, fmt.Errorf("read response body failed: %w", err)
 	}
 
-	p.logger.Infof("Bailian API Response Status: %d", resp.StatusCode)
-	p.logger.Infof("Bailian API Response Body: %s", string(respBody))
+	p.logger.Infof("Bailian API Response Status: %d, Length: %d", resp.StatusCode, len(respBody))
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
+		return nil, &provider.StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	var apiResp BailianResponse
@@ -124,54 +139,72 @@ func (p *BailianProvider) CallLLM(ctx context.Context, req *provider.LLMRequest)
 		return nil, fmt.Errorf("decode response failed: %w", err)
 	}
 
+	// 请求均使用 result_format=message，内容和推理过程在 choices 中，兼容返回 text 的旧格式
+	text, finishReason := apiResp.Output.Text, apiResp.Output.FinishReason
+	var reasoning string
+	var toolCalls []*provider.ToolCall
+	if len(apiResp.Output.Choices) > 0 {
+		choice := apiResp.Output.Choices[0]
+		text, finishReason = choice.Message.Content, choice.FinishReason
+		reasoning = choice.Message.ReasoningContent
+		toolCalls = toProviderToolCalls(choice.Message.ToolCalls)
+	}
+
 	// 检查输出内容是否为空
-	if apiResp.Output.Text == "" {
+	if text == "" && len(toolCalls) == 0 {
 		return nil, fmt.Errorf("no text content in response")
 	}
 
-	p.logger.Infof("Bailian API Output: finish_reason=%s, text_length=%d",
-		apiResp.Output.FinishReason, len(apiResp.Output.Text))
+	p.logger.Infof("Bailian API Output: finish_reason=%s, text_length=%d, tool_calls=%d",
+		finishReason, len(text), len(toolCalls))
 
 	return &provider.LLMResponse{
-		Content:          apiResp.Output.Text,
+		Content:          text,
 		ModelCode:        req.ModelCode,
 		PromptTokens:     apiResp.Usage.InputTokens,
 		CompletionTokens: apiResp.Usage.OutputTokens,
 		TotalTokens:      apiResp.Usage.TotalTokens,
-		FinishReason:     apiResp.Output.FinishReason,
+		FinishReason:     finishReason,
+		ToolCalls:        toolCalls,
+		ReasoningContent: reasoning,
+		ReasoningTokens:  apiResp.Usage.reasoningTokens(),
 	}, nil
 }
 
 // StreamLLM 流式调用
 func (p *BailianProvider) StreamLLM(ctx context.Context, req *provider.LLMRequest) (provider.StreamReader, error) {
-	// 构建百炼API请求
+	// 构建百炼API请求，含图片、音频时使用多模态接口
+	multimodal := hasMedia(req.Messages)
 	apiReq := &BailianRequest{
 		Model: req.ModelCode,
 		Input: &BailianInput{
-			Messages: convertMessages(req.Messages),
+			Messages: convertMessages(req.Messages, multimodal),
 		},
 		Parameters: &BailianParameters{
-			Temperature: req.Temperature,
-			MaxTokens:   req.MaxTokens,
-			TopP:        0.8,
-			TopK:        50,
-			Stream:      true,
+			Temperature:       req.Temperature,
+			MaxTokens:         req.MaxTokens,
+			TopP:              0.8,
+			TopK:              50,
+			Stream:            true,
+			IncrementalOutput: true,
+			ResultFormat:      resultFormatMessage,
 		},
 	}
+	applySampling(apiReq.Parameters, req)
+	applyThinking(apiReq.Parameters, req)
+	applyTools(apiReq.Parameters, req)
+	applyResponseFormat(apiReq.Parameters, req)
 
 	reqBody, err := json.Marshal(apiReq)
 	if err != nil {
 		return nil, fmt.Errorf("marshal request failed: %w", err)
 	}
 
-	endpoint := req.Config.APIEndpoint
-	if endpoint == "" {
-		endpoint = DefaultAPIEndpoint
-	}
+	endpoint := resolveEndpoint(req.Config.APIEndpoint, multimodal)
 
-	// 添加调试日志
-	p.logger.Infof("Bailian Stream API Request - Endpoint: %s, Model: %s", endpoint, req.ModelCode)
-	p.logger.Infof("Bailian Stream API Request Body: %s", string(reqBody))
+	// 请求体包含用户输入，只记录元信息
+	p.logger.Infof("Bailian Stream API Request - Endpoint: %s, Model: %s, Messages: %d, Multimodal: %v",
+		endpoint, req.ModelCode, len(req.Messages), multimodal)
 
 	// 创建HTTP客户端
 	client := p.createHTTPClient(req.Config)
@@ -197,38 +230,189 @@ func (p *BailianProvider) StreamLLM(ctx context.Context, req *provider.LLMReques
 	if resp.StatusCode != http.StatusOK {
 		defer resp.Body.Close()
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, &provider.StatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
-	return NewBailianStreamReader(resp.Body, req.ModelCode, p.logger), nil
+	return NewBailianStreamReader(resp.Body, req.ModelCode, p.logger).WithIncrementalOutput(), nil
 }
 
-// HealthCheck 健康检查
+// HealthCheck 健康检查，向文本生成接口发送空请求验证连通性和鉴权
 func (p *BailianProvider) HealthCheck(ctx context.Context) error {
-	// 简单的健康检查，可以调用一个简单的API来验证连接性
-	return nil
+	return provider.Probe(ctx, p.createHTTPClient(p.config), resolveEndpoint(p.config.APIEndpoint, false), p.config)
 }
 
 // convertMessages 转换消息格式
-func convertMessages(messages []*provider.ChatMessage) []BailianMessage {
+func convertMessages(messages []*provider.ChatMessage, multimodal bool) []BailianMessage {
 	var result []BailianMessage
 	for _, msg := range messages {
-		result = append(result, BailianMessage{
-			Role:    msg.Role,
-			Content: msg.Content,
+		message := BailianMessage{
+			Role:       msg.Role,
+			Content:    msg.Content,
+			ToolCallID: msg.ToolCallID,
+			Name:       msg.Name,
+		}
+		if multimodal {
+			message.Parts = convertContentParts(msg)
+		}
+		for _, call := range msg.ToolCalls {
+			message.ToolCalls = append(message.ToolCalls, BailianToolCall{
+				ID:   call.ID,
+				Type: toolType(call.Type),
+				Function: BailianFunctionCall{
+					Name:      call.Function.Name,
+					Arguments: call.Function.Arguments,
+				},
+			})
+		}
+		result = append(result, message)
+	}
+	return result
+}
+
+// convertContentParts 转换为多模态接口的内容片段，图片、音频为 URL 或 base64 data URI
+func convertContentParts(msg *provider.ChatMessage) []BailianContentPart {
+	result := []BailianContentPart{}
+	if len(msg.Parts) == 0 {
+		if msg.Content != "" {
+			result = append(result, BailianContentPart{Text: msg.Content})
+		}
+		return result
+	}
+	for _, part := range msg.Parts {
+		switch part.Type {
+		case provider.ContentPartText:
+			result = append(result, BailianContentPart{Text: part.Text})
+		case provider.ContentPartImage:
+			result = append(result, BailianContentPart{Image: part.URI()})
+		case provider.ContentPartAudio:
+			result = append(result, BailianContentPart{Audio: part.URI()})
+		}
+	}
+	return result
+}
+
+// hasMedia 消息中是否包含图片、音频
+func hasMedia(messages []*provider.ChatMessage) bool {
+	for _, msg := range messages {
+		if msg.HasMedia() {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveEndpoint 请求地址，多模态请求将文本生成接口替换为多模态接口
+func resolveEndpoint(endpoint string, multimodal bool) string {
+	if endpoint == "" {
+		endpoint = DefaultAPIEndpoint
+	}
+	if multimodal {
+		endpoint = strings.Replace(endpoint, "/text-generation/", "/multimodal-generation/", 1)
+	}
+	return endpoint
+}
+
+// applyTools 设置工具调用参数
+func applyTools(params *BailianParameters, req *provider.LLMRequest) {
+	for _, tool := range req.Tools {
+		if tool.Function == nil {
+			continue
+		}
+		params.Tools = append(params.Tools, BailianTool{
+			Type: toolType(tool.Type),
+			Function: BailianFunctionDefinition{
+				Name:        tool.Function.Name,
+				Description: tool.Function.Description,
+				Parameters:  tool.Function.Parameters,
+			},
+		})
+	}
+	if len(params.Tools) == 0 {
+		return
+	}
+
+	switch req.ToolChoice {
+	case "":
+	case "auto", "none", "required":
+		params.ToolChoice = req.ToolChoice
+	default:
+		params.ToolChoice = map[string]interface{}{
+			"type":     "function",
+			"function": map[string]string{"name": req.ToolChoice},
+		}
+	}
+}
+
+// applySampling 设置调用方指定的采样参数，百炼不支持 frequency_penalty
+func applySampling(params *BailianParameters, req *provider.LLMRequest) {
+	if req.TopP != nil {
+		params.TopP = *req.TopP
+	}
+	params.Seed = req.Seed
+	params.Stop = req.Stop
+	params.PresencePenalty = req.PresencePenalty
+}
+
+// applyThinking 设置 Qwen3 等混合推理模型的思考模式，由供应商默认参数或调用方 extra_params 中的 enable_thinking 指定
+func applyThinking(params *BailianParameters, req *provider.LLMRequest) {
+	value, ok := req.ExtraParams[paramEnableThinking]
+	if !ok {
+		return
+	}
+	if enable, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+		params.EnableThinking = &enable
+	}
+}
+
+// applyResponseFormat 设置输出格式，百炼仅支持 JSON 对象模式，Schema 由调用方通过提示词约束
+func applyResponseFormat(params *BailianParameters, req *provider.LLMRequest) {
+	format := req.ResponseFormat
+	if format == nil || format.Type == "" || format.Type == provider.ResponseFormatText {
+		return
+	}
+	params.ResponseFormat = &BailianResponseFormat{Type: provider.ResponseFormatJSONObject}
+}
+
+// toProviderToolCalls 转换模型返回的工具调用
+func toProviderToolCalls(calls []BailianToolCall) []*provider.ToolCall {
+	var result []*provider.ToolCall
+	for i, call := range calls {
+		index := i
+		if call.Index != nil {
+			index = *call.Index
+		}
+		result = append(result, &provider.ToolCall{
+			Index: index,
+			ID:    call.ID,
+			Type:  call.Type,
+			Function: provider.FunctionCall{
+				Name:      call.Function.Name,
+				Arguments: call.Function.Arguments,
+			},
 		})
 	}
 	return result
 }
 
+// toolType 工具类型默认为 function
+func toolType(t string) string {
+	if t == "" {
+		return "function"
+	}
+	return t
+}
+
 // BailianStreamReader 百炼流式读取器
 type BailianStreamReader struct {
-	reader       io.ReadCloser
-	scanner      *bufio.Scanner
-	modelCode    string
-	finished     bool
-	logger       logx.Logger
-	previousText string // 用于计算增量
+	reader            io.ReadCloser
+	scanner           *bufio.Scanner
+	modelCode         string
+	finished          bool
+	logger            logx.Logger
+	previousText      string                     // 用于计算增量
+	previousReasoning string                     // 用于计算推理过程增量
+	previousCall      map[int]*provider.ToolCall // 用于计算工具调用增量
+	incremental       bool                       // incremental_output=true 时每个数据块只包含增量
 }
 
 // NewBailianStreamReader 创建百炼流式读取器
@@ -244,9 +428,16 @@ func NewBailianStreamReader(reader io.ReadCloser, modelCode string, logger logx.
 		finished:     false,
 		logger:       logger,
 		previousText: "",
+		previousCall: make(map[int]*provider.ToolCall),
 	}
 }
 
+// WithIncrementalOutput 按 incremental_output=true 的增量数据块解析
+func (r *BailianStreamReader) WithIncrementalOutput() *BailianStreamReader {
+	r.incremental = true
+	return r
+}
+
 // Read 读取下一个响应
 func (r *BailianStreamReader) Read() (provider.StreamResponse, error) {
 	if r.finished {
@@ -275,25 +466,32 @@ func (r *BailianStreamReader) Read() (provider.StreamResponse, error) {
 			// 解析JSON数据
 			var chunk BailianStreamChunk
 			if err := json.Unmarshal([]byte(data), &chunk); err != nil {
-				r.logger.Errorf("Bailian Stream: Failed to parse JSON: %v, data: %s", err, data)
+				r.logger.Errorf("Bailian Stream: Failed to parse JSON: %v, length: %d", err, len(data))
 				continue // 跳过无法解析的行
 			}
 
 			// 处理流式响应
 			output := chunk.Output
+			text, finishReason := output.Text, output.FinishReason
+			var reasoning string
+			var toolCalls []*provider.ToolCall
+			if len(output.Choices) > 0 {
+				choice := output.Choices[0]
+				text, finishReason = choice.Message.Content, choice.FinishReason
+				reasoning = choice.Message.ReasoningContent
+				toolCalls = toProviderToolCalls(choice.Message.ToolCalls)
+			}
 
-			// 计算增量内容（百炼返回的是完整文本，需要计算增量）
-			delta := ""
-			if output.Text != "" {
-				// 计算增量：当前文本减去之前的文本
-				if len(output.Text) > len(r.previousText) {
-					delta = output.Text[len(r.previousText):]
-				}
-				r.previousText = output.Text
+			// 非增量输出时百炼返回的是完整文本，需要计算增量
+			delta, reasoningDelta := text, reasoning
+			if !r.incremental {
+				delta = incrementalDelta(text, &r.previousText)
+				reasoningDelta = incrementalDelta(reasoning, &r.previousReasoning)
+				toolCalls = r.toolCallDeltas(toolCalls)
 			}
 
 			// 检查是否结束
-			if output.FinishReason != "" && output.FinishReason != "null" {
+			if finishReason != "" && finishReason != "null" {
 				r.finished = true
 				var usage *bs_llm.LLMUsage
 				if chunk.Usage != nil {
@@ -301,14 +499,17 @@ func (r *BailianStreamReader) Read() (provider.StreamResponse, error) {
 						PromptTokens:     chunk.Usage.InputTokens,
 						CompletionTokens: chunk.Usage.OutputTokens,
 						TotalTokens:      chunk.Usage.InputTokens + chunk.Usage.OutputTokens,
+						ReasoningTokens:  chunk.Usage.reasoningTokens(),
 					}
 				}
-				return provider.NewStreamResponse(delta, true, usage, output.FinishReason, nil), nil
+				return provider.NewStreamResponse(delta, true, usage, finishReason, nil).
+					WithToolCalls(toolCalls).WithReasoning(reasoningDelta), nil
 			}
 
 			// 只有在有增量内容时才返回响应
-			if delta != "" {
-				return provider.NewStreamResponse(delta, false, nil, "", nil), nil
+			if delta != "" || reasoningDelta != "" || len(toolCalls) > 0 {
+				return provider.NewStreamResponse(delta, false, nil, "", nil).
+					WithToolCalls(toolCalls).WithReasoning(reasoningDelta), nil
 			}
 		}
 	}
@@ -322,6 +523,53 @@ func (r *BailianStreamReader) Read() (provider.StreamResponse, error) {
 	return provider.NewStreamResponse("", true, nil, "stop", nil), nil
 }
 
+// incrementalDelta 计算完整文本相对上一次的增量并更新 previous，text 为空时不更新
+func incrementalDelta(text string, previous *string) string {
+	if text == "" {
+		return ""
+	}
+	delta := ""
+	// 计算增量：当前文本减去之前的文本
+	if len(text) > len(*previous) {
+		delta = text[len(*previous):]
+	}
+	*previous = text
+	return delta
+}
+
+// toolCallDeltas 计算工具调用增量（百炼返回的是完整的工具调用，需要转换为与OpenAI一致的增量）
+func (r *BailianStreamReader) toolCallDeltas(calls []*provider.ToolCall) []*provider.ToolCall {
+	var deltas []*provider.ToolCall
+	for _, call := range calls {
+		previous, ok := r.previousCall[call.Index]
+		if !ok {
+			r.previousCall[call.Index] = call
+			deltas = append(deltas, call)
+			continue
+		}
+
+		delta := &provider.ToolCall{Index: call.Index}
+		if call.ID != previous.ID {
+			delta.ID = call.ID
+			delta.Type = call.Type
+		}
+		if call.Function.Name != previous.Function.Name {
+			delta.Function.Name = call.Function.Name
+		}
+		if strings.HasPrefix(call.Function.Arguments, previous.Function.Arguments) {
+			delta.Function.Arguments = call.Function.Arguments[len(previous.Function.Arguments):]
+		} else {
+			delta.Function.Arguments = call.Function.Arguments
+		}
+		r.previousCall[call.Index] = call
+
+		if delta.ID != "" || delta.Function.Name != "" || delta.Function.Arguments != "" {
+			deltas = append(deltas, delta)
+		}
+	}
+	return deltas
+}
+
 // Close 关闭流
 func (r *BailianStreamReader) Close() error {
 	r.finished = true
@@ -340,17 +588,103 @@ type BailianInput struct {
 }
 
 type BailianParameters struct {
-	Temperature float64 `json:"temperature,omitempty"`
-	MaxTokens   int64   `json:"max_tokens,omitempty"`
-	TopP        float64 `json:"top_p,omitempty"`
-	TopK        int     `json:"top_k,omitempty"`
-	Seed        int64   `json:"seed,omitempty"`
-	Stream      bool    `json:"stream,omitempty"`
+	Temperature       float64                `json:"temperature"`
+	MaxTokens         int64                  `json:"max_tokens,omitempty"`
+	TopP              float64                `json:"top_p,omitempty"`
+	TopK              int                    `json:"top_k,omitempty"`
+	Seed              *int64                 `json:"seed,omitempty"`
+	Stop              []string               `json:"stop,omitempty"`
+	PresencePenalty   *float64               `json:"presence_penalty,omitempty"`
+	Stream            bool                   `json:"stream,omitempty"`
+	IncrementalOutput bool                   `json:"incremental_output,omitempty"` // 流式输出增量内容，推理模型的流式调用必须开启
+	EnableThinking    *bool                  `json:"enable_thinking,omitempty"`
+	ResultFormat      string                 `json:"result_format,omitempty"`
+	Tools             []BailianTool          `json:"tools,omitempty"`
+	ToolChoice        interface{}            `json:"tool_choice,omitempty"`
+	ResponseFormat    *BailianResponseFormat `json:"response_format,omitempty"`
+}
+
+type BailianResponseFormat struct {
+	Type string `json:"type"`
 }
 
 type BailianMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role             string               `json:"role"`
+	Content          string               `json:"content"`
+	ReasoningContent string               `json:"reasoning_content,omitempty"` // 推理模型的思考内容，仅在响应中返回
+	ToolCalls        []BailianToolCall    `json:"tool_calls,omitempty"`
+	ToolCallID       string               `json:"tool_call_id,omitempty"`
+	Name             string               `json:"name,omitempty"`
+	Parts            []BailianContentPart `json:"-"` // 多模态接口的内容片段，非 nil 时替代 content 发送
+}
+
+// MarshalJSON 多模态接口的 content 为内容片段数组
+func (m BailianMessage) MarshalJSON() ([]byte, error) {
+	type message BailianMessage
+	if m.Parts == nil {
+		return json.Marshal(message(m))
+	}
+	return json.Marshal(struct {
+		message
+		Content []BailianContentPart `json:"content"`
+	}{message: message(m), Content: m.Parts})
+}
+
+// UnmarshalJSON 多模态接口返回的 content 为内容片段数组，拼接其中的文本
+func (m *BailianMessage) UnmarshalJSON(data []byte) error {
+	type message BailianMessage
+	aux := struct {
+		*message
+		Content json.RawMessage `json:"content"`
+	}{message: (*message)(m)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	m.Content = ""
+	if len(aux.Content) == 0 || string(aux.Content) == "null" {
+		return nil
+	}
+	if aux.Content[0] == '"' {
+		return json.Unmarshal(aux.Content, &m.Content)
+	}
+	var parts []BailianContentPart
+	if err := json.Unmarshal(aux.Content, &parts); err != nil {
+		return err
+	}
+	for _, part := range parts {
+		m.Content += part.Text
+	}
+	return nil
+}
+
+type BailianContentPart struct {
+	Text  string `json:"text,omitempty"`
+	Image string `json:"image,omitempty"`
+	Audio string `json:"audio,omitempty"`
+}
+
+type BailianTool struct {
+	Type     string                    `json:"type"`
+	Function BailianFunctionDefinition `json:"function"`
+}
+
+type BailianFunctionDefinition struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+}
+
+type BailianToolCall struct {
+	Index    *int                `json:"index,omitempty"`
+	ID       string              `json:"id,omitempty"`
+	Type     string              `json:"type,omitempty"`
+	Function BailianFunctionCall `json:"function"`
+}
+
+type BailianFunctionCall struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments"`
 }
 
 type BailianResponse struct {
@@ -360,20 +694,34 @@ type BailianResponse struct {
 }
 
 type BailianOutput struct {
-	Text         string `json:"text"`
-	FinishReason string `json:"finish_reason"`
+	Text         string          `json:"text"`
+	FinishReason string          `json:"finish_reason"`
+	Choices      []BailianChoice `json:"choices,omitempty"` // result_format=message 时返回
 }
 
-// 保留旧的结构体定义以备兼容性使用（如果需要）
+// BailianChoice result_format=message 时的输出
 type BailianChoice struct {
 	Message      BailianMessage `json:"message"`
 	FinishReason string         `json:"finish_reason"`
 }
 
 type BailianUsage struct {
-	InputTokens  int64 `json:"input_tokens"`
-	OutputTokens int64 `json:"output_tokens"`
-	TotalTokens  int64 `json:"total_tokens"`
+	InputTokens         int64                       `json:"input_tokens"`
+	OutputTokens        int64                       `json:"output_tokens"`
+	TotalTokens         int64                       `json:"total_tokens"`
+	OutputTokensDetails *BailianOutputTokensDetails `json:"output_tokens_details,omitempty"`
+}
+
+type BailianOutputTokensDetails struct {
+	ReasoningTokens int64 `json:"reasoning_tokens"`
+}
+
+// reasoningTokens 推理token数，未返回明细时为0
+func (u *BailianUsage) reasoningTokens() int64 {
+	if u.OutputTokensDetails == nil {
+		return 0
+	}
+	return u.OutputTokensDetails.ReasoningTokens
 }
 
 // 流式响应结构体
@@ -384,6 +732,7 @@ type BailianStreamChunk struct {
 }
 
 type BailianStreamOutput struct {
-	FinishReason string `json:"finish_reason"`
-	Text         string `json:"text"`
+	FinishReason string          `json:"finish_reason"`
+	Text         string          `json:"text"`
+	Choices      []BailianChoice `json:"choices,omitempty"`
 }
@@ -1,8 +1,18 @@
 package bailian
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"jxzy/bs/bs_llm/internal/provider"
+
+	"github.com/zeromicro/go-zero/core/logx"
 )
 
 func TestBailianStreamChunkParsing(t *testing.T) {
@@ -64,3 +74,302 @@ func TestBailianStreamChunkParsingWithStop(t *testing.T) {
 
 	t.Logf("Parsed chunk with stop: %+v", chunk)
 }
+
+func TestBailianStreamToolCallDeltas(t *testing.T) {
+	// 测试 result_format=message 时完整的工具调用被转换为增量
+	stream := strings.Join([]string{
+		`data:{"output":{"choices":[{"message":{"role":"assistant","content":"","tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":"}}]},"finish_reason":"null"}]}}`,
+		`data:{"output":{"choices":[{"message":{"role":"assistant","content":"","tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"杭州\"}"}}]},"finish_reason":"tool_calls"}]},"usage":{"input_tokens":10,"output_tokens":5}}`,
+	}, "\n")
+
+	reader := NewBailianStreamReader(io.NopCloser(strings.NewReader(stream)), "qwen-plus", logx.WithContext(context.Background()))
+
+	var calls []*provider.ToolCall
+	var last provider.StreamResponse
+	for {
+		resp, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+		calls = append(calls, resp.ToolCalls()...)
+		last = resp
+	}
+
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 tool call deltas, got %d", len(calls))
+	}
+	if calls[0].ID != "call_1" || calls[0].Function.Name != "get_weather" {
+		t.Errorf("Expected first delta to carry id and name, got %+v", calls[0])
+	}
+	if calls[1].ID != "" || calls[1].Function.Name != "" {
+		t.Errorf("Expected second delta to carry arguments only, got %+v", calls[1])
+	}
+	if args := calls[0].Function.Arguments + calls[1].Function.Arguments; args != `{"city":"杭州"}` {
+		t.Errorf("Expected merged arguments '{\"city\":\"杭州\"}', got '%s'", args)
+	}
+	if last == nil || !last.Finished() || last.FinishReason() != "tool_calls" {
+		t.Errorf("Expected last response to finish with tool_calls")
+	}
+}
+
+func TestBailianStreamReasoningDeltas(t *testing.T) {
+	// 推理模型先返回完整的推理过程，再返回回答，均需转换为增量
+	stream := strings.Join([]string{
+		`data:{"output":{"choices":[{"message":{"role":"assistant","content":"","reasoning_content":"用户"},"finish_reason":"null"}]}}`,
+		`data:{"output":{"choices":[{"message":{"role":"assistant","content":"","reasoning_content":"用户在问好"},"finish_reason":"null"}]}}`,
+		`data:{"output":{"choices":[{"message":{"role":"assistant","content":"你好","reasoning_content":"用户在问好"},"finish_reason":"stop"}]},"usage":{"input_tokens":10,"output_tokens":8,"output_tokens_details":{"reasoning_tokens":5}}}`,
+	}, "\n")
+
+	reader := NewBailianStreamReader(io.NopCloser(strings.NewReader(stream)), "qwq-plus", logx.WithContext(context.Background()))
+
+	var reasoning, text string
+	var last provider.StreamResponse
+	for {
+		resp, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+		reasoning += resp.ReasoningDelta()
+		text += resp.Delta()
+		last = resp
+	}
+
+	if reasoning != "用户在问好" || text != "你好" {
+		t.Errorf("Unexpected deltas: reasoning %q, text %q", reasoning, text)
+	}
+	if last == nil || last.Usage() == nil || last.Usage().ReasoningTokens != 5 {
+		t.Errorf("Expected reasoning tokens in final usage")
+	}
+}
+
+func TestBailianApplyTools(t *testing.T) {
+	params := &BailianParameters{}
+	applyTools(params, &provider.LLMRequest{
+		Tools: []*provider.Tool{{
+			Function: &provider.FunctionDefinition{
+				Name:       "get_weather",
+				Parameters: json.RawMessage(`{"type":"object"}`),
+			},
+		}},
+		ToolChoice: "get_weather",
+	})
+
+	if len(params.Tools) != 1 || params.Tools[0].Type != "function" {
+		t.Errorf("Expected one function tool, got %+v", params.Tools)
+	}
+	if _, ok := params.ToolChoice.(map[string]interface{}); !ok {
+		t.Errorf("Expected named tool choice, got %v", params.ToolChoice)
+	}
+}
+
+func TestBailianCallLLMReasoning(t *testing.T) {
+	var body map[string]map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		fmt.Fprint(w, `{"output":{"choices":[{"message":{"role":"assistant","content":"你好","reasoning_content":"用户在问好"},"finish_reason":"stop"}]},"usage":{"input_tokens":10,"output_tokens":8,"total_tokens":18,"output_tokens_details":{"reasoning_tokens":5}}}`)
+	}))
+	defer server.Close()
+
+	// 不带工具和输出格式的普通请求也使用 message 格式，才能拿到推理过程
+	resp, err := NewBailianProvider(nil).CallLLM(context.Background(), &provider.LLMRequest{
+		ModelCode:   "qwen-plus",
+		Messages:    []*provider.ChatMessage{{Role: "user", Content: "你好"}},
+		ExtraParams: map[string]string{"enable_thinking": "true"},
+		Config:      &provider.ProviderConfig{APIEndpoint: server.URL},
+	})
+	if err != nil {
+		t.Fatalf("CallLLM failed: %v", err)
+	}
+	params := body["parameters"]
+	if params["result_format"] != "message" || params["enable_thinking"] != true || params["incremental_output"] != nil {
+		t.Errorf("Unexpected request parameters: %v", params)
+	}
+	if resp.Content != "你好" || resp.ReasoningContent != "用户在问好" || resp.ReasoningTokens != 5 {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+}
+
+func TestBailianStreamLLMIncrementalOutput(t *testing.T) {
+	var body map[string]map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		fmt.Fprint(w, strings.Join([]string{
+			`data:{"output":{"choices":[{"message":{"role":"assistant","content":"","reasoning_content":"用户"},"finish_reason":"null"}]}}`,
+			`data:{"output":{"choices":[{"message":{"role":"assistant","content":"","reasoning_content":"在问好"},"finish_reason":"null"}]}}`,
+			`data:{"output":{"choices":[{"message":{"role":"assistant","content":"你"},"finish_reason":"null"}]}}`,
+			`data:{"output":{"choices":[{"message":{"role":"assistant","content":"好"},"finish_reason":"stop"}]},"usage":{"input_tokens":10,"output_tokens":8}}`,
+		}, "\n"))
+	}))
+	defer server.Close()
+
+	reader, err := NewBailianProvider(nil).StreamLLM(context.Background(), &provider.LLMRequest{
+		ModelCode: "qwen-plus",
+		Messages:  []*provider.ChatMessage{{Role: "user", Content: "你好"}},
+		Config:    &provider.ProviderConfig{APIEndpoint: server.URL},
+	})
+	if err != nil {
+		t.Fatalf("StreamLLM failed: %v", err)
+	}
+	defer reader.Close()
+
+	var reasoning, text string
+	for {
+		resp, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+		reasoning += resp.ReasoningDelta()
+		text += resp.Delta()
+	}
+	params := body["parameters"]
+	if params["result_format"] != "message" || params["incremental_output"] != true || params["enable_thinking"] != nil {
+		t.Errorf("Unexpected request parameters: %v", params)
+	}
+	if reasoning != "用户在问好" || text != "你好" {
+		t.Errorf("Unexpected deltas: reasoning %q, text %q", reasoning, text)
+	}
+}
+
+func TestBailianParametersKeepZeroTemperature(t *testing.T) {
+	// 温度为0时也要发送，否则供应商按默认温度采样
+	data, err := json.Marshal(&BailianParameters{Temperature: 0})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"temperature":0`) {
+		t.Errorf("Expected temperature 0 in %s", data)
+	}
+}
+
+func TestBailianApplySampling(t *testing.T) {
+	// 未指定时保留默认 top_p，指定时使用调用方的值
+	params := &BailianParameters{TopP: 0.8}
+	applySampling(params, &provider.LLMRequest{})
+	if params.TopP != 0.8 || params.Seed != nil || params.Stop != nil {
+		t.Errorf("Expected defaults to be kept, got %+v", params)
+	}
+
+	topP, seed, penalty := 0.5, int64(42), 1.0
+	applySampling(params, &provider.LLMRequest{TopP: &topP, Seed: &seed, Stop: []string{"END"}, PresencePenalty: &penalty})
+	data, _ := json.Marshal(params)
+	for _, want := range []string{`"top_p":0.5`, `"seed":42`, `"stop":["END"]`, `"presence_penalty":1`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected %s in %s", want, data)
+		}
+	}
+}
+
+func TestBailianApplySamplingZeroSeed(t *testing.T) {
+	// seed=0 是合法取值，需要发送
+	seed := int64(0)
+	params := &BailianParameters{}
+	applySampling(params, &provider.LLMRequest{Seed: &seed})
+	data, _ := json.Marshal(params)
+	if !strings.Contains(string(data), `"seed":0`) {
+		t.Errorf("Expected seed 0 in %s", data)
+	}
+}
+
+func TestBailianMultimodalMessages(t *testing.T) {
+	messages := []*provider.ChatMessage{
+		{Role: "system", Content: "你是助手"},
+		{Role: "user", Content: "这是什么", Parts: []*provider.ContentPart{
+			{Type: provider.ContentPartText, Text: "这是什么"},
+			{Type: provider.ContentPartImage, Data: []byte("png"), MimeType: "image/png"},
+		}},
+	}
+	if !hasMedia(messages) {
+		t.Fatal("Expected messages to contain media")
+	}
+	if endpoint := resolveEndpoint("", true); endpoint != MultimodalAPIEndpoint {
+		t.Errorf("Expected multimodal endpoint, got %s", endpoint)
+	}
+
+	data, err := json.Marshal(convertMessages(messages, true))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	expected := `[{"role":"system","content":[{"text":"你是助手"}]},{"role":"user","content":[{"text":"这是什么"},{"image":"data:image/png;base64,cG5n"}]}]`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	// 多模态接口返回的 content 为片段数组
+	var choice BailianChoice
+	if err := json.Unmarshal([]byte(`{"message":{"role":"assistant","content":[{"text":"一只"},{"text":"猫"}]},"finish_reason":"stop"}`), &choice); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if choice.Message.Content != "一只猫" || choice.Message.Role != "assistant" {
+		t.Errorf("Expected content '一只猫', got %+v", choice.Message)
+	}
+}
+
+func TestBailianEmbed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/services/embeddings/text-embedding/text-embedding" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			t.Errorf("Unexpected authorization: %s", r.Header.Get("Authorization"))
+		}
+		var req BailianEmbeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		if req.Model != "text-embedding-v4" || len(req.Input.Texts) != 2 || req.Parameters == nil || req.Parameters.Dimension != 2 {
+			t.Errorf("Unexpected request: %+v", req)
+		}
+		fmt.Fprint(w, `{"output":{"embeddings":[{"text_index":1,"embedding":[0,1]},{"text_index":0,"embedding":[1,0]}]},"usage":{"total_tokens":3},"request_id":"r1"}`)
+	}))
+	defer server.Close()
+
+	// 配置的文本生成接口地址替换为同一域名下的向量接口
+	p := NewBailianProvider(nil)
+	resp, err := p.Embed(context.Background(), &provider.EmbeddingRequest{
+		Texts:      []string{"你好", "世界"},
+		ModelCode:  "text-embedding-v4",
+		Dimensions: 2,
+		Config:     &provider.ProviderConfig{APIEndpoint: server.URL + "/api/v1/services/aigc/text-generation/generation", APIKey: "test-key"},
+	})
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if fmt.Sprint(resp.Embeddings) != "[[1 0] [0 1]]" || resp.PromptTokens != 3 || resp.TotalTokens != 3 {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+
+	if endpoint := resolveEmbeddingEndpoint(""); endpoint != DefaultEmbeddingAPIEndpoint {
+		t.Errorf("Expected default embedding endpoint, got %s", endpoint)
+	}
+}
+
+func TestBailianHealthCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 鉴权通过时空请求返回参数错误
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"code":"InvalidApiKey"}`)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"code":"InvalidParameter"}`)
+	}))
+	defer server.Close()
+
+	if err := NewBailianProvider(&provider.ProviderConfig{APIEndpoint: server.URL, APIKey: "test-key"}).HealthCheck(context.Background()); err != nil {
+		t.Errorf("Expected healthy provider, got %v", err)
+	}
+	err := NewBailianProvider(&provider.ProviderConfig{APIEndpoint: server.URL, APIKey: "bad-key"}).HealthCheck(context.Background())
+	if statusErr, ok := err.(*provider.StatusError); !ok || statusErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected 401 status error, got %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+package bailian
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"jxzy/bs/bs_llm/internal/provider"
+)
+
+const (
+	DefaultEmbeddingAPIEndpoint = "https://dashscope.aliyuncs.com/api/v1/services/embeddings/text-embedding/text-embedding"
+
+	embeddingPath = "/services/embeddings/text-embedding/text-embedding"
+	// maxEmbeddingBatch text-embedding-v3/v4 单次请求最多10条文本
+	maxEmbeddingBatch = 10
+)
+
+// Embed 调用百炼通用文本向量接口
+func (p *BailianProvider) Embed(ctx context.Context, req *provider.EmbeddingRequest) (*provider.EmbeddingResponse, error) {
+	apiReq := &BailianEmbeddingRequest{
+		Model: req.ModelCode,
+		Input: BailianEmbeddingInput{Texts: req.Texts},
+	}
+	if req.Dimensions > 0 {
+		apiReq.Parameters = &BailianEmbeddingParameters{Dimension: req.Dimensions}
+	}
+
+	reqBody, err := json.Marshal(apiReq)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request failed: %w", err)
+	}
+
+	endpoint := resolveEmbeddingEndpoint(req.Config.APIEndpoint)
+	p.logger.Infof("Bailian Embedding API Request - Endpoint: %s, Model: %s, Texts: %d", endpoint, req.ModelCode, len(req.Texts))
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+req.Config.APIKey)
+	for k, v := range req.Config.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := p.createHTTPClient(req.Config).Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &provider.StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	var apiResp BailianEmbeddingResponse
+	if err := json.Unmarshal(respBody, &apiResp); err != nil {
+		return nil, fmt.Errorf("decode response failed: %w", err)
+	}
+
+	// 按 text_index 还原顺序
+	embeddings := make([][]float32, len(req.Texts))
+	for _, item := range apiResp.Output.Embeddings {
+		if item.TextIndex < 0 || item.TextIndex >= len(embeddings) {
+			return nil, fmt.Errorf("invalid text_index %d in response", item.TextIndex)
+		}
+		embeddings[item.TextIndex] = item.Embedding
+	}
+	for i, embedding := range embeddings {
+		if len(embedding) == 0 {
+			return nil, fmt.Errorf("no embedding for text %d in response", i)
+		}
+	}
+
+	return &provider.EmbeddingResponse{
+		Embeddings:   embeddings,
+		ModelCode:    req.ModelCode,
+		PromptTokens: apiResp.Usage.TotalTokens,
+		TotalTokens:  apiResp.Usage.TotalTokens,
+	}, nil
+}
+
+// MaxEmbeddingBatch 单次请求的最大文本数
+func (p *BailianProvider) MaxEmbeddingBatch() int {
+	return maxEmbeddingBatch
+}
+
+// resolveEmbeddingEndpoint 向量接口地址，沿用配置中文本生成接口的域名（如代理地址）
+func resolveEmbeddingEndpoint(endpoint string) string {
+	if i := strings.Index(endpoint, "/services/"); i >= 0 {
+		return endpoint[:i] + embeddingPath
+	}
+	return DefaultEmbeddingAPIEndpoint
+}
+
+// 百炼向量接口请求和响应结构体
+type BailianEmbeddingRequest struct {
+	Model      string                      `json:"model"`
+	Input      BailianEmbeddingInput       `json:"input"`
+	Parameters *BailianEmbeddingParameters `json:"parameters,omitempty"`
+}
+
+type BailianEmbeddingInput struct {
+	Texts []string `json:"texts"`
+}
+
+type BailianEmbeddingParameters struct {
+	Dimension int64 `json:"dimension,omitempty"`
+}
+
+type BailianEmbeddingResponse struct {
+	RequestID string `json:"request_id"`
+	Output    struct {
+		Embeddings []struct {
+			TextIndex int       `json:"text_index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"embeddings"`
+	} `json:"output"`
+	Usage struct {
+		TotalTokens int64 `json:"total_tokens"`
+	} `json:"usage"`
+}
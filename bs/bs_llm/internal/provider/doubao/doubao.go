@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -24,16 +25,17 @@ const (
 
 // DoubaoProvider 豆包供应商实现
 type DoubaoProvider struct {
-	client *http.Client
+	config *provider.ProviderConfig
 	logger logx.Logger
 }
 
-// NewDoubaoProvider 创建豆包供应商
-func NewDoubaoProvider() *DoubaoProvider {
+// NewDoubaoProvider 创建豆包供应商，config 为该供应商的默认配置，用于健康检查
+func NewDoubaoProvider(config *provider.ProviderConfig) *DoubaoProvider {
+	if config == nil {
+		config = &provider.ProviderConfig{}
+	}
 	return &DoubaoProvider{
-		client: &http.Client{
-			Timeout: 120 * time.Second,
-		},
+		config: config,
 		logger: logx.WithContext(context.Background()),
 	}
 }
@@ -46,11 +48,19 @@ func (p *DoubaoProvider) Name() string {
 // CallLLM 非流式调用
 func (p *DoubaoProvider) CallLLM(ctx context.Context, req *provider.LLMRequest) (*provider.LLMResponse, error) {
 	apiReq := &ChatCompletionRequest{
-		Model:       req.ModelCode,
-		Messages:    convertMessages(req.Messages),
-		Temperature: req.Temperature,
-		MaxTokens:   req.MaxTokens,
-		Stream:      false,
+		Model:            req.ModelCode,
+		Messages:         convertMessages(req.Messages),
+		Temperature:      req.Temperature,
+		MaxTokens:        req.MaxTokens,
+		TopP:             req.TopP,
+		Stop:             req.Stop,
+		Seed:             req.Seed,
+		PresencePenalty:  req.PresencePenalty,
+		FrequencyPenalty: req.FrequencyPenalty,
+		Stream:           false,
+		Tools:            convertTools(req.Tools),
+		ToolChoice:       convertToolChoice(req.ToolChoice),
+		ResponseFormat:   convertResponseFormat(req.ResponseFormat),
 	}
 
 	reqBody, err := json.Marshal(apiReq)
@@ -75,7 +85,7 @@ func (p *DoubaoProvider) CallLLM(ctx context.Context, req *provider.LLMRequest)
 		httpReq.Header.Set(k, v)
 	}
 
-	resp, err := p.client.Do(httpReq)
+	resp, err := createHTTPClient(req.Config).Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -83,7 +93,7 @@ func (p *DoubaoProvider) CallLLM(ctx context.Context, req *provider.LLMRequest)
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, &provider.StatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var apiResp ChatCompletionResponse
@@ -103,17 +113,28 @@ func (p *DoubaoProvider) CallLLM(ctx context.Context, req *provider.LLMRequest)
 		CompletionTokens: apiResp.Usage.CompletionTokens,
 		TotalTokens:      apiResp.Usage.TotalTokens,
 		FinishReason:     choice.FinishReason,
+		ToolCalls:        toProviderToolCalls(choice.Message.ToolCalls),
+		ReasoningContent: choice.Message.ReasoningContent,
+		ReasoningTokens:  apiResp.Usage.reasoningTokens(),
 	}, nil
 }
 
 // StreamLLM 流式调用
 func (p *DoubaoProvider) StreamLLM(ctx context.Context, req *provider.LLMRequest) (provider.StreamReader, error) {
 	apiReq := &ChatCompletionRequest{
-		Model:       req.ModelCode,
-		Messages:    convertMessages(req.Messages),
-		Temperature: req.Temperature,
-		MaxTokens:   req.MaxTokens,
-		Stream:      true,
+		Model:            req.ModelCode,
+		Messages:         convertMessages(req.Messages),
+		Temperature:      req.Temperature,
+		MaxTokens:        req.MaxTokens,
+		TopP:             req.TopP,
+		Stop:             req.Stop,
+		Seed:             req.Seed,
+		PresencePenalty:  req.PresencePenalty,
+		FrequencyPenalty: req.FrequencyPenalty,
+		Stream:           true,
+		Tools:            convertTools(req.Tools),
+		ToolChoice:       convertToolChoice(req.ToolChoice),
+		ResponseFormat:   convertResponseFormat(req.ResponseFormat),
 	}
 
 	reqBody, err := json.Marshal(apiReq)
@@ -139,7 +160,7 @@ func (p *DoubaoProvider) StreamLLM(ctx context.Context, req *provider.LLMRequest
 		httpReq.Header.Set(k, v)
 	}
 
-	resp, err := p.client.Do(httpReq)
+	resp, err := createHTTPClient(req.Config).Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -147,31 +168,169 @@ func (p *DoubaoProvider) StreamLLM(ctx context.Context, req *provider.LLMRequest
 	if resp.StatusCode != http.StatusOK {
 		defer resp.Body.Close()
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, &provider.StatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	return NewDoubaoStreamReader(resp.Body, req.ModelCode), nil
 }
 
-// HealthCheck 健康检查
+// HealthCheck 健康检查，向对话接口发送空请求验证连通性和鉴权
 func (p *DoubaoProvider) HealthCheck(ctx context.Context) error {
-	// 这里可以实现简单的健康检查逻辑
-	// 比如调用一个简单的API来验证连接性
-	return nil
+	endpoint := p.config.APIEndpoint
+	if endpoint == "" {
+		endpoint = DefaultAPIEndpoint
+	}
+	return provider.Probe(ctx, createHTTPClient(p.config), endpoint, p.config)
+}
+
+// createHTTPClient 根据配置创建HTTP客户端
+func createHTTPClient(config *provider.ProviderConfig) *http.Client {
+	timeout := 120 * time.Second
+	if config != nil && config.Timeout > 0 {
+		timeout = time.Duration(config.Timeout) * time.Second
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+	}
 }
 
 // convertMessages 转换消息格式
 func convertMessages(messages []*provider.ChatMessage) []ChatMessage {
 	var result []ChatMessage
 	for _, msg := range messages {
-		result = append(result, ChatMessage{
-			Role:    msg.Role,
-			Content: msg.Content,
+		message := ChatMessage{
+			Role:       msg.Role,
+			Content:    msg.Content,
+			ToolCallID: msg.ToolCallID,
+			Name:       msg.Name,
+		}
+		if msg.HasMedia() {
+			message.Parts = convertContentParts(msg.Parts)
+		}
+		for _, call := range msg.ToolCalls {
+			message.ToolCalls = append(message.ToolCalls, ToolCall{
+				ID:   call.ID,
+				Type: toolType(call.Type),
+				Function: FunctionCall{
+					Name:      call.Function.Name,
+					Arguments: call.Function.Arguments,
+				},
+			})
+		}
+		result = append(result, message)
+	}
+	return result
+}
+
+// convertContentParts 转换多模态内容片段：图片为 image_url，音频原始数据为 input_audio，音频 URL 为 audio_url
+func convertContentParts(parts []*provider.ContentPart) []ContentPart {
+	var result []ContentPart
+	for _, part := range parts {
+		switch part.Type {
+		case provider.ContentPartText:
+			result = append(result, ContentPart{Type: "text", Text: part.Text})
+		case provider.ContentPartImage:
+			result = append(result, ContentPart{Type: "image_url", ImageURL: &MediaURL{URL: part.URI()}})
+		case provider.ContentPartAudio:
+			if part.URL != "" {
+				result = append(result, ContentPart{Type: "audio_url", AudioURL: &MediaURL{URL: part.URL}})
+				continue
+			}
+			result = append(result, ContentPart{Type: "input_audio", InputAudio: &InputAudio{
+				Data:   base64.StdEncoding.EncodeToString(part.Data),
+				Format: part.Format(),
+			}})
+		}
+	}
+	return result
+}
+
+// convertTools 转换工具定义
+func convertTools(tools []*provider.Tool) []Tool {
+	var result []Tool
+	for _, tool := range tools {
+		if tool.Function == nil {
+			continue
+		}
+		result = append(result, Tool{
+			Type: toolType(tool.Type),
+			Function: FunctionDefinition{
+				Name:        tool.Function.Name,
+				Description: tool.Function.Description,
+				Parameters:  tool.Function.Parameters,
+			},
+		})
+	}
+	return result
+}
+
+// convertToolChoice 转换工具选择策略，auto/none/required 之外的值视为指定的函数名
+func convertToolChoice(toolChoice string) interface{} {
+	switch toolChoice {
+	case "":
+		return nil
+	case "auto", "none", "required":
+		return toolChoice
+	default:
+		return map[string]interface{}{
+			"type":     "function",
+			"function": map[string]string{"name": toolChoice},
+		}
+	}
+}
+
+// convertResponseFormat 转换输出格式，普通文本不传
+func convertResponseFormat(format *provider.ResponseFormat) *ResponseFormat {
+	if format == nil || format.Type == "" || format.Type == provider.ResponseFormatText {
+		return nil
+	}
+	if format.Type != provider.ResponseFormatJSONSchema {
+		return &ResponseFormat{Type: format.Type}
+	}
+	name := format.Name
+	if name == "" {
+		name = "response"
+	}
+	return &ResponseFormat{
+		Type: format.Type,
+		JSONSchema: &JSONSchema{
+			Name:   name,
+			Schema: format.Schema,
+			Strict: format.Strict,
+		},
+	}
+}
+
+// toProviderToolCalls 转换模型返回的工具调用
+func toProviderToolCalls(calls []ToolCall) []*provider.ToolCall {
+	var result []*provider.ToolCall
+	for i, call := range calls {
+		index := i
+		if call.Index != nil {
+			index = *call.Index
+		}
+		result = append(result, &provider.ToolCall{
+			Index: index,
+			ID:    call.ID,
+			Type:  call.Type,
+			Function: provider.FunctionCall{
+				Name:      call.Function.Name,
+				Arguments: call.Function.Arguments,
+			},
 		})
 	}
 	return result
 }
 
+// toolType 工具类型默认为 function
+func toolType(t string) string {
+	if t == "" {
+		return "function"
+	}
+	return t
+}
+
 // DoubaoStreamReader 豆包流式读取器
 type DoubaoStreamReader struct {
 	reader    io.ReadCloser
@@ -222,6 +381,8 @@ func (r *DoubaoStreamReader) Read() (provider.StreamResponse, error) {
 				if choice.Delta.Content != "" {
 					delta = choice.Delta.Content
 				}
+				reasoning := choice.Delta.ReasoningContent
+				toolCalls := toProviderToolCalls(choice.Delta.ToolCalls)
 
 				// 检查是否结束
 				if choice.FinishReason != "" {
@@ -232,12 +393,14 @@ func (r *DoubaoStreamReader) Read() (provider.StreamResponse, error) {
 							PromptTokens:     chunk.Usage.PromptTokens,
 							CompletionTokens: chunk.Usage.CompletionTokens,
 							TotalTokens:      chunk.Usage.TotalTokens,
+							ReasoningTokens:  chunk.Usage.reasoningTokens(),
 						}
 					}
-					return provider.NewStreamResponse(delta, true, usage, choice.FinishReason, nil), nil
+					return provider.NewStreamResponse(delta, true, usage, choice.FinishReason, nil).
+						WithToolCalls(toolCalls).WithReasoning(reasoning), nil
 				}
 
-				return provider.NewStreamResponse(delta, false, nil, "", nil), nil
+				return provider.NewStreamResponse(delta, false, nil, "", nil).WithToolCalls(toolCalls).WithReasoning(reasoning), nil
 			}
 		}
 	}
@@ -259,16 +422,92 @@ func (r *DoubaoStreamReader) Close() error {
 
 // API请求和响应结构体
 type ChatCompletionRequest struct {
-	Model       string        `json:"model"`
-	Messages    []ChatMessage `json:"messages"`
-	Temperature float64       `json:"temperature,omitempty"`
-	MaxTokens   int64         `json:"max_tokens,omitempty"`
-	Stream      bool          `json:"stream"`
+	Model            string          `json:"model"`
+	Messages         []ChatMessage   `json:"messages"`
+	Temperature      float64         `json:"temperature"`
+	MaxTokens        int64           `json:"max_tokens,omitempty"`
+	TopP             *float64        `json:"top_p,omitempty"`
+	Stop             []string        `json:"stop,omitempty"`
+	Seed             *int64          `json:"seed,omitempty"`
+	PresencePenalty  *float64        `json:"presence_penalty,omitempty"`
+	FrequencyPenalty *float64        `json:"frequency_penalty,omitempty"`
+	Stream           bool            `json:"stream"`
+	Tools            []Tool          `json:"tools,omitempty"`
+	ToolChoice       interface{}     `json:"tool_choice,omitempty"`
+	ResponseFormat   *ResponseFormat `json:"response_format,omitempty"`
+}
+
+type ResponseFormat struct {
+	Type       string      `json:"type"`
+	JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+}
+
+type JSONSchema struct {
+	Name   string          `json:"name"`
+	Schema json.RawMessage `json:"schema,omitempty"`
+	Strict bool            `json:"strict,omitempty"`
 }
 
 type ChatMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role             string        `json:"role"`
+	Content          string        `json:"content"`
+	ReasoningContent string        `json:"reasoning_content,omitempty"` // 深度思考模型的思考内容，仅在响应中返回
+	ToolCalls        []ToolCall    `json:"tool_calls,omitempty"`
+	ToolCallID       string        `json:"tool_call_id,omitempty"`
+	Name             string        `json:"name,omitempty"`
+	Parts            []ContentPart `json:"-"` // 多模态内容片段，非空时替代 content 发送
+}
+
+// MarshalJSON 含多模态内容片段时 content 以片段数组发送
+func (m ChatMessage) MarshalJSON() ([]byte, error) {
+	type message ChatMessage
+	if len(m.Parts) == 0 {
+		return json.Marshal(message(m))
+	}
+	return json.Marshal(struct {
+		message
+		Content []ContentPart `json:"content"`
+	}{message: message(m), Content: m.Parts})
+}
+
+type ContentPart struct {
+	Type       string      `json:"type"`
+	Text       string      `json:"text,omitempty"`
+	ImageURL   *MediaURL   `json:"image_url,omitempty"`
+	AudioURL   *MediaURL   `json:"audio_url,omitempty"`
+	InputAudio *InputAudio `json:"input_audio,omitempty"`
+}
+
+type MediaURL struct {
+	URL string `json:"url"`
+}
+
+type InputAudio struct {
+	Data   string `json:"data"`
+	Format string `json:"format"`
+}
+
+type Tool struct {
+	Type     string             `json:"type"`
+	Function FunctionDefinition `json:"function"`
+}
+
+type FunctionDefinition struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+}
+
+type ToolCall struct {
+	Index    *int         `json:"index,omitempty"`
+	ID       string       `json:"id,omitempty"`
+	Type     string       `json:"type,omitempty"`
+	Function FunctionCall `json:"function"`
+}
+
+type FunctionCall struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments"`
 }
 
 type ChatCompletionResponse struct {
@@ -287,9 +526,22 @@ type Choice struct {
 }
 
 type Usage struct {
-	PromptTokens     int64 `json:"prompt_tokens"`
-	CompletionTokens int64 `json:"completion_tokens"`
-	TotalTokens      int64 `json:"total_tokens"`
+	PromptTokens            int64                    `json:"prompt_tokens"`
+	CompletionTokens        int64                    `json:"completion_tokens"`
+	TotalTokens             int64                    `json:"total_tokens"`
+	CompletionTokensDetails *CompletionTokensDetails `json:"completion_tokens_details,omitempty"`
+}
+
+type CompletionTokensDetails struct {
+	ReasoningTokens int64 `json:"reasoning_tokens"`
+}
+
+// reasoningTokens 推理token数，未返回明细时为0
+func (u *Usage) reasoningTokens() int64 {
+	if u.CompletionTokensDetails == nil {
+		return 0
+	}
+	return u.CompletionTokensDetails.ReasoningTokens
 }
 
 // 流式响应结构体
@@ -0,0 +1,57 @@
+package doubao
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"jxzy/bs/bs_llm/internal/provider"
+)
+
+func TestChatCompletionRequestKeepsZeroTemperature(t *testing.T) {
+	// 温度为0时也要发送，否则供应商按默认温度采样
+	data, err := json.Marshal(&ChatCompletionRequest{Model: "doubao-pro", Temperature: 0})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"temperature":0`) {
+		t.Errorf("Expected temperature 0 in %s", data)
+	}
+}
+
+func TestCallLLMSendsSeed(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"ok"},"finish_reason":"stop"}]}`)
+	}))
+	defer server.Close()
+
+	seed := int64(0)
+	_, err := NewDoubaoProvider(nil).CallLLM(context.Background(), &provider.LLMRequest{
+		ModelCode: "doubao-pro",
+		Messages:  []*provider.ChatMessage{{Role: "user", Content: "hi"}},
+		Seed:      &seed,
+		Config:    &provider.ProviderConfig{APIEndpoint: server.URL},
+	})
+	if err != nil {
+		t.Fatalf("CallLLM failed: %v", err)
+	}
+	if v, ok := body["seed"]; !ok || v != float64(0) {
+		t.Errorf("Expected seed 0 in request, got %v", body)
+	}
+}
+
+func TestCreateHTTPClientUsesConfigTimeout(t *testing.T) {
+	if timeout := createHTTPClient(&provider.ProviderConfig{Timeout: 5}).Timeout; timeout != 5*time.Second {
+		t.Errorf("Expected 5s timeout, got %s", timeout)
+	}
+	if timeout := createHTTPClient(&provider.ProviderConfig{}).Timeout; timeout != 120*time.Second {
+		t.Errorf("Expected default 120s timeout, got %s", timeout)
+	}
+}
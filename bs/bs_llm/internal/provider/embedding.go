@@ -0,0 +1,28 @@
+package provider
+
+import "context"
+
+// Embedder 支持文本向量化的供应商，未实现该接口的供应商不能用于向量化场景
+type Embedder interface {
+	// Embed 向量化一批文本，文本数不超过 MaxEmbeddingBatch
+	Embed(ctx context.Context, req *EmbeddingRequest) (*EmbeddingResponse, error)
+	// MaxEmbeddingBatch 单次请求的最大文本数
+	MaxEmbeddingBatch() int
+}
+
+// EmbeddingRequest 标准化的向量化请求
+type EmbeddingRequest struct {
+	SceneCode  string          `json:"scene_code"` // 调用方场景编码，供应商接口不使用
+	Texts      []string        `json:"texts"`
+	ModelCode  string          `json:"model_code"`
+	Dimensions int64           `json:"dimensions,omitempty"` // 0表示使用模型默认维度
+	Config     *ProviderConfig `json:"config"`
+}
+
+// EmbeddingResponse 标准化的向量化响应，Embeddings 与请求的 Texts 一一对应
+type EmbeddingResponse struct {
+	Embeddings   [][]float32 `json:"embeddings"`
+	ModelCode    string      `json:"model_code"`
+	PromptTokens int64       `json:"prompt_tokens"`
+	TotalTokens  int64       `json:"total_tokens"`
+}
@@ -0,0 +1,74 @@
+package fake
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"jxzy/bs/bs_llm/internal/provider"
+)
+
+const (
+	// DefaultEmbeddingDimensions 请求未指定维度时的向量维度
+	DefaultEmbeddingDimensions = 64
+	// maxEmbeddingBatch 单次请求的最大文本数，与百炼一致，便于测试分批
+	maxEmbeddingBatch = 10
+)
+
+// Embed 返回确定性的向量：按字符哈希到各维度计数后归一化，字符重合越多的文本余弦相似度越高
+// 规则按场景编码和全部文本（换行拼接）匹配，只使用延迟、错误注入和 PromptTokens 配置
+func (p *FakeProvider) Embed(ctx context.Context, req *provider.EmbeddingRequest) (*provider.EmbeddingResponse, error) {
+	rule, _ := p.matchInput(req.SceneCode, strings.Join(req.Texts, "\n"))
+	if err := p.inject(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	dimensions := int(req.Dimensions)
+	if dimensions <= 0 {
+		dimensions = DefaultEmbeddingDimensions
+	}
+	embeddings := make([][]float32, len(req.Texts))
+	promptTokens := rule.response.PromptTokens
+	for i, text := range req.Texts {
+		embeddings[i] = embed(text, dimensions)
+		if rule.response.PromptTokens == 0 {
+			promptTokens += int64(utf8.RuneCountInString(text))
+		}
+	}
+	return &provider.EmbeddingResponse{
+		Embeddings:   embeddings,
+		ModelCode:    req.ModelCode,
+		PromptTokens: promptTokens,
+		TotalTokens:  promptTokens,
+	}, nil
+}
+
+// MaxEmbeddingBatch 单次请求的最大文本数
+func (p *FakeProvider) MaxEmbeddingBatch() int {
+	return maxEmbeddingBatch
+}
+
+// embed 计算文本的字符哈希向量
+func embed(text string, dimensions int) []float32 {
+	counts := make([]float64, dimensions)
+	for _, r := range text {
+		h := fnv.New32a()
+		h.Write([]byte(string(r)))
+		counts[h.Sum32()%uint32(dimensions)]++
+	}
+
+	var norm float64
+	for _, c := range counts {
+		norm += c * c
+	}
+	norm = math.Sqrt(norm)
+	vector := make([]float32, dimensions)
+	for i, c := range counts {
+		if norm > 0 {
+			vector[i] = float32(c / norm)
+		}
+	}
+	return vector
+}
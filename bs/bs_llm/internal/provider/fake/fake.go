@@ -0,0 +1,320 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/provider"
+
+	"github.com/zeromicro/go-zero/core/conf"
+)
+
+const ProviderName = "fake"
+
+// Script 模拟供应商的应答脚本
+type Script struct {
+	Rules   []Rule   `json:",optional"` // 按顺序匹配，第一个匹配的规则生效
+	Default Response `json:",optional"` // 未匹配任何规则时的应答，Content 为空时回显最后一条用户消息
+}
+
+// Rule 应答规则，SceneCode 和 Pattern 同时配置时需同时满足
+type Rule struct {
+	SceneCode string `json:",optional"` // 为空匹配所有场景
+	Pattern   string `json:",optional"` // 匹配最后一条用户消息的正则，为空匹配所有消息
+	Response
+}
+
+// Response 模拟应答
+type Response struct {
+	Content          string `json:",optional"`     // 应答内容，可用 $1、${name} 引用 Pattern 的分组
+	Reasoning        string `json:",optional"`     // 推理过程，流式调用时在应答内容之前按 ChunkSize 分片返回
+	FinishReason     string `json:",default=stop"` // 结束原因
+	Latency          int    `json:",optional"`     // 首个响应前的延迟（毫秒）
+	ChunkSize        int    `json:",default=4"`    // 流式调用每个增量的字符数
+	ChunkInterval    int    `json:",optional"`     // 流式调用增量之间的间隔（毫秒）
+	PromptTokens     int64  `json:",optional"`     // 为0时按消息字符数计算
+	CompletionTokens int64  `json:",optional"`     // 为0时按应答和推理过程的字符数计算
+	ErrorStatus      int    `json:",optional"`     // 非0时调用返回该状态码的 StatusError，如 429、503
+	ErrorMessage     string `json:",optional"`     // 注入错误的响应体，默认 injected error
+	FailTimes        int    `json:",optional"`     // 注入错误的次数，之后正常应答；0 表示每次都返回错误
+	FailAfterChunks  int    `json:",optional"`     // 流式调用发送该数量的增量后返回 ErrorStatus（默认500）错误，0 表示不在流中注入
+}
+
+// compiledRule 编译后的应答规则
+type compiledRule struct {
+	sceneCode string
+	pattern   *regexp.Regexp
+	response  Response
+}
+
+// FakeProvider 本地确定性的模拟供应商，用于离线的集成测试
+// 按场景编码或最后一条用户消息匹配脚本中的规则返回预设应答，支持延迟、流式分片、用量和错误注入。
+type FakeProvider struct {
+	name     string
+	rules    []*compiledRule
+	fallback *compiledRule
+
+	mu    sync.Mutex
+	fails map[*compiledRule]int // 规则 -> 已注入的错误次数
+}
+
+// NewFakeProvider 按脚本创建模拟供应商，script 为空时回显最后一条用户消息
+func NewFakeProvider(name string, script *Script) (*FakeProvider, error) {
+	if script == nil {
+		script = &Script{}
+	}
+	p := &FakeProvider{
+		name:     name,
+		fallback: &compiledRule{response: withDefaults(script.Default)},
+		fails:    make(map[*compiledRule]int),
+	}
+	for i, rule := range script.Rules {
+		compiled := &compiledRule{sceneCode: rule.SceneCode, response: withDefaults(rule.Response)}
+		if rule.Pattern != "" {
+			pattern, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern in rule %d: %w", i, err)
+			}
+			compiled.pattern = pattern
+		}
+		p.rules = append(p.rules, compiled)
+	}
+	return p, nil
+}
+
+// LoadScript 从 yaml 或 json 文件加载应答脚本
+func LoadScript(path string) (*Script, error) {
+	var script Script
+	if err := conf.Load(path, &script); err != nil {
+		return nil, fmt.Errorf("failed to load fake script %s: %w", path, err)
+	}
+	return &script, nil
+}
+
+// Name 供应商名称
+func (p *FakeProvider) Name() string {
+	return p.name
+}
+
+// CallLLM 非流式调用
+func (p *FakeProvider) CallLLM(ctx context.Context, req *provider.LLMRequest) (*provider.LLMResponse, error) {
+	rule, content := p.match(req)
+	if err := p.inject(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	promptTokens, completionTokens := usage(rule.response, req, content)
+	return &provider.LLMResponse{
+		Content:          content,
+		ModelCode:        req.ModelCode,
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		TotalTokens:      promptTokens + completionTokens,
+		FinishReason:     rule.response.FinishReason,
+		ReasoningContent: rule.response.Reasoning,
+		ReasoningTokens:  reasoningTokens(rule.response),
+	}, nil
+}
+
+// StreamLLM 流式调用，按 ChunkSize 切分应答内容
+func (p *FakeProvider) StreamLLM(ctx context.Context, req *provider.LLMRequest) (provider.StreamReader, error) {
+	rule, content := p.match(req)
+	if rule.response.FailAfterChunks > 0 {
+		// 错误在流中注入，建立连接时只等待延迟
+		if err := sleep(ctx, rule.response.Latency); err != nil {
+			return nil, err
+		}
+	} else if err := p.inject(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	promptTokens, completionTokens := usage(rule.response, req, content)
+	return &FakeStreamReader{
+		ctx:       ctx,
+		reasoning: split(rule.response.Reasoning, rule.response.ChunkSize),
+		chunks:    split(content, rule.response.ChunkSize),
+		response:  rule.response,
+		usage: &bs_llm.LLMUsage{
+			PromptTokens:     promptTokens,
+			CompletionTokens: completionTokens,
+			TotalTokens:      promptTokens + completionTokens,
+			ReasoningTokens:  reasoningTokens(rule.response),
+		},
+	}, nil
+}
+
+// HealthCheck 健康检查
+func (p *FakeProvider) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+// match 返回匹配的规则及应答内容
+func (p *FakeProvider) match(req *provider.LLMRequest) (*compiledRule, string) {
+	return p.matchInput(req.SceneCode, lastUserMessage(req.Messages))
+}
+
+// matchInput 按场景编码和输入文本匹配规则，返回匹配的规则及应答内容
+func (p *FakeProvider) matchInput(sceneCode, input string) (*compiledRule, string) {
+	for _, rule := range p.rules {
+		if rule.sceneCode != "" && rule.sceneCode != sceneCode {
+			continue
+		}
+		if rule.pattern == nil {
+			return rule, rule.response.Content
+		}
+		if submatch := rule.pattern.FindStringSubmatchIndex(input); submatch != nil {
+			return rule, string(rule.pattern.ExpandString(nil, rule.response.Content, input, submatch))
+		}
+	}
+	if p.fallback.response.Content == "" {
+		return p.fallback, input
+	}
+	return p.fallback, p.fallback.response.Content
+}
+
+// inject 等待配置的延迟，并按规则注入错误
+func (p *FakeProvider) inject(ctx context.Context, rule *compiledRule) error {
+	if err := sleep(ctx, rule.response.Latency); err != nil {
+		return err
+	}
+	if rule.response.ErrorStatus == 0 {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if rule.response.FailTimes > 0 && p.fails[rule] >= rule.response.FailTimes {
+		return nil
+	}
+	p.fails[rule]++
+	return &provider.StatusError{StatusCode: rule.response.ErrorStatus, Body: rule.response.ErrorMessage}
+}
+
+// FakeStreamReader 模拟供应商的流式读取器
+type FakeStreamReader struct {
+	ctx       context.Context
+	reasoning []string // 推理过程分片，先于应答内容返回
+	chunks    []string
+	response  Response
+	usage     *bs_llm.LLMUsage
+	sent      int
+	finished  bool
+}
+
+// Read 读取下一个增量，推理过程在应答内容之前返回，最后一个响应携带 usage
+func (r *FakeStreamReader) Read() (provider.StreamResponse, error) {
+	if r.finished {
+		return nil, io.EOF
+	}
+	if r.sent > 0 {
+		if err := sleep(r.ctx, r.response.ChunkInterval); err != nil {
+			return nil, err
+		}
+	}
+	if r.response.FailAfterChunks > 0 && r.sent >= r.response.FailAfterChunks {
+		r.finished = true
+		status := r.response.ErrorStatus
+		if status == 0 {
+			status = http.StatusInternalServerError
+		}
+		return nil, &provider.StatusError{StatusCode: status, Body: r.response.ErrorMessage}
+	}
+	if r.sent < len(r.reasoning) {
+		chunk := r.reasoning[r.sent]
+		r.sent++
+		return provider.NewStreamResponse("", false, nil, "", nil).WithReasoning(chunk), nil
+	}
+	if i := r.sent - len(r.reasoning); i < len(r.chunks) {
+		r.sent++
+		return provider.NewStreamResponse(r.chunks[i], false, nil, "", nil), nil
+	}
+
+	r.finished = true
+	return provider.NewStreamResponse("", true, r.usage, r.response.FinishReason, nil), nil
+}
+
+// Close 关闭流
+func (r *FakeStreamReader) Close() error {
+	r.finished = true
+	return nil
+}
+
+// withDefaults 补全代码中直接构造的脚本未设置的默认值
+func withDefaults(resp Response) Response {
+	if resp.FinishReason == "" {
+		resp.FinishReason = "stop"
+	}
+	if resp.ChunkSize <= 0 {
+		resp.ChunkSize = 4
+	}
+	if resp.ErrorMessage == "" {
+		resp.ErrorMessage = "injected error"
+	}
+	return resp
+}
+
+// usage 返回脚本指定的用量，未指定时按字符数计算，输出token数包含推理过程
+func usage(resp Response, req *provider.LLMRequest, content string) (int64, int64) {
+	promptTokens, completionTokens := resp.PromptTokens, resp.CompletionTokens
+	if promptTokens == 0 {
+		for _, msg := range req.Messages {
+			promptTokens += int64(utf8.RuneCountInString(msg.Content))
+		}
+	}
+	if completionTokens == 0 {
+		completionTokens = int64(utf8.RuneCountInString(content)) + reasoningTokens(resp)
+	}
+	return promptTokens, completionTokens
+}
+
+// reasoningTokens 按推理过程的字符数计算推理token数
+func reasoningTokens(resp Response) int64 {
+	return int64(utf8.RuneCountInString(resp.Reasoning))
+}
+
+// lastUserMessage 返回最后一条用户消息的文本
+func lastUserMessage(messages []*provider.ChatMessage) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			return messages[i].Content
+		}
+	}
+	return ""
+}
+
+// split 按字符数切分文本
+func split(content string, size int) []string {
+	runes := []rune(content)
+	var chunks []string
+	for len(runes) > 0 {
+		n := size
+		if n > len(runes) {
+			n = len(runes)
+		}
+		chunks = append(chunks, string(runes[:n]))
+		runes = runes[n:]
+	}
+	return chunks
+}
+
+// sleep 等待指定毫秒数，context 取消时提前返回
+func sleep(ctx context.Context, millis int) error {
+	if millis <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(time.Duration(millis) * time.Millisecond)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,269 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"jxzy/bs/bs_llm/internal/jsonschema"
+	"jxzy/bs/bs_llm/internal/provider"
+)
+
+func newRequest(sceneCode, content string) *provider.LLMRequest {
+	return &provider.LLMRequest{
+		SceneCode: sceneCode,
+		ModelCode: "fake-model",
+		Messages: []*provider.ChatMessage{
+			{Role: "system", Content: "sys"},
+			{Role: "user", Content: content},
+		},
+	}
+}
+
+func TestFakeProviderMatch(t *testing.T) {
+	p, err := NewFakeProvider("fake", &Script{
+		Rules: []Rule{
+			{SceneCode: "summary", Response: Response{Content: "摘要", PromptTokens: 100, CompletionTokens: 20}},
+			{Pattern: `我叫(?P<name>\S+)`, Response: Response{Content: "你好，${name}"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewFakeProvider failed: %v", err)
+	}
+
+	cases := []struct {
+		scene, input, want string
+		total              int64
+	}{
+		{"summary", "任意内容", "摘要", 120},
+		{"chat", "我叫小明", "你好，小明", 3 + 4 + 5},
+		{"chat", "hello", "hello", 3 + 5 + 5}, // 回显
+	}
+	for _, tc := range cases {
+		resp, err := p.CallLLM(context.Background(), newRequest(tc.scene, tc.input))
+		if err != nil {
+			t.Fatalf("CallLLM failed: %v", err)
+		}
+		if resp.Content != tc.want || resp.TotalTokens != tc.total || resp.FinishReason != "stop" {
+			t.Errorf("%s/%s: unexpected response %+v", tc.scene, tc.input, resp)
+		}
+	}
+}
+
+func TestFakeProviderStream(t *testing.T) {
+	p, _ := NewFakeProvider("fake", &Script{Default: Response{Content: "你好，世界！", ChunkSize: 2}})
+	reader, err := p.StreamLLM(context.Background(), newRequest("chat", "hi"))
+	if err != nil {
+		t.Fatalf("StreamLLM failed: %v", err)
+	}
+	defer reader.Close()
+
+	var deltas []string
+	for {
+		resp, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+		if resp.Finished() {
+			if resp.Usage() == nil || resp.Usage().CompletionTokens != 6 {
+				t.Errorf("Expected usage in final response, got %+v", resp.Usage())
+			}
+			continue
+		}
+		deltas = append(deltas, resp.Delta())
+	}
+	if strings.Join(deltas, "|") != "你好|，世|界！" {
+		t.Errorf("Unexpected chunks: %v", deltas)
+	}
+}
+
+func TestFakeProviderReasoning(t *testing.T) {
+	p, _ := NewFakeProvider("fake", &Script{Default: Response{Content: "42", Reasoning: "先想一想", ChunkSize: 2}})
+	resp, err := p.CallLLM(context.Background(), newRequest("chat", "hi"))
+	if err != nil {
+		t.Fatalf("CallLLM failed: %v", err)
+	}
+	if resp.ReasoningContent != "先想一想" || resp.ReasoningTokens != 4 || resp.CompletionTokens != 6 {
+		t.Errorf("Unexpected reasoning response: %+v", resp)
+	}
+
+	reader, _ := p.StreamLLM(context.Background(), newRequest("chat", "hi"))
+	var reasoning, deltas []string
+	for {
+		resp, err := reader.Read()
+		if err != nil {
+			break
+		}
+		if resp.Finished() {
+			if resp.Usage().ReasoningTokens != 4 {
+				t.Errorf("Expected reasoning tokens in usage, got %+v", resp.Usage())
+			}
+			continue
+		}
+		if resp.ReasoningDelta() != "" {
+			if len(deltas) > 0 {
+				t.Error("Expected reasoning before content")
+			}
+			reasoning = append(reasoning, resp.ReasoningDelta())
+		}
+		if resp.Delta() != "" {
+			deltas = append(deltas, resp.Delta())
+		}
+	}
+	if strings.Join(reasoning, "|") != "先想|一想" || strings.Join(deltas, "|") != "42" {
+		t.Errorf("Unexpected stream: reasoning %v, content %v", reasoning, deltas)
+	}
+}
+
+func TestFakeProviderInjectedFailures(t *testing.T) {
+	p, _ := NewFakeProvider("fake", &Script{Rules: []Rule{
+		{Pattern: "^flaky", Response: Response{Content: "ok", ErrorStatus: http.StatusServiceUnavailable, FailTimes: 2}},
+		{Pattern: "^broken", Response: Response{Content: "abcdefgh", ChunkSize: 2, FailAfterChunks: 2}},
+	}})
+
+	for i := 0; i < 2; i++ {
+		_, err := p.CallLLM(context.Background(), newRequest("chat", "flaky"))
+		var statusErr *provider.StatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable || !provider.IsRetryable(err) {
+			t.Fatalf("Call %d: expected retryable 503, got %v", i, err)
+		}
+	}
+	if resp, err := p.CallLLM(context.Background(), newRequest("chat", "flaky")); err != nil || resp.Content != "ok" {
+		t.Errorf("Expected success after injected failures, got %v, %v", resp, err)
+	}
+
+	reader, err := p.StreamLLM(context.Background(), newRequest("chat", "broken"))
+	if err != nil {
+		t.Fatalf("StreamLLM failed: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := reader.Read(); err != nil {
+			t.Fatalf("Read %d failed: %v", i, err)
+		}
+	}
+	if _, err := reader.Read(); err == nil {
+		t.Error("Expected error after 2 chunks")
+	}
+}
+
+func TestFakeProviderLatency(t *testing.T) {
+	p, _ := NewFakeProvider("fake", &Script{Default: Response{Latency: 1000}})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.CallLLM(ctx, newRequest("chat", "hi")); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context canceled during latency, got %v", err)
+	}
+}
+
+func TestFakeProviderEmbed(t *testing.T) {
+	p, _ := NewFakeProvider("fake", &Script{Rules: []Rule{
+		{SceneCode: "embed", Pattern: "boom", Response: Response{ErrorStatus: 500}},
+	}})
+	resp, err := p.Embed(context.Background(), &provider.EmbeddingRequest{
+		SceneCode: "embed",
+		Texts:     []string{"产品编码ABC", "产品编码ABD", "天气不错"},
+		ModelCode: "fake-embedding",
+	})
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if len(resp.Embeddings) != 3 || len(resp.Embeddings[0]) != DefaultEmbeddingDimensions || resp.TotalTokens != 7+7+4 {
+		t.Fatalf("Unexpected response: %+v", resp)
+	}
+
+	dot := func(a, b []float32) (sum float32) {
+		for i := range a {
+			sum += a[i] * b[i]
+		}
+		return sum
+	}
+	if self := dot(resp.Embeddings[0], resp.Embeddings[0]); self < 0.999 || self > 1.001 {
+		t.Errorf("Expected normalized vector, got norm %f", self)
+	}
+	if dot(resp.Embeddings[0], resp.Embeddings[1]) <= dot(resp.Embeddings[0], resp.Embeddings[2]) {
+		t.Error("Expected similar texts to have higher similarity")
+	}
+
+	again, _ := p.Embed(context.Background(), &provider.EmbeddingRequest{Texts: []string{"产品编码ABC"}, Dimensions: 8})
+	if len(again.Embeddings[0]) != 8 {
+		t.Errorf("Expected 8 dimensions, got %d", len(again.Embeddings[0]))
+	}
+
+	_, err = p.Embed(context.Background(), &provider.EmbeddingRequest{SceneCode: "embed", Texts: []string{"boom"}})
+	var statusErr *provider.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected injected 500, got %v", err)
+	}
+}
+
+func TestLoadScript(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "script.yaml")
+	data := "Rules:\n  - SceneCode: summary\n    Content: 摘要\n    ErrorStatus: 429\n    FailTimes: 1\nDefault:\n  Content: default\n"
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	script, err := LoadScript(path)
+	if err != nil {
+		t.Fatalf("LoadScript failed: %v", err)
+	}
+	if len(script.Rules) != 1 || script.Rules[0].Content != "摘要" || script.Rules[0].ErrorStatus != 429 || script.Rules[0].ChunkSize != 4 {
+		t.Errorf("Unexpected rules: %+v", script.Rules)
+	}
+	if script.Default.Content != "default" || script.Default.FinishReason != "stop" {
+		t.Errorf("Unexpected default: %+v", script.Default)
+	}
+
+	// 示例脚本可以正常加载
+	if _, err := LoadScript("../../../etc/fake.yaml"); err != nil {
+		t.Errorf("Failed to load example script: %v", err)
+	}
+}
+
+func TestExampleScript(t *testing.T) {
+	script, err := LoadScript("../../../etc/fake.yaml")
+	if err != nil {
+		t.Fatalf("Failed to load example script: %v", err)
+	}
+	p, err := NewFakeProvider("fake", script)
+	if err != nil {
+		t.Fatalf("NewFakeProvider failed: %v", err)
+	}
+
+	// 关键句提取的应答需要通过 bll_context 请求中的 key_sentences Schema 校验
+	schema, err := jsonschema.Compile([]byte(`{
+  "type": "object",
+  "properties": {
+    "key_sentences": {"type": "array", "items": {"type": "string"}}
+  },
+  "required": ["key_sentences"]
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := p.CallLLM(context.Background(), newRequest("rag-sentence-extraction", "如何优化数据库性能？"))
+	if err != nil {
+		t.Fatalf("CallLLM failed: %v", err)
+	}
+	if err := schema.ValidateJSON([]byte(resp.Content)); err != nil {
+		t.Errorf("Key sentence response does not match schema: %v", err)
+	}
+
+	for _, scene := range []string{"knowledge_segmentation", "knowledge_segment_summary"} {
+		resp, err := p.CallLLM(context.Background(), newRequest(scene, "测试内容"))
+		if err != nil {
+			t.Fatalf("CallLLM %s failed: %v", scene, err)
+		}
+		if lines := strings.Split(resp.Content, "\n"); len(lines) != 2 {
+			t.Errorf("Expected 2 lines for %s, got %q", scene, resp.Content)
+		}
+	}
+}
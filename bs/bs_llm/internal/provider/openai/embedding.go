@@ -0,0 +1,102 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"jxzy/bs/bs_llm/internal/provider"
+)
+
+const (
+	embeddingsPath = "/embeddings"
+	// maxEmbeddingBatch OpenAI 接口单次请求最多2048条文本
+	maxEmbeddingBatch = 2048
+)
+
+// Embed 调用 {base}/embeddings 接口
+func (p *OpenAIProvider) Embed(ctx context.Context, req *provider.EmbeddingRequest) (*provider.EmbeddingResponse, error) {
+	config := p.resolveConfig(req.Config)
+
+	apiReq := &EmbeddingRequest{
+		Model:      req.ModelCode,
+		Input:      req.Texts,
+		Dimensions: req.Dimensions,
+	}
+	reqBody, err := json.Marshal(apiReq)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request failed: %w", err)
+	}
+
+	endpoint := baseURL(config.APIEndpoint) + embeddingsPath
+	p.logger.Infof("OpenAI-compatible Embedding API Request - Provider: %s, Endpoint: %s, Model: %s, Texts: %d",
+		p.name, endpoint, req.ModelCode, len(req.Texts))
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	setHeaders(httpReq, config)
+
+	resp, err := createHTTPClient(config).Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &provider.StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	var apiResp EmbeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("decode response failed: %w", err)
+	}
+
+	// 按 index 还原顺序
+	embeddings := make([][]float32, len(req.Texts))
+	for _, item := range apiResp.Data {
+		if item.Index < 0 || item.Index >= len(embeddings) {
+			return nil, fmt.Errorf("invalid index %d in response", item.Index)
+		}
+		embeddings[item.Index] = item.Embedding
+	}
+	for i, embedding := range embeddings {
+		if len(embedding) == 0 {
+			return nil, fmt.Errorf("no embedding for text %d in response", i)
+		}
+	}
+
+	result := &provider.EmbeddingResponse{Embeddings: embeddings, ModelCode: req.ModelCode}
+	if apiResp.Usage != nil {
+		result.PromptTokens = apiResp.Usage.PromptTokens
+		result.TotalTokens = apiResp.Usage.TotalTokens
+	}
+	return result, nil
+}
+
+// MaxEmbeddingBatch 单次请求的最大文本数
+func (p *OpenAIProvider) MaxEmbeddingBatch() int {
+	return maxEmbeddingBatch
+}
+
+// 向量接口请求和响应结构体
+type EmbeddingRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int64    `json:"dimensions,omitempty"`
+}
+
+type EmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Model string `json:"model"`
+	Usage *Usage `json:"usage,omitempty"`
+}
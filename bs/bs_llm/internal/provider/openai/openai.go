@@ -0,0 +1,633 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/provider"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const (
+	DefaultBaseURL = "https://api.openai.com/v1"
+	ProviderName   = "openai"
+
+	chatCompletionsPath = "/chat/completions"
+	modelsPath          = "/models"
+)
+
+// OpenAIProvider OpenAI兼容协议供应商实现
+// 适用于任何实现了 /v1/chat/completions 的服务，如 vLLM、Ollama、DeepSeek 等
+type OpenAIProvider struct {
+	name   string
+	config *provider.ProviderConfig
+	logger logx.Logger
+}
+
+// NewOpenAIProvider 创建OpenAI兼容供应商
+// name 为注册到 Manager 中的供应商编码，config 为该供应商的默认配置（用于健康检查及请求未携带配置时）
+func NewOpenAIProvider(name string, config *provider.ProviderConfig) *OpenAIProvider {
+	if name == "" {
+		name = ProviderName
+	}
+	if config == nil {
+		config = &provider.ProviderConfig{}
+	}
+	return &OpenAIProvider{
+		name:   name,
+		config: config,
+		logger: logx.WithContext(context.Background()),
+	}
+}
+
+// Name 供应商名称
+func (p *OpenAIProvider) Name() string {
+	return p.name
+}
+
+// CallLLM 非流式调用
+func (p *OpenAIProvider) CallLLM(ctx context.Context, req *provider.LLMRequest) (*provider.LLMResponse, error) {
+	config := p.resolveConfig(req.Config)
+
+	resp, err := p.doChatCompletion(ctx, req, config, false)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var apiResp ChatCompletionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
+		return nil, fmt.Errorf("decode response failed: %w", err)
+	}
+
+	if len(apiResp.Choices) == 0 {
+		return nil, fmt.Errorf("no choices in response")
+	}
+
+	choice := apiResp.Choices[0]
+	result := &provider.LLMResponse{
+		Content:          choice.Message.Content,
+		ModelCode:        req.ModelCode,
+		FinishReason:     choice.FinishReason,
+		ToolCalls:        toProviderToolCalls(choice.Message.ToolCalls),
+		ReasoningContent: choice.Message.ReasoningContent,
+	}
+	if apiResp.Usage != nil {
+		result.PromptTokens = apiResp.Usage.PromptTokens
+		result.CompletionTokens = apiResp.Usage.CompletionTokens
+		result.TotalTokens = apiResp.Usage.TotalTokens
+		result.ReasoningTokens = apiResp.Usage.reasoningTokens()
+	}
+	return result, nil
+}
+
+// StreamLLM 流式调用
+func (p *OpenAIProvider) StreamLLM(ctx context.Context, req *provider.LLMRequest) (provider.StreamReader, error) {
+	config := p.resolveConfig(req.Config)
+
+	resp, err := p.doChatCompletion(ctx, req, config, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOpenAIStreamReader(resp.Body, req.ModelCode), nil
+}
+
+// HealthCheck 健康检查，请求 {base}/models 验证连通性和鉴权
+func (p *OpenAIProvider) HealthCheck(ctx context.Context) error {
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", baseURL(p.config.APIEndpoint)+modelsPath, nil)
+	if err != nil {
+		return fmt.Errorf("create request failed: %w", err)
+	}
+	setHeaders(httpReq, p.config)
+
+	resp, err := createHTTPClient(p.config).Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("health check failed with status %d: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// doChatCompletion 发送 chat/completions 请求，返回状态码为200的响应
+func (p *OpenAIProvider) doChatCompletion(ctx context.Context, req *provider.LLMRequest, config *provider.ProviderConfig, stream bool) (*http.Response, error) {
+	apiReq := &ChatCompletionRequest{
+		Model:            req.ModelCode,
+		Messages:         convertMessages(req.Messages),
+		Temperature:      req.Temperature,
+		MaxTokens:        req.MaxTokens,
+		TopP:             req.TopP,
+		Stop:             req.Stop,
+		Seed:             req.Seed,
+		PresencePenalty:  req.PresencePenalty,
+		FrequencyPenalty: req.FrequencyPenalty,
+		Stream:           stream,
+		Tools:            convertTools(req.Tools),
+		ToolChoice:       convertToolChoice(req.ToolChoice),
+		ResponseFormat:   convertResponseFormat(req.ResponseFormat),
+	}
+	if stream {
+		// 要求服务端在最后一个分片中返回usage
+		apiReq.StreamOptions = &StreamOptions{IncludeUsage: true}
+	}
+
+	reqBody, err := json.Marshal(apiReq)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request failed: %w", err)
+	}
+
+	endpoint := chatCompletionsEndpoint(config.APIEndpoint)
+	p.logger.Infof("OpenAI-compatible API Request - Provider: %s, Endpoint: %s, Model: %s, Stream: %v",
+		p.name, endpoint, req.ModelCode, stream)
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+
+	// 设置请求头
+	httpReq.Header.Set("Content-Type", "application/json")
+	if stream {
+		httpReq.Header.Set("Accept", "text/event-stream")
+	}
+	setHeaders(httpReq, config)
+
+	resp, err := createHTTPClient(config).Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return nil, &provider.StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	return resp, nil
+}
+
+// resolveConfig 合并请求配置与供应商默认配置，请求配置优先
+func (p *OpenAIProvider) resolveConfig(reqConfig *provider.ProviderConfig) *provider.ProviderConfig {
+	if reqConfig == nil {
+		return p.config
+	}
+
+	merged := *reqConfig
+	if merged.APIEndpoint == "" {
+		merged.APIEndpoint = p.config.APIEndpoint
+	}
+	if merged.APIKey == "" {
+		merged.APIKey = p.config.APIKey
+	}
+	if merged.Timeout <= 0 {
+		merged.Timeout = p.config.Timeout
+	}
+	if len(merged.Headers) == 0 {
+		merged.Headers = p.config.Headers
+	}
+	return &merged
+}
+
+// createHTTPClient 根据配置创建HTTP客户端
+func createHTTPClient(config *provider.ProviderConfig) *http.Client {
+	timeout := 120 * time.Second
+	if config != nil && config.Timeout > 0 {
+		timeout = time.Duration(config.Timeout) * time.Second
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+	}
+}
+
+// setHeaders 设置鉴权及自定义请求头，本地部署的服务（如Ollama）可以不配置API Key
+func setHeaders(httpReq *http.Request, config *provider.ProviderConfig) {
+	if config.APIKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+config.APIKey)
+	}
+	for k, v := range config.Headers {
+		httpReq.Header.Set(k, v)
+	}
+}
+
+// baseURL 规范化基础地址，兼容直接配置完整 chat/completions 地址的情况
+func baseURL(endpoint string) string {
+	endpoint = strings.TrimRight(strings.TrimSpace(endpoint), "/")
+	if endpoint == "" {
+		return DefaultBaseURL
+	}
+	return strings.TrimSuffix(endpoint, chatCompletionsPath)
+}
+
+// chatCompletionsEndpoint 获取 chat/completions 完整地址
+func chatCompletionsEndpoint(endpoint string) string {
+	return baseURL(endpoint) + chatCompletionsPath
+}
+
+// convertMessages 转换消息格式
+func convertMessages(messages []*provider.ChatMessage) []ChatMessage {
+	var result []ChatMessage
+	for _, msg := range messages {
+		message := ChatMessage{
+			Role:       msg.Role,
+			Content:    msg.Content,
+			ToolCallID: msg.ToolCallID,
+			Name:       msg.Name,
+		}
+		if msg.HasMedia() {
+			message.Parts = convertContentParts(msg.Parts)
+		}
+		for _, call := range msg.ToolCalls {
+			message.ToolCalls = append(message.ToolCalls, ToolCall{
+				ID:   call.ID,
+				Type: toolType(call.Type),
+				Function: FunctionCall{
+					Name:      call.Function.Name,
+					Arguments: call.Function.Arguments,
+				},
+			})
+		}
+		result = append(result, message)
+	}
+	return result
+}
+
+// convertContentParts 转换多模态内容片段：图片为 image_url，音频原始数据为 input_audio，音频 URL 为 audio_url
+func convertContentParts(parts []*provider.ContentPart) []ContentPart {
+	var result []ContentPart
+	for _, part := range parts {
+		switch part.Type {
+		case provider.ContentPartText:
+			result = append(result, ContentPart{Type: "text", Text: part.Text})
+		case provider.ContentPartImage:
+			result = append(result, ContentPart{Type: "image_url", ImageURL: &MediaURL{URL: part.URI()}})
+		case provider.ContentPartAudio:
+			if part.URL != "" {
+				result = append(result, ContentPart{Type: "audio_url", AudioURL: &MediaURL{URL: part.URL}})
+				continue
+			}
+			result = append(result, ContentPart{Type: "input_audio", InputAudio: &InputAudio{
+				Data:   base64.StdEncoding.EncodeToString(part.Data),
+				Format: part.Format(),
+			}})
+		}
+	}
+	return result
+}
+
+// convertTools 转换工具定义
+func convertTools(tools []*provider.Tool) []Tool {
+	var result []Tool
+	for _, tool := range tools {
+		if tool.Function == nil {
+			continue
+		}
+		result = append(result, Tool{
+			Type: toolType(tool.Type),
+			Function: FunctionDefinition{
+				Name:        tool.Function.Name,
+				Description: tool.Function.Description,
+				Parameters:  tool.Function.Parameters,
+			},
+		})
+	}
+	return result
+}
+
+// convertToolChoice 转换工具选择策略，auto/none/required 之外的值视为指定的函数名
+func convertToolChoice(toolChoice string) interface{} {
+	switch toolChoice {
+	case "":
+		return nil
+	case "auto", "none", "required":
+		return toolChoice
+	default:
+		return map[string]interface{}{
+			"type":     "function",
+			"function": map[string]string{"name": toolChoice},
+		}
+	}
+}
+
+// convertResponseFormat 转换输出格式，普通文本不传
+func convertResponseFormat(format *provider.ResponseFormat) *ResponseFormat {
+	if format == nil || format.Type == "" || format.Type == provider.ResponseFormatText {
+		return nil
+	}
+	if format.Type != provider.ResponseFormatJSONSchema {
+		return &ResponseFormat{Type: format.Type}
+	}
+	name := format.Name
+	if name == "" {
+		name = "response"
+	}
+	return &ResponseFormat{
+		Type: format.Type,
+		JSONSchema: &JSONSchema{
+			Name:   name,
+			Schema: format.Schema,
+			Strict: format.Strict,
+		},
+	}
+}
+
+// toProviderToolCalls 转换模型返回的工具调用
+func toProviderToolCalls(calls []ToolCall) []*provider.ToolCall {
+	var result []*provider.ToolCall
+	for i, call := range calls {
+		index := i
+		if call.Index != nil {
+			index = *call.Index
+		}
+		result = append(result, &provider.ToolCall{
+			Index: index,
+			ID:    call.ID,
+			Type:  call.Type,
+			Function: provider.FunctionCall{
+				Name:      call.Function.Name,
+				Arguments: call.Function.Arguments,
+			},
+		})
+	}
+	return result
+}
+
+// toolType 工具类型默认为 function
+func toolType(t string) string {
+	if t == "" {
+		return "function"
+	}
+	return t
+}
+
+// OpenAIStreamReader OpenAI兼容协议流式读取器
+type OpenAIStreamReader struct {
+	reader       io.ReadCloser
+	scanner      *bufio.Scanner
+	modelCode    string
+	finished     bool
+	finishReason string
+	usage        *bs_llm.LLMUsage
+}
+
+// NewOpenAIStreamReader 创建OpenAI兼容协议流式读取器
+func NewOpenAIStreamReader(reader io.ReadCloser, modelCode string) *OpenAIStreamReader {
+	scanner := bufio.NewScanner(reader)
+	// 设置扫描器的缓冲区大小，确保能处理长行
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	return &OpenAIStreamReader{
+		reader:    reader,
+		scanner:   scanner,
+		modelCode: modelCode,
+		finished:  false,
+	}
+}
+
+// Read 读取下一个响应
+// finish_reason 所在分片之后服务端可能还会单独下发一个仅包含usage的分片，
+// 因此读到 finish_reason 后会继续读取直到 [DONE] 或流结束，再返回结束响应
+func (r *OpenAIStreamReader) Read() (provider.StreamResponse, error) {
+	if r.finished {
+		return nil, io.EOF
+	}
+
+	for r.scanner.Scan() {
+		line := strings.TrimSpace(r.scanner.Text())
+
+		// SSE格式: data: {...}，跳过空行、注释和其他事件字段
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+
+		// 检查是否是结束标志
+		if data == "[DONE]" {
+			return r.finish(), nil
+		}
+
+		// 解析JSON数据
+		var chunk ChatCompletionChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			continue // 跳过无法解析的行
+		}
+
+		if chunk.Error != nil {
+			r.finished = true
+			return nil, fmt.Errorf("stream error: %s", chunk.Error.Message)
+		}
+
+		if chunk.Usage != nil {
+			r.usage = &bs_llm.LLMUsage{
+				PromptTokens:     chunk.Usage.PromptTokens,
+				CompletionTokens: chunk.Usage.CompletionTokens,
+				TotalTokens:      chunk.Usage.TotalTokens,
+				ReasoningTokens:  chunk.Usage.reasoningTokens(),
+			}
+		}
+
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+
+		choice := chunk.Choices[0]
+		if choice.FinishReason != nil && *choice.FinishReason != "" {
+			r.finishReason = *choice.FinishReason
+		}
+
+		toolCalls := toProviderToolCalls(choice.Delta.ToolCalls)
+		if choice.Delta.Content != "" || choice.Delta.ReasoningContent != "" || len(toolCalls) > 0 {
+			return provider.NewStreamResponse(choice.Delta.Content, false, nil, "", nil).
+				WithToolCalls(toolCalls).WithReasoning(choice.Delta.ReasoningContent), nil
+		}
+	}
+
+	// 检查扫描器错误
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return r.finish(), nil
+}
+
+// finish 生成结束响应
+func (r *OpenAIStreamReader) finish() provider.StreamResponse {
+	r.finished = true
+	finishReason := r.finishReason
+	if finishReason == "" {
+		finishReason = "stop"
+	}
+	return provider.NewStreamResponse("", true, r.usage, finishReason, nil)
+}
+
+// Close 关闭流
+func (r *OpenAIStreamReader) Close() error {
+	r.finished = true
+	return r.reader.Close()
+}
+
+// API请求和响应结构体
+type ChatCompletionRequest struct {
+	Model            string          `json:"model"`
+	Messages         []ChatMessage   `json:"messages"`
+	Temperature      float64         `json:"temperature"`
+	MaxTokens        int64           `json:"max_tokens,omitempty"`
+	TopP             *float64        `json:"top_p,omitempty"`
+	Stop             []string        `json:"stop,omitempty"`
+	Seed             *int64          `json:"seed,omitempty"`
+	PresencePenalty  *float64        `json:"presence_penalty,omitempty"`
+	FrequencyPenalty *float64        `json:"frequency_penalty,omitempty"`
+	Stream           bool            `json:"stream"`
+	StreamOptions    *StreamOptions  `json:"stream_options,omitempty"`
+	Tools            []Tool          `json:"tools,omitempty"`
+	ToolChoice       interface{}     `json:"tool_choice,omitempty"`
+	ResponseFormat   *ResponseFormat `json:"response_format,omitempty"`
+}
+
+type StreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+type ResponseFormat struct {
+	Type       string      `json:"type"`
+	JSONSchema *JSONSchema `json:"json_schema,omitempty"`
+}
+
+type JSONSchema struct {
+	Name   string          `json:"name"`
+	Schema json.RawMessage `json:"schema,omitempty"`
+	Strict bool            `json:"strict,omitempty"`
+}
+
+type ChatMessage struct {
+	Role             string        `json:"role"`
+	Content          string        `json:"content"`
+	ReasoningContent string        `json:"reasoning_content,omitempty"` // 推理模型的思考内容，仅在响应中返回
+	ToolCalls        []ToolCall    `json:"tool_calls,omitempty"`
+	ToolCallID       string        `json:"tool_call_id,omitempty"`
+	Name             string        `json:"name,omitempty"`
+	Parts            []ContentPart `json:"-"` // 多模态内容片段，非空时替代 content 发送
+}
+
+// MarshalJSON 含多模态内容片段时 content 以片段数组发送
+func (m ChatMessage) MarshalJSON() ([]byte, error) {
+	type message ChatMessage
+	if len(m.Parts) == 0 {
+		return json.Marshal(message(m))
+	}
+	return json.Marshal(struct {
+		message
+		Content []ContentPart `json:"content"`
+	}{message: message(m), Content: m.Parts})
+}
+
+type ContentPart struct {
+	Type       string      `json:"type"`
+	Text       string      `json:"text,omitempty"`
+	ImageURL   *MediaURL   `json:"image_url,omitempty"`
+	AudioURL   *MediaURL   `json:"audio_url,omitempty"`
+	InputAudio *InputAudio `json:"input_audio,omitempty"`
+}
+
+type MediaURL struct {
+	URL string `json:"url"`
+}
+
+type InputAudio struct {
+	Data   string `json:"data"`
+	Format string `json:"format"`
+}
+
+type Tool struct {
+	Type     string             `json:"type"`
+	Function FunctionDefinition `json:"function"`
+}
+
+type FunctionDefinition struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+}
+
+type ToolCall struct {
+	Index    *int         `json:"index,omitempty"`
+	ID       string       `json:"id,omitempty"`
+	Type     string       `json:"type,omitempty"`
+	Function FunctionCall `json:"function"`
+}
+
+type FunctionCall struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments"`
+}
+
+type ChatCompletionResponse struct {
+	ID      string   `json:"id"`
+	Object  string   `json:"object"`
+	Created int64    `json:"created"`
+	Model   string   `json:"model"`
+	Choices []Choice `json:"choices"`
+	Usage   *Usage   `json:"usage,omitempty"`
+}
+
+type Choice struct {
+	Index        int         `json:"index"`
+	Message      ChatMessage `json:"message"`
+	FinishReason string      `json:"finish_reason"`
+}
+
+type Usage struct {
+	PromptTokens            int64                    `json:"prompt_tokens"`
+	CompletionTokens        int64                    `json:"completion_tokens"`
+	TotalTokens             int64                    `json:"total_tokens"`
+	CompletionTokensDetails *CompletionTokensDetails `json:"completion_tokens_details,omitempty"`
+}
+
+type CompletionTokensDetails struct {
+	ReasoningTokens int64 `json:"reasoning_tokens"`
+}
+
+// reasoningTokens 推理token数，未返回明细时为0
+func (u *Usage) reasoningTokens() int64 {
+	if u.CompletionTokensDetails == nil {
+		return 0
+	}
+	return u.CompletionTokensDetails.ReasoningTokens
+}
+
+// 流式响应结构体
+type ChatCompletionChunk struct {
+	ID      string        `json:"id"`
+	Object  string        `json:"object"`
+	Created int64         `json:"created"`
+	Model   string        `json:"model"`
+	Choices []ChoiceChunk `json:"choices"`
+	Usage   *Usage        `json:"usage,omitempty"`
+	Error   *APIError     `json:"error,omitempty"`
+}
+
+type ChoiceChunk struct {
+	Index        int         `json:"index"`
+	Delta        ChatMessage `json:"delta"`
+	FinishReason *string     `json:"finish_reason"`
+}
+
+type APIError struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+}
@@ -0,0 +1,237 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"jxzy/bs/bs_llm/internal/provider"
+)
+
+// newTestServer 创建一个模拟 OpenAI 兼容接口的本地服务
+func newTestServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"object":"list","data":[{"id":"test-model"}]}`)
+	})
+	mux.HandleFunc("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var req ChatCompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		if req.Model != "test-model" {
+			t.Errorf("Expected model 'test-model', got '%s'", req.Model)
+		}
+
+		if !req.Stream {
+			fmt.Fprint(w, `{"id":"c1","choices":[{"index":0,"message":{"role":"assistant","content":"Hello"},"finish_reason":"stop"}],"usage":{"prompt_tokens":5,"completion_tokens":1,"total_tokens":6}}`)
+			return
+		}
+
+		if req.StreamOptions == nil || !req.StreamOptions.IncludeUsage {
+			t.Error("Expected stream_options.include_usage to be set")
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"choices\":[{\"index\":0,\"delta\":{\"role\":\"assistant\",\"content\":\"\"},\"finish_reason\":null}]}\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hel\"},\"finish_reason\":null}]}\n\n")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "data:{\"choices\":[{\"index\":0,\"delta\":{\"content\":\"lo\"},\"finish_reason\":\"stop\"}]}\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[],\"usage\":{\"prompt_tokens\":5,\"completion_tokens\":2,\"total_tokens\":7}}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+	mux.HandleFunc("/v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		var req EmbeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		if len(req.Input) != 2 || req.Dimensions != 2 {
+			t.Errorf("Unexpected embedding request: %+v", req)
+		}
+		// 返回顺序与输入不一致时按 index 还原
+		fmt.Fprint(w, `{"object":"list","data":[{"index":1,"embedding":[0,1]},{"index":0,"embedding":[1,0]}],"usage":{"prompt_tokens":4,"total_tokens":4}}`)
+	})
+	return httptest.NewServer(mux)
+}
+
+func newTestRequest(baseURL string) *provider.LLMRequest {
+	return &provider.LLMRequest{
+		Messages:  []*provider.ChatMessage{{Role: "user", Content: "hi"}},
+		ModelCode: "test-model",
+		Config: &provider.ProviderConfig{
+			APIEndpoint: baseURL,
+			APIKey:      "test-key",
+		},
+	}
+}
+
+func TestOpenAICallLLM(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	p := NewOpenAIProvider("vllm", nil)
+	resp, err := p.CallLLM(context.Background(), newTestRequest(server.URL+"/v1"))
+	if err != nil {
+		t.Fatalf("CallLLM failed: %v", err)
+	}
+
+	if resp.Content != "Hello" {
+		t.Errorf("Expected content 'Hello', got '%s'", resp.Content)
+	}
+	if resp.FinishReason != "stop" {
+		t.Errorf("Expected finish_reason 'stop', got '%s'", resp.FinishReason)
+	}
+	if resp.TotalTokens != 6 {
+		t.Errorf("Expected total_tokens 6, got %d", resp.TotalTokens)
+	}
+}
+
+func TestOpenAIEmbed(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	p := NewOpenAIProvider("vllm", &provider.ProviderConfig{APIEndpoint: server.URL + "/v1", APIKey: "test-key"})
+	resp, err := p.Embed(context.Background(), &provider.EmbeddingRequest{
+		Texts:      []string{"a", "b"},
+		ModelCode:  "test-embedding",
+		Dimensions: 2,
+	})
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if fmt.Sprint(resp.Embeddings) != "[[1 0] [0 1]]" || resp.PromptTokens != 4 || resp.TotalTokens != 4 {
+		t.Errorf("Unexpected response: %+v", resp)
+	}
+}
+
+func TestOpenAIStreamLLM(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	// 直接配置完整的 chat/completions 地址也应当可用
+	p := NewOpenAIProvider("vllm", nil)
+	reader, err := p.StreamLLM(context.Background(), newTestRequest(server.URL+"/v1/chat/completions"))
+	if err != nil {
+		t.Fatalf("StreamLLM failed: %v", err)
+	}
+	defer reader.Close()
+
+	var text string
+	var last provider.StreamResponse
+	for {
+		resp, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+		text += resp.Delta()
+		last = resp
+	}
+
+	if text != "Hello" {
+		t.Errorf("Expected text 'Hello', got '%s'", text)
+	}
+	if last == nil || !last.Finished() {
+		t.Fatal("Expected last response to be finished")
+	}
+	if last.FinishReason() != "stop" {
+		t.Errorf("Expected finish_reason 'stop', got '%s'", last.FinishReason())
+	}
+	if last.Usage() == nil || last.Usage().TotalTokens != 7 {
+		t.Errorf("Expected usage with total_tokens 7, got %+v", last.Usage())
+	}
+}
+
+func TestOpenAIStreamReasoning(t *testing.T) {
+	stream := strings.Join([]string{
+		`data: {"choices":[{"index":0,"delta":{"role":"assistant","reasoning_content":"先"},"finish_reason":null}]}`,
+		`data: {"choices":[{"index":0,"delta":{"reasoning_content":"思考"},"finish_reason":null}]}`,
+		`data: {"choices":[{"index":0,"delta":{"content":"答案"},"finish_reason":"stop"}]}`,
+		`data: {"choices":[],"usage":{"prompt_tokens":5,"completion_tokens":6,"total_tokens":11,"completion_tokens_details":{"reasoning_tokens":3}}}`,
+		`data: [DONE]`,
+	}, "\n\n")
+	reader := NewOpenAIStreamReader(io.NopCloser(strings.NewReader(stream)), "deepseek-reasoner")
+
+	var reasoning, text string
+	var last provider.StreamResponse
+	for {
+		resp, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+		reasoning += resp.ReasoningDelta()
+		text += resp.Delta()
+		last = resp
+	}
+
+	if reasoning != "先思考" || text != "答案" {
+		t.Errorf("Unexpected deltas: reasoning %q, text %q", reasoning, text)
+	}
+	if last == nil || last.Usage() == nil || last.Usage().ReasoningTokens != 3 {
+		t.Errorf("Expected reasoning tokens in final usage")
+	}
+}
+
+func TestOpenAIHealthCheck(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	p := NewOpenAIProvider("vllm", &provider.ProviderConfig{APIEndpoint: server.URL + "/v1", APIKey: "test-key"})
+	if err := p.HealthCheck(context.Background()); err != nil {
+		t.Errorf("Expected health check to pass, got %v", err)
+	}
+
+	p = NewOpenAIProvider("vllm", &provider.ProviderConfig{APIEndpoint: server.URL + "/v1", APIKey: "wrong-key"})
+	if err := p.HealthCheck(context.Background()); err == nil {
+		t.Error("Expected health check to fail with wrong api key")
+	}
+}
+
+func TestConvertResponseFormat(t *testing.T) {
+	if convertResponseFormat(nil) != nil || convertResponseFormat(&provider.ResponseFormat{Type: "text"}) != nil {
+		t.Error("Expected text format to be omitted")
+	}
+
+	format := convertResponseFormat(&provider.ResponseFormat{
+		Type:   provider.ResponseFormatJSONSchema,
+		Schema: json.RawMessage(`{"type":"object"}`),
+		Strict: true,
+	})
+	data, _ := json.Marshal(format)
+	expected := `{"type":"json_schema","json_schema":{"name":"response","schema":{"type":"object"},"strict":true}}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+}
+
+func TestConvertMultimodalMessages(t *testing.T) {
+	data, err := json.Marshal(convertMessages([]*provider.ChatMessage{
+		{Role: "user", Content: "hi"},
+		{Role: "user", Content: "describe", Parts: []*provider.ContentPart{
+			{Type: provider.ContentPartText, Text: "describe"},
+			{Type: provider.ContentPartImage, URL: "https://example.com/a.png"},
+			{Type: provider.ContentPartAudio, Data: []byte("wav"), MimeType: "audio/wav"},
+		}},
+	}))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	expected := `[{"role":"user","content":"hi"},{"role":"user","content":[{"type":"text","text":"describe"},` +
+		`{"type":"image_url","image_url":{"url":"https://example.com/a.png"}},{"type":"input_audio","input_audio":{"data":"d2F2","format":"wav"}}]}]`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+}
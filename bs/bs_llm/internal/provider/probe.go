@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Probe 向供应商接口发送不含模型和消息的空请求，验证连通性和鉴权，不消耗 token
+// 鉴权通过时接口返回400参数错误，视为健康；其他非200响应返回 StatusError
+func Probe(ctx context.Context, client *http.Client, endpoint string, config *ProviderConfig) error {
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader("{}"))
+	if err != nil {
+		return fmt.Errorf("create request failed: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+config.APIKey)
+	for k, v := range config.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusBadRequest {
+		return nil
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
+}
@@ -2,14 +2,20 @@ package provider
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"io"
 	"jxzy/bs/bs_llm/bs_llm"
+	"strings"
+	"sync"
 )
 
 // StreamResponse 流式响应接口
 type StreamResponse interface {
 	// Delta 增量内容
 	Delta() string
+	// ReasoningDelta 推理过程增量，推理模型在回答之前输出的思考内容
+	ReasoningDelta() string
 	// Finished 是否结束
 	Finished() bool
 	// Usage token使用情况
@@ -18,6 +24,8 @@ type StreamResponse interface {
 	FinishReason() string
 	// Error 错误信息
 	Error() error
+	// ToolCalls 工具调用增量
+	ToolCalls() []*ToolCall
 }
 
 // Provider LLM供应商接口
@@ -45,29 +53,131 @@ type StreamReader interface {
 
 // LLMRequest 标准化的LLM请求
 type LLMRequest struct {
-	Messages    []*ChatMessage    `json:"messages"`
-	ModelCode   string            `json:"model_code"`
-	Temperature float64           `json:"temperature"`
-	MaxTokens   int64             `json:"max_tokens"`
-	Stream      bool              `json:"stream"`
-	ExtraParams map[string]string `json:"extra_params"`
-	Config      *ProviderConfig   `json:"config"`
+	SceneCode      string            `json:"scene_code"` // 调用方场景编码，供应商接口不使用
+	Messages       []*ChatMessage    `json:"messages"`
+	ModelCode      string            `json:"model_code"`
+	Temperature    float64           `json:"temperature"`
+	MaxTokens      int64             `json:"max_tokens"`
+	Stream         bool              `json:"stream"`
+	ExtraParams    map[string]string `json:"extra_params"`
+	Tools          []*Tool           `json:"tools"`
+	ToolChoice     string            `json:"tool_choice"`
+	ResponseFormat *ResponseFormat   `json:"response_format"`
+	Config         *ProviderConfig   `json:"config"`
+
+	// 可选采样参数，为空时使用供应商接口的默认值，供应商不支持的参数忽略
+	TopP             *float64 `json:"top_p,omitempty"`
+	Stop             []string `json:"stop,omitempty"`
+	Seed             *int64   `json:"seed,omitempty"`
+	PresencePenalty  *float64 `json:"presence_penalty,omitempty"`
+	FrequencyPenalty *float64 `json:"frequency_penalty,omitempty"`
+}
+
+// 输出格式类型
+const (
+	ResponseFormatText       = "text"
+	ResponseFormatJSONObject = "json_object"
+	ResponseFormatJSONSchema = "json_schema"
+)
+
+// ResponseFormat 输出格式，供应商支持时启用 JSON 模式
+type ResponseFormat struct {
+	Type   string          `json:"type"`
+	Name   string          `json:"name,omitempty"`
+	Schema json.RawMessage `json:"schema,omitempty"` // JSON Schema，Type 为 json_schema 时有效
+	Strict bool            `json:"strict,omitempty"`
 }
 
 // ChatMessage 聊天消息
 type ChatMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role       string         `json:"role"`
+	Content    string         `json:"content"` // 文本内容，含多模态片段时为全部文本片段的拼接
+	ToolCalls  []*ToolCall    `json:"tool_calls"`
+	ToolCallID string         `json:"tool_call_id"`
+	Name       string         `json:"name"`
+	Parts      []*ContentPart `json:"parts,omitempty"` // 多模态内容片段，仅含文本时为空
+}
+
+// HasMedia 是否包含图片、音频等非文本片段
+func (m *ChatMessage) HasMedia() bool {
+	for _, part := range m.Parts {
+		if part.Type != ContentPartText {
+			return true
+		}
+	}
+	return false
+}
+
+// 内容片段类型
+const (
+	ContentPartText  = "text"
+	ContentPartImage = "image"
+	ContentPartAudio = "audio"
+)
+
+// ContentPart 多模态内容片段
+type ContentPart struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Data     []byte `json:"data,omitempty"`
+	MimeType string `json:"mime_type,omitempty"`
+}
+
+// URI 图片或音频的地址，原始数据转为 base64 data URI
+func (p *ContentPart) URI() string {
+	if p.URL != "" {
+		return p.URL
+	}
+	return "data:" + p.MimeType + ";base64," + base64.StdEncoding.EncodeToString(p.Data)
+}
+
+// Format 音频格式，取 MIME 类型的子类型，如 audio/wav 为 wav
+func (p *ContentPart) Format() string {
+	if i := strings.LastIndex(p.MimeType, "/"); i >= 0 {
+		return p.MimeType[i+1:]
+	}
+	return p.MimeType
+}
+
+// Tool 工具定义
+type Tool struct {
+	Type     string              `json:"type"`
+	Function *FunctionDefinition `json:"function"`
+}
+
+// FunctionDefinition 函数定义
+type FunctionDefinition struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"` // JSON Schema
+}
+
+// ToolCall 工具调用，流式响应中为增量，按 Index 拼接
+type ToolCall struct {
+	Index    int          `json:"index"`
+	ID       string       `json:"id"`
+	Type     string       `json:"type"`
+	Function FunctionCall `json:"function"`
+}
+
+// FunctionCall 函数调用
+type FunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
 }
 
 // LLMResponse 标准化的LLM响应
 type LLMResponse struct {
-	Content          string `json:"content"`
-	ModelCode        string `json:"model_code"`
-	PromptTokens     int64  `json:"prompt_tokens"`
-	CompletionTokens int64  `json:"completion_tokens"`
-	TotalTokens      int64  `json:"total_tokens"`
-	FinishReason     string `json:"finish_reason"`
+	Content          string      `json:"content"`
+	ModelCode        string      `json:"model_code"`
+	PromptTokens     int64       `json:"prompt_tokens"`
+	CompletionTokens int64       `json:"completion_tokens"`
+	TotalTokens      int64       `json:"total_tokens"`
+	FinishReason     string      `json:"finish_reason"`
+	ToolCalls        []*ToolCall `json:"tool_calls"`
+	ReasoningContent string      `json:"reasoning_content,omitempty"` // 推理模型的思考内容
+	ReasoningTokens  int64       `json:"reasoning_tokens,omitempty"`  // 推理token数，已包含在 CompletionTokens 中
 }
 
 // ProviderConfig 供应商配置
@@ -80,30 +190,65 @@ type ProviderConfig struct {
 	RetryCount    int32             `json:"retry_count"`
 }
 
-// Manager 供应商管理器
+// Manager 供应商管理器（并发安全，支持整体替换以实现配置热加载）
 type Manager struct {
+	mu        sync.RWMutex
 	providers map[string]Provider
+	configs   map[string]*ProviderConfig
 }
 
 // NewManager 创建供应商管理器
 func NewManager() *Manager {
 	return &Manager{
 		providers: make(map[string]Provider),
+		configs:   make(map[string]*ProviderConfig),
 	}
 }
 
 // Register 注册供应商
 func (m *Manager) Register(name string, provider Provider) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.providers[name] = provider
 }
 
+// RegisterWithConfig 注册供应商及其配置
+func (m *Manager) RegisterWithConfig(name string, provider Provider, config *ProviderConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.providers[name] = provider
+	m.configs[name] = config
+}
+
+// Replace 使用新的供应商集合整体替换当前注册的供应商
+func (m *Manager) Replace(providers map[string]Provider, configs map[string]*ProviderConfig) {
+	if configs == nil {
+		configs = make(map[string]*ProviderConfig)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.providers = providers
+	m.configs = configs
+}
+
 // GetProvider 获取供应商
 func (m *Manager) GetProvider(name string) Provider {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.providers[name]
 }
 
+// GetProviderConfig 获取供应商配置，未配置时返回nil
+func (m *Manager) GetProviderConfig(name string) *ProviderConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.configs[name]
+}
+
 // ListProviders 列出所有供应商
 func (m *Manager) ListProviders() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	var names []string
 	for name := range m.providers {
 		names = append(names, name)
@@ -113,11 +258,13 @@ func (m *Manager) ListProviders() []string {
 
 // BaseStreamResponse 基础流式响应实现
 type BaseStreamResponse struct {
-	delta        string
-	finished     bool
-	usage        *bs_llm.LLMUsage
-	finishReason string
-	err          error
+	delta          string
+	reasoningDelta string
+	finished       bool
+	usage          *bs_llm.LLMUsage
+	finishReason   string
+	err            error
+	toolCalls      []*ToolCall
 }
 
 func NewStreamResponse(delta string, finished bool, usage *bs_llm.LLMUsage, finishReason string, err error) *BaseStreamResponse {
@@ -134,6 +281,10 @@ func (r *BaseStreamResponse) Delta() string {
 	return r.delta
 }
 
+func (r *BaseStreamResponse) ReasoningDelta() string {
+	return r.reasoningDelta
+}
+
 func (r *BaseStreamResponse) Finished() bool {
 	return r.finished
 }
@@ -150,6 +301,22 @@ func (r *BaseStreamResponse) Error() error {
 	return r.err
 }
 
+func (r *BaseStreamResponse) ToolCalls() []*ToolCall {
+	return r.toolCalls
+}
+
+// WithToolCalls 设置工具调用增量
+func (r *BaseStreamResponse) WithToolCalls(toolCalls []*ToolCall) *BaseStreamResponse {
+	r.toolCalls = toolCalls
+	return r
+}
+
+// WithReasoning 设置推理过程增量
+func (r *BaseStreamResponse) WithReasoning(reasoningDelta string) *BaseStreamResponse {
+	r.reasoningDelta = reasoningDelta
+	return r
+}
+
 // BaseStreamReader 基础流式读取器
 type BaseStreamReader struct {
 	reader io.ReadCloser
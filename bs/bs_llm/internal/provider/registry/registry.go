@@ -0,0 +1,251 @@
+package registry
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/bs/bs_llm/internal/provider"
+	"jxzy/bs/bs_llm/internal/provider/bailian"
+	"jxzy/bs/bs_llm/internal/provider/doubao"
+	"jxzy/bs/bs_llm/internal/provider/fake"
+	"jxzy/bs/bs_llm/internal/provider/openai"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 供应商协议类型
+const (
+	TypeDoubao  = "doubao"
+	TypeBailian = "bailian"
+	TypeOpenAI  = "openai"
+	TypeFake    = "fake" // 本地模拟供应商，APIEndpoint 为应答脚本文件路径，为空时回显用户消息
+)
+
+// Definition 供应商定义，描述一个供应商编码对应的协议类型及配置
+type Definition struct {
+	Code   string                   `json:"code"`
+	Type   string                   `json:"type"`
+	Config *provider.ProviderConfig `json:"config"`
+}
+
+// Loader 供应商定义加载函数
+type Loader func(ctx context.Context) ([]*Definition, error)
+
+// Registry 供应商注册中心
+// 按顺序从多个 Loader 加载供应商定义（后加载的覆盖同名的先加载的），并写入进程级的 Manager。
+// 通过 Start 定期重新加载，实现无需重启的供应商新增和密钥轮换。
+type Registry struct {
+	manager *provider.Manager
+	loaders []Loader
+	logger  logx.Logger
+
+	mu           sync.Mutex
+	lastLoaded   [][]*Definition              // 每个 Loader 最近一次成功加载的结果
+	fingerprints map[string]string            // 供应商编码 -> 定义指纹
+	instances    map[string]provider.Provider // 供应商编码 -> 供应商实例
+	stopCh       chan struct{}
+}
+
+// NewRegistry 创建供应商注册中心
+func NewRegistry(manager *provider.Manager, loaders ...Loader) *Registry {
+	return &Registry{
+		manager:      manager,
+		loaders:      loaders,
+		logger:       logx.WithContext(context.Background()),
+		lastLoaded:   make([][]*Definition, len(loaders)),
+		fingerprints: make(map[string]string),
+		instances:    make(map[string]provider.Provider),
+	}
+}
+
+// Reload 重新加载所有供应商定义并更新 Manager
+// 某个 Loader 加载失败时沿用其上一次成功加载的结果，避免数据库抖动导致供应商被摘除
+func (r *Registry) Reload(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var loadErr error
+	for i, load := range r.loaders {
+		defs, err := load(ctx)
+		if err != nil {
+			r.logger.Errorf("Failed to load provider definitions from loader %d: %v", i, err)
+			loadErr = err
+			continue
+		}
+		r.lastLoaded[i] = defs
+	}
+
+	merged := make(map[string]*Definition)
+	for _, defs := range r.lastLoaded {
+		for _, def := range defs {
+			merged[def.Code] = def
+		}
+	}
+
+	providers := make(map[string]provider.Provider, len(merged))
+	configs := make(map[string]*provider.ProviderConfig, len(merged))
+	fingerprints := make(map[string]string, len(merged))
+	for code, def := range merged {
+		fp := fingerprint(def)
+		instance, ok := r.instances[code]
+		if !ok || r.fingerprints[code] != fp {
+			created, err := NewProvider(def)
+			if err != nil {
+				r.logger.Errorf("Failed to create provider %s: %v", code, err)
+				continue
+			}
+			instance = created
+			if ok {
+				r.logger.Infof("Provider %s reloaded - Type: %s, Endpoint: %s", code, def.Type, def.Config.APIEndpoint)
+			} else {
+				r.logger.Infof("Provider %s registered - Type: %s, Endpoint: %s", code, def.Type, def.Config.APIEndpoint)
+			}
+		}
+		providers[code] = instance
+		configs[code] = def.Config
+		fingerprints[code] = fp
+	}
+
+	for code := range r.instances {
+		if _, ok := providers[code]; !ok {
+			r.logger.Infof("Provider %s removed", code)
+		}
+	}
+
+	r.instances = providers
+	r.fingerprints = fingerprints
+	r.manager.Replace(providers, configs)
+
+	return loadErr
+}
+
+// Start 启动定期热加载
+func (r *Registry) Start(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	if r.stopCh != nil {
+		r.mu.Unlock()
+		return
+	}
+	stopCh := make(chan struct{})
+	r.stopCh = stopCh
+	r.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				_ = r.Reload(ctx)
+				cancel()
+			case <-stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止定期热加载
+func (r *Registry) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopCh != nil {
+		close(r.stopCh)
+		r.stopCh = nil
+	}
+}
+
+// NewProvider 根据供应商定义创建供应商实例
+func NewProvider(def *Definition) (provider.Provider, error) {
+	switch def.Type {
+	case TypeDoubao:
+		return doubao.NewDoubaoProvider(def.Config), nil
+	case TypeBailian:
+		return bailian.NewBailianProvider(def.Config), nil
+	case TypeOpenAI:
+		return openai.NewOpenAIProvider(def.Code, def.Config), nil
+	case TypeFake:
+		var script *fake.Script
+		if def.Config != nil && def.Config.APIEndpoint != "" {
+			loaded, err := fake.LoadScript(def.Config.APIEndpoint)
+			if err != nil {
+				return nil, err
+			}
+			script = loaded
+		}
+		return fake.NewFakeProvider(def.Code, script)
+	default:
+		return nil, fmt.Errorf("unknown provider type %q", def.Type)
+	}
+}
+
+// StaticLoader 返回固定供应商定义的 Loader，用于配置文件中的供应商
+func StaticLoader(defs []*Definition) Loader {
+	return func(ctx context.Context) ([]*Definition, error) {
+		return defs, nil
+	}
+}
+
+// ModelLoader 返回从 llm_provider 表加载供应商定义的 Loader
+func ModelLoader(providerModel model.LlmProviderModel) Loader {
+	return func(ctx context.Context) ([]*Definition, error) {
+		rows, err := providerModel.FindAllEnabled(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query llm_provider: %w", err)
+		}
+
+		var defs []*Definition
+		for _, row := range rows {
+			headers, err := parseStringMap(row.Headers)
+			if err != nil {
+				return nil, fmt.Errorf("invalid headers for provider %s: %w", row.ProviderCode, err)
+			}
+			defaultParams, err := parseStringMap(row.DefaultParams)
+			if err != nil {
+				return nil, fmt.Errorf("invalid default_params for provider %s: %w", row.ProviderCode, err)
+			}
+
+			defs = append(defs, &Definition{
+				Code: row.ProviderCode,
+				Type: row.ProviderType,
+				Config: &provider.ProviderConfig{
+					APIEndpoint:   row.ApiEndpoint,
+					APIKey:        row.ApiKey,
+					Headers:       headers,
+					DefaultParams: defaultParams,
+					Timeout:       int32(row.Timeout),
+					RetryCount:    int32(row.RetryCount),
+				},
+			})
+		}
+		return defs, nil
+	}
+}
+
+// parseStringMap 解析JSON对象格式的字符串字段
+func parseStringMap(value sql.NullString) (map[string]string, error) {
+	result := make(map[string]string)
+	if !value.Valid || value.String == "" {
+		return result, nil
+	}
+	if err := json.Unmarshal([]byte(value.String), &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// fingerprint 计算供应商定义指纹，定义未变化时复用已有的供应商实例
+func fingerprint(def *Definition) string {
+	data, _ := json.Marshal(def)
+	return string(data)
+}
@@ -0,0 +1,88 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"jxzy/bs/bs_llm/internal/provider"
+)
+
+func TestRegistryReload(t *testing.T) {
+	static := StaticLoader([]*Definition{
+		{Code: "doubao", Type: TypeDoubao, Config: &provider.ProviderConfig{APIKey: "static-key"}},
+		{Code: "vllm", Type: TypeOpenAI, Config: &provider.ProviderConfig{APIEndpoint: "http://127.0.0.1:8000/v1"}},
+	})
+
+	// 模拟 llm_provider 表，覆盖 static 中的 doubao 配置
+	tableDefs := []*Definition{
+		{Code: "doubao", Type: TypeDoubao, Config: &provider.ProviderConfig{APIKey: "table-key"}},
+	}
+	var tableErr error
+	table := func(ctx context.Context) ([]*Definition, error) {
+		return tableDefs, tableErr
+	}
+
+	manager := provider.NewManager()
+	r := NewRegistry(manager, static, table)
+	if err := r.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	if manager.GetProvider("doubao") == nil || manager.GetProvider("vllm") == nil {
+		t.Fatalf("Expected doubao and vllm to be registered, got %v", manager.ListProviders())
+	}
+	if key := manager.GetProviderConfig("doubao").APIKey; key != "table-key" {
+		t.Errorf("Expected table config to override static config, got api key '%s'", key)
+	}
+	vllm := manager.GetProvider("vllm")
+
+	// 新增供应商并轮换密钥，未变化的供应商应复用原实例
+	tableDefs = []*Definition{
+		{Code: "doubao", Type: TypeDoubao, Config: &provider.ProviderConfig{APIKey: "rotated-key"}},
+		{Code: "deepseek", Type: TypeOpenAI, Config: &provider.ProviderConfig{APIEndpoint: "https://api.deepseek.com/v1"}},
+	}
+	if err := r.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if key := manager.GetProviderConfig("doubao").APIKey; key != "rotated-key" {
+		t.Errorf("Expected rotated api key, got '%s'", key)
+	}
+	if manager.GetProvider("deepseek") == nil {
+		t.Error("Expected deepseek to be registered after reload")
+	}
+	if manager.GetProvider("vllm") != vllm {
+		t.Error("Expected unchanged provider instance to be reused")
+	}
+
+	// 表加载失败时沿用上一次的结果
+	tableErr = errors.New("db unavailable")
+	if err := r.Reload(context.Background()); err == nil {
+		t.Error("Expected reload to report loader error")
+	}
+	if manager.GetProvider("deepseek") == nil {
+		t.Error("Expected providers from last successful load to be kept")
+	}
+}
+
+func TestRegistryUnknownType(t *testing.T) {
+	manager := provider.NewManager()
+	r := NewRegistry(manager, StaticLoader([]*Definition{
+		{Code: "unknown", Type: "unknown", Config: &provider.ProviderConfig{}},
+	}))
+	if err := r.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if manager.GetProvider("unknown") != nil {
+		t.Error("Expected provider with unknown type to be skipped")
+	}
+}
+
+func TestNewFakeProvider(t *testing.T) {
+	if _, err := NewProvider(&Definition{Code: "fake", Type: TypeFake, Config: &provider.ProviderConfig{}}); err != nil {
+		t.Errorf("Expected echo fake provider without script, got %v", err)
+	}
+	if _, err := NewProvider(&Definition{Code: "fake", Type: TypeFake, Config: &provider.ProviderConfig{APIEndpoint: "not-exist.yaml"}}); err == nil {
+		t.Error("Expected error for missing script file")
+	}
+}
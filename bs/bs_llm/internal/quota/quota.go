@@ -0,0 +1,217 @@
+package quota
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"jxzy/common/errorx"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 默认与 llm_completion 对账的间隔
+const defaultSyncInterval = time.Minute
+
+// evictInterval 清理过期统计的间隔，窗口为空及非当日的用量记录会被移除
+const evictInterval = time.Minute
+
+// Rule 配额规则，RequestsPerMinute/TokensPerDay 为0表示不限制
+// SceneCode/UserId 为空表示匹配所有场景/用户，多条规则匹配时以最具体的为准（场景+用户 > 场景 > 用户）
+type Rule struct {
+	SceneCode         string
+	UserId            string
+	RequestsPerMinute int
+	TokensPerDay      int64
+}
+
+// UsageLoader 查询用户在场景下自 since 起的实际 token 用量
+type UsageLoader func(ctx context.Context, userId, sceneCode string, since time.Time) (int64, error)
+
+// Limiter 按 user_id + scene_code 统计的请求频率及每日 token 配额
+// 请求频率按滑动窗口在进程内统计；每日 token 用量在进程内累加，并定期以 llm_completion 中的实际用量对账，
+// 使多实例部署时用量能够收敛。
+type Limiter struct {
+	defaultRule  Rule
+	rules        []Rule
+	loadUsage    UsageLoader
+	syncInterval time.Duration
+	now          func() time.Time
+	logger       logx.Logger
+
+	mu        sync.Mutex
+	windows   map[string][]time.Time // key -> 最近一分钟内的请求时间
+	usages    map[string]*usage      // key -> 当日 token 用量
+	evictedAt time.Time              // 最近一次清理过期统计的时间
+}
+
+type usage struct {
+	day      time.Time
+	tokens   int64
+	syncedAt time.Time
+}
+
+// NewLimiter 创建配额限制器，loadUsage 为空时仅使用进程内统计
+func NewLimiter(defaultRule Rule, rules []Rule, loadUsage UsageLoader, syncInterval time.Duration) *Limiter {
+	if syncInterval <= 0 {
+		syncInterval = defaultSyncInterval
+	}
+	return &Limiter{
+		defaultRule:  defaultRule,
+		rules:        rules,
+		loadUsage:    loadUsage,
+		syncInterval: syncInterval,
+		now:          time.Now,
+		logger:       logx.WithContext(context.Background()),
+		windows:      make(map[string][]time.Time),
+		usages:       make(map[string]*usage),
+	}
+}
+
+// Allow 检查并占用一次请求配额
+// 超出配额时返回 ErrCodeLLMQuotaExceeded 业务错误，可直接作为 gRPC 错误返回
+func (l *Limiter) Allow(ctx context.Context, userId, sceneCode string) error {
+	rule := l.match(userId, sceneCode)
+	if rule.RequestsPerMinute <= 0 && rule.TokensPerDay <= 0 {
+		return nil
+	}
+
+	key := sceneCode + "\x00" + userId
+	now := l.now()
+
+	// 对账在锁外进行，避免数据库查询阻塞其它请求
+	var synced *int64
+	if rule.TokensPerDay > 0 && l.needSync(key, now) {
+		if tokens, err := l.loadUsage(ctx, userId, sceneCode, startOfDay(now)); err != nil {
+			l.logger.Errorf("Failed to load token usage - UserId: %s, SceneCode: %s, Error: %v", userId, sceneCode, err)
+		} else {
+			synced = &tokens
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.evict(now)
+
+	if rule.TokensPerDay > 0 {
+		u := l.usage(key, now)
+		if synced != nil {
+			u.tokens = *synced
+			u.syncedAt = now
+		}
+		if u.tokens >= rule.TokensPerDay {
+			return errorx.NewCodeErrorf(errorx.ErrCodeLLMQuotaExceeded,
+				"LLM配额已用尽: 场景 %s 用户 %s 今日已使用 %d tokens，上限 %d", sceneCode, userId, u.tokens, rule.TokensPerDay)
+		}
+	}
+
+	if rule.RequestsPerMinute > 0 {
+		window := prune(l.windows[key], now.Add(-time.Minute))
+		if len(window) >= rule.RequestsPerMinute {
+			l.windows[key] = window
+			return errorx.NewCodeErrorf(errorx.ErrCodeLLMQuotaExceeded,
+				"LLM请求过于频繁: 场景 %s 用户 %s 每分钟最多 %d 次请求", sceneCode, userId, rule.RequestsPerMinute)
+		}
+		l.windows[key] = append(window, now)
+	}
+	return nil
+}
+
+// Record 记录一次调用的实际 token 用量
+func (l *Limiter) Record(userId, sceneCode string, tokens int64) {
+	if tokens <= 0 {
+		return
+	}
+	if rule := l.match(userId, sceneCode); rule.TokensPerDay <= 0 {
+		return
+	}
+
+	now := l.now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.evict(now)
+	l.usage(sceneCode+"\x00"+userId, now).tokens += tokens
+}
+
+// match 查找用户和场景适用的配额规则
+func (l *Limiter) match(userId, sceneCode string) Rule {
+	matched := l.defaultRule
+	best := -1
+	for _, rule := range l.rules {
+		if (rule.SceneCode != "" && rule.SceneCode != sceneCode) || (rule.UserId != "" && rule.UserId != userId) {
+			continue
+		}
+		score := 0
+		if rule.SceneCode != "" {
+			score += 2
+		}
+		if rule.UserId != "" {
+			score++
+		}
+		if score > best {
+			matched = rule
+			best = score
+		}
+	}
+	return matched
+}
+
+// needSync 判断是否需要与 llm_completion 对账
+func (l *Limiter) needSync(key string, now time.Time) bool {
+	if l.loadUsage == nil {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	u, ok := l.usages[key]
+	return !ok || !u.day.Equal(startOfDay(now)) || now.Sub(u.syncedAt) >= l.syncInterval
+}
+
+// usage 获取当日用量记录，跨天时重置，调用方需持有锁
+func (l *Limiter) usage(key string, now time.Time) *usage {
+	day := startOfDay(now)
+	u, ok := l.usages[key]
+	if !ok || !u.day.Equal(day) {
+		u = &usage{day: day}
+		l.usages[key] = u
+	}
+	return u
+}
+
+// evict 每 evictInterval 清理一次不再需要的统计，避免不活跃的用户和场景一直占用内存，调用方需持有锁
+func (l *Limiter) evict(now time.Time) {
+	if now.Sub(l.evictedAt) < evictInterval {
+		return
+	}
+	l.evictedAt = now
+
+	since := now.Add(-time.Minute)
+	for key, window := range l.windows {
+		if window = prune(window, since); len(window) == 0 {
+			delete(l.windows, key)
+		} else {
+			l.windows[key] = window
+		}
+	}
+	day := startOfDay(now)
+	for key, u := range l.usages {
+		if !u.day.Equal(day) {
+			delete(l.usages, key)
+		}
+	}
+}
+
+// prune 移除窗口起点之前的请求时间
+func prune(window []time.Time, since time.Time) []time.Time {
+	i := 0
+	for i < len(window) && !window[i].After(since) {
+		i++
+	}
+	return window[i:]
+}
+
+// startOfDay 返回当天零点
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,131 @@
+package quota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"jxzy/common/errorx"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLimiterRequestsPerMinute(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local)
+	l := NewLimiter(Rule{RequestsPerMinute: 2}, nil, nil, 0)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if err := l.Allow(context.Background(), "u1", "chat"); err != nil {
+			t.Fatalf("Expected request %d to be allowed, got %v", i+1, err)
+		}
+	}
+	err := l.Allow(context.Background(), "u1", "chat")
+	if err == nil {
+		t.Fatal("Expected third request in the same minute to be rejected")
+	}
+
+	// 配额错误应转换为携带业务错误码的 gRPC status
+	st := status.Convert(err)
+	if st.Code() != codes.ResourceExhausted {
+		t.Errorf("Expected ResourceExhausted, got %v", st.Code())
+	}
+	codeErr, ok := errorx.FromError(st.Err())
+	if !ok || codeErr.Code != errorx.ErrCodeLLMQuotaExceeded {
+		t.Errorf("Expected quota code %d in status details, got %+v", errorx.ErrCodeLLMQuotaExceeded, codeErr)
+	}
+
+	// 其他用户不受影响，窗口滑过后恢复
+	if err := l.Allow(context.Background(), "u2", "chat"); err != nil {
+		t.Errorf("Expected other user to be allowed, got %v", err)
+	}
+	now = now.Add(time.Minute + time.Second)
+	if err := l.Allow(context.Background(), "u1", "chat"); err != nil {
+		t.Errorf("Expected request after window to be allowed, got %v", err)
+	}
+}
+
+func TestLimiterTokensPerDay(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.Local)
+	dbTokens := int64(900)
+	var loads int
+	loader := func(ctx context.Context, userId, sceneCode string, since time.Time) (int64, error) {
+		loads++
+		if !since.Equal(startOfDay(now)) {
+			t.Errorf("Expected usage since start of day, got %v", since)
+		}
+		return dbTokens, nil
+	}
+
+	l := NewLimiter(Rule{}, []Rule{{SceneCode: "chat", TokensPerDay: 1000}}, loader, time.Minute)
+	l.now = func() time.Time { return now }
+
+	if err := l.Allow(context.Background(), "u1", "chat"); err != nil {
+		t.Fatalf("Expected request under budget to be allowed, got %v", err)
+	}
+	l.Record("u1", "chat", 150)
+	if err := l.Allow(context.Background(), "u1", "chat"); err == nil {
+		t.Error("Expected request over daily token budget to be rejected")
+	}
+	if loads != 1 {
+		t.Errorf("Expected usage to be loaded once within sync interval, got %d", loads)
+	}
+
+	// 对账后以 llm_completion 中的实际用量为准
+	dbTokens = 500
+	now = now.Add(time.Minute)
+	if err := l.Allow(context.Background(), "u1", "chat"); err != nil {
+		t.Errorf("Expected request to be allowed after reconciliation, got %v", err)
+	}
+
+	// 未匹配规则的场景不限制
+	if err := l.Allow(context.Background(), "u1", "other"); err != nil {
+		t.Errorf("Expected unlimited scene to be allowed, got %v", err)
+	}
+}
+
+func TestLimiterRuleMatch(t *testing.T) {
+	l := NewLimiter(Rule{RequestsPerMinute: 10}, []Rule{
+		{UserId: "u1", RequestsPerMinute: 20},
+		{SceneCode: "chat", RequestsPerMinute: 30},
+		{SceneCode: "chat", UserId: "u1", RequestsPerMinute: 40},
+	}, nil, 0)
+
+	cases := []struct {
+		userId, sceneCode string
+		want              int
+	}{
+		{"u2", "other", 10},
+		{"u1", "other", 20},
+		{"u2", "chat", 30},
+		{"u1", "chat", 40},
+	}
+	for _, c := range cases {
+		if got := l.match(c.userId, c.sceneCode).RequestsPerMinute; got != c.want {
+			t.Errorf("match(%s, %s) = %d, want %d", c.userId, c.sceneCode, got, c.want)
+		}
+	}
+}
+
+func TestLimiterEvict(t *testing.T) {
+	now := time.Date(2024, 1, 1, 23, 59, 0, 0, time.Local)
+	l := NewLimiter(Rule{RequestsPerMinute: 10, TokensPerDay: 1000}, nil, nil, 0)
+	l.now = func() time.Time { return now }
+
+	for _, userId := range []string{"u1", "u2", "u3"} {
+		if err := l.Allow(context.Background(), userId, "chat"); err != nil {
+			t.Fatalf("Expected request to be allowed, got %v", err)
+		}
+		l.Record(userId, "chat", 10)
+	}
+
+	// 跨天且窗口滑过后，其他用户的请求触发清理，只保留活跃用户的统计
+	now = now.Add(2 * time.Minute)
+	if err := l.Allow(context.Background(), "u4", "chat"); err != nil {
+		t.Fatalf("Expected request to be allowed, got %v", err)
+	}
+	if len(l.windows) != 1 || len(l.usages) != 1 {
+		t.Errorf("Expected stale entries to be evicted, windows: %d, usages: %d", len(l.windows), len(l.usages))
+	}
+}
@@ -33,3 +33,15 @@ func (s *BsLlmServiceServer) LLM(ctx context.Context, in *bs_llm.LLMRequest) (*b
 	l := logic.NewLLMLogic(ctx, s.svcCtx)
 	return l.LLM(in)
 }
+
+// 批量LLM调用，按供应商限制并发，按请求顺序流式返回每条结果
+func (s *BsLlmServiceServer) BatchLLM(in *bs_llm.BatchLLMRequest, stream bs_llm.BsLlmService_BatchLLMServer) error {
+	l := logic.NewBatchLLMLogic(stream.Context(), s.svcCtx)
+	return l.BatchLLM(in, stream)
+}
+
+// 文本向量化，一次请求可包含多条文本
+func (s *BsLlmServiceServer) Embed(ctx context.Context, in *bs_llm.EmbedRequest) (*bs_llm.EmbedResponse, error) {
+	l := logic.NewEmbedLogic(ctx, s.svcCtx)
+	return l.Embed(in)
+}
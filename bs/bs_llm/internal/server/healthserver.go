@@ -0,0 +1,58 @@
+package server
+
+import (
+	"sync"
+
+	"jxzy/bs/bs_llm/bs_llm"
+	"jxzy/bs/bs_llm/internal/health"
+	"jxzy/bs/bs_llm/internal/svc"
+
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ProviderHealthServicePrefix 供应商在 gRPC 健康检查中的服务名前缀，如 bs_llm.provider.doubao
+const ProviderHealthServicePrefix = "bs_llm.provider."
+
+// NewHealthServer 创建 gRPC 健康检查服务，状态随供应商健康监控更新
+// 整体服务（空服务名）始终为 SERVING；bs_llm.BsLlmService 在至少一个供应商未熔断时为 SERVING；
+// 每个供应商以 bs_llm.provider.<provider_code> 为服务名，熔断时为 NOT_SERVING。
+func NewHealthServer(svcCtx *svc.ServiceContext) *grpchealth.Server {
+	server := grpchealth.NewServer()
+
+	var mu sync.Mutex
+	reported := make(map[string]bool)
+	update := func(statuses []*health.Status) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		current := make(map[string]bool, len(statuses))
+		anyHealthy := false
+		for _, status := range statuses {
+			current[status.ProviderCode] = true
+			servingStatus := healthpb.HealthCheckResponse_NOT_SERVING
+			if status.Healthy() {
+				servingStatus = healthpb.HealthCheckResponse_SERVING
+				anyHealthy = true
+			}
+			server.SetServingStatus(ProviderHealthServicePrefix+status.ProviderCode, servingStatus)
+		}
+		// 已摘除的供应商
+		for code := range reported {
+			if !current[code] {
+				server.SetServingStatus(ProviderHealthServicePrefix+code, healthpb.HealthCheckResponse_SERVICE_UNKNOWN)
+			}
+		}
+		reported = current
+
+		servingStatus := healthpb.HealthCheckResponse_NOT_SERVING
+		if anyHealthy {
+			servingStatus = healthpb.HealthCheckResponse_SERVING
+		}
+		server.SetServingStatus(bs_llm.BsLlmService_ServiceDesc.ServiceName, servingStatus)
+	}
+
+	update(svcCtx.ProviderHealth.List())
+	svcCtx.ProviderHealth.OnChange(update)
+	return server
+}
@@ -2,10 +2,23 @@ package svc
 
 import (
 	"context"
+	"time"
+
+	"jxzy/bs/bs_llm/internal/cache"
 	"jxzy/bs/bs_llm/internal/config"
+	"jxzy/bs/bs_llm/internal/health"
 	"jxzy/bs/bs_llm/internal/model"
+	"jxzy/bs/bs_llm/internal/pricing"
+	"jxzy/bs/bs_llm/internal/provider"
+	"jxzy/bs/bs_llm/internal/provider/bailian"
+	"jxzy/bs/bs_llm/internal/provider/doubao"
+	"jxzy/bs/bs_llm/internal/provider/registry"
+	"jxzy/bs/bs_llm/internal/quota"
+	"jxzy/bs/bs_llm/internal/tokenizer"
+	commonlogger "jxzy/common/logger"
 
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/zeromicro/go-zero/core/collection"
 	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
 )
@@ -14,12 +27,27 @@ type ServiceContext struct {
 	Config             config.Config
 	LlmSceneModel      model.LlmSceneModel
 	LlmCompletionModel model.LlmCompletionModel
+	LlmProviderModel   model.LlmProviderModel
+	LlmModelPriceModel model.LlmModelPriceModel
+	ProviderManager    *provider.Manager
+	ProviderRegistry   *registry.Registry
+	ProviderHealth     *health.Monitor
+	QuotaLimiter       *quota.Limiter
+	Tokenizers         *tokenizer.Registry
+	Pricing            *pricing.Table
+	Redactor           *commonlogger.Redactor // 日志和问答记录的全局脱敏规则，为空表示不脱敏
+	ResponseCache      cache.Cache
+	LocalResponseCache cache.Cache       // 进程内缓存，脱敏场景的响应不写入 mysql 缓存表
+	SceneCache         *collection.Cache // scene_code -> *model.LlmScene，为空表示不缓存
 	logger             logx.Logger
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
 	var sceneModel model.LlmSceneModel
 	var completionModel model.LlmCompletionModel
+	var providerModel model.LlmProviderModel
+	var responseCacheModel model.LlmResponseCacheModel
+	var priceModel model.LlmModelPriceModel
 
 	logger := logx.WithContext(context.Background())
 
@@ -28,13 +56,192 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		conn := sqlx.NewMysql(c.MySQL.DataSource)
 		sceneModel = model.NewLlmSceneModel(conn)
 		completionModel = model.NewLlmCompletionModel(conn)
+		providerModel = model.NewLlmProviderModel(conn)
+		responseCacheModel = model.NewLlmResponseCacheModel(conn)
+		priceModel = model.NewLlmModelPriceModel(conn)
 		logger.Info("Successfully connected to MySQL")
 	}
 
+	// 初始化进程级供应商管理器：配置文件中的供应商 + llm_provider表中的供应商
+	loaders := []registry.Loader{registry.StaticLoader(staticProviderDefinitions(c))}
+	if providerModel != nil {
+		loaders = append(loaders, registry.ModelLoader(providerModel))
+	}
+	manager := provider.NewManager()
+	providerRegistry := registry.NewRegistry(manager, loaders...)
+	if err := providerRegistry.Reload(context.Background()); err != nil {
+		logger.Errorf("Failed to load providers, using available definitions: %v", err)
+	}
+	logger.Infof("Providers loaded: %v", manager.ListProviders())
+
+	// 初始化供应商健康监控，按真实调用的错误率熔断
+	providerHealth := health.NewMonitor(manager, health.Options{
+		Window:             time.Duration(c.ProviderHealth.Window) * time.Second,
+		MinRequests:        c.ProviderHealth.MinRequests,
+		ErrorRateThreshold: c.ProviderHealth.ErrorRateThreshold,
+		OpenDuration:       time.Duration(c.ProviderHealth.OpenDuration) * time.Second,
+		CheckTimeout:       time.Duration(c.ProviderHealth.CheckTimeout) * time.Second,
+	})
+
+	// 初始化调用配额，每日token用量以 llm_completion 表中的实际用量对账
+	var loadUsage quota.UsageLoader
+	if completionModel != nil {
+		loadUsage = completionModel.SumTokensSince
+	}
+	quotaLimiter := quota.NewLimiter(quotaDefaultRule(c.Quota), quotaRules(c.Quota), loadUsage,
+		time.Duration(c.Quota.SyncInterval)*time.Second)
+
+	// 加载分词器词表，加载失败的分词器跳过，对应模型按字符数估算
+	tokenizers := tokenizer.NewRegistry(nil)
+	for _, tc := range c.Tokenizers {
+		bpe, err := tokenizer.LoadBPE(tc.Name, tc.VocabFile, tc.Pattern)
+		if err != nil {
+			logger.Errorf("Failed to load tokenizer %s from %s: %v", tc.Name, tc.VocabFile, err)
+			continue
+		}
+		tokenizers.Register(bpe, tc.ModelCodes...)
+		logger.Infof("Tokenizer %s loaded for models: %v", tc.Name, tc.ModelCodes)
+	}
+
+	// 加载模型价格，用于计算每次调用的费用
+	var priceLoader pricing.Loader
+	if priceModel != nil {
+		priceLoader = pricing.ModelLoader(priceModel)
+	}
+	priceTable := pricing.NewTable(priceLoader)
+	if err := priceTable.Reload(context.Background()); err != nil {
+		logger.Errorf("Failed to load model prices, completion cost will be 0: %v", err)
+	}
+
+	// 初始化响应缓存
+	localResponseCache := cache.NewLRU(c.ResponseCache.Capacity)
+	var responseCache cache.Cache = localResponseCache
+	if c.ResponseCache.Backend == cache.BackendMySQL {
+		if responseCacheModel != nil {
+			responseCache = cache.NewMySQL(responseCacheModel)
+		} else {
+			logger.Error("Response cache backend mysql requires MySQL configuration, falling back to memory")
+		}
+	}
+
+	// 初始化场景配置缓存，场景管理接口修改场景后主动失效
+	var sceneCache *collection.Cache
+	if c.SceneCacheExpire > 0 {
+		var err error
+		sceneCache, err = collection.NewCache(time.Duration(c.SceneCacheExpire)*time.Second, collection.WithName("llm_scene"))
+		if err != nil {
+			logger.Errorf("Failed to create scene cache, scene config will not be cached: %v", err)
+		}
+	}
+
 	return &ServiceContext{
 		Config:             c,
 		LlmSceneModel:      sceneModel,
 		LlmCompletionModel: completionModel,
+		LlmProviderModel:   providerModel,
+		LlmModelPriceModel: priceModel,
+		ProviderManager:    manager,
+		ProviderRegistry:   providerRegistry,
+		ProviderHealth:     providerHealth,
+		QuotaLimiter:       quotaLimiter,
+		Tokenizers:         tokenizers,
+		Pricing:            priceTable,
+		Redactor:           commonlogger.MustNewRedactor(c.Redact),
+		ResponseCache:      responseCache,
+		LocalResponseCache: localResponseCache,
+		SceneCache:         sceneCache,
 		logger:             logger,
 	}
 }
+
+// InvalidateScene 使场景配置缓存失效
+func (s *ServiceContext) InvalidateScene(sceneCode string) {
+	if s.SceneCache != nil {
+		s.SceneCache.Del(sceneCode)
+	}
+}
+
+// staticProviderDefinitions 从配置文件构建供应商定义
+// 兼容旧的 DoubaoAPIKey/BailianAPIKey/OpenAICompatible 配置，Providers 中的同名配置会覆盖它们
+func staticProviderDefinitions(c config.Config) []*registry.Definition {
+	defs := []*registry.Definition{
+		{
+			Code: "doubao",
+			Type: registry.TypeDoubao,
+			Config: &provider.ProviderConfig{
+				APIEndpoint: doubao.DefaultAPIEndpoint,
+				APIKey:      c.DoubaoAPIKey,
+				Headers:     make(map[string]string),
+				Timeout:     30,
+				RetryCount:  3,
+			},
+		},
+		{
+			Code: "bailian",
+			Type: registry.TypeBailian,
+			Config: &provider.ProviderConfig{
+				APIEndpoint: bailian.DefaultAPIEndpoint,
+				APIKey:      c.BailianAPIKey,
+				Headers:     make(map[string]string),
+				Timeout:     120,
+				RetryCount:  3,
+			},
+		},
+	}
+
+	for _, p := range c.OpenAICompatible {
+		defs = append(defs, &registry.Definition{
+			Code: p.ProviderCode,
+			Type: registry.TypeOpenAI,
+			Config: &provider.ProviderConfig{
+				APIEndpoint: p.BaseURL,
+				APIKey:      p.APIKey,
+				Headers:     make(map[string]string),
+				Timeout:     p.Timeout,
+				RetryCount:  3,
+			},
+		})
+	}
+
+	for _, p := range c.Providers {
+		headers := p.Headers
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+		defs = append(defs, &registry.Definition{
+			Code: p.ProviderCode,
+			Type: p.ProviderType,
+			Config: &provider.ProviderConfig{
+				APIEndpoint:   p.APIEndpoint,
+				APIKey:        p.APIKey,
+				Headers:       headers,
+				DefaultParams: p.DefaultParams,
+				Timeout:       p.Timeout,
+				RetryCount:    p.RetryCount,
+			},
+		})
+	}
+	return defs
+}
+
+// quotaDefaultRule 从配置文件构建默认配额规则
+func quotaDefaultRule(c config.QuotaConf) quota.Rule {
+	return quota.Rule{
+		RequestsPerMinute: c.RequestsPerMinute,
+		TokensPerDay:      c.TokensPerDay,
+	}
+}
+
+// quotaRules 从配置文件构建配额覆盖规则
+func quotaRules(c config.QuotaConf) []quota.Rule {
+	rules := make([]quota.Rule, 0, len(c.Rules))
+	for _, r := range c.Rules {
+		rules = append(rules, quota.Rule{
+			SceneCode:         r.SceneCode,
+			UserId:            r.UserId,
+			RequestsPerMinute: r.RequestsPerMinute,
+			TokensPerDay:      r.TokensPerDay,
+		})
+	}
+	return rules
+}
@@ -0,0 +1,296 @@
+package tokenizer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 预分词规则
+const (
+	PatternCL100K = "cl100k" // OpenAI cl100k_base/o200k 系列，数字最多3位一组
+	PatternQwen   = "qwen"   // 通义千问，数字逐位切分
+)
+
+// BPE 字节级 BPE 分词器，兼容 tiktoken 格式的词表文件
+type BPE struct {
+	name      string
+	ranks     map[string]int
+	decoder   map[int][]byte
+	maxDigits int
+}
+
+// LoadBPE 从 tiktoken 格式的词表文件加载 BPE 分词器
+// 词表文件每行为 "base64编码的token 排名"，如 cl100k_base.tiktoken、qwen.tiktoken
+func LoadBPE(name, vocabFile, pattern string) (*BPE, error) {
+	f, err := os.Open(vocabFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open vocab file: %w", err)
+	}
+	defer f.Close()
+
+	ranks := make(map[string]int)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		fields := bytes.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid vocab line %d in %s", lineNo, vocabFile)
+		}
+		token, err := base64.StdEncoding.DecodeString(string(fields[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid token at line %d in %s: %w", lineNo, vocabFile, err)
+		}
+		rank, err := strconv.Atoi(string(fields[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid rank at line %d in %s: %w", lineNo, vocabFile, err)
+		}
+		ranks[string(token)] = rank
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read vocab file: %w", err)
+	}
+
+	return NewBPE(name, ranks, pattern)
+}
+
+// NewBPE 使用 token -> 排名 映射创建 BPE 分词器，词表必须包含全部256个单字节 token
+func NewBPE(name string, ranks map[string]int, pattern string) (*BPE, error) {
+	var maxDigits int
+	switch pattern {
+	case "", PatternCL100K:
+		maxDigits = 3
+	case PatternQwen:
+		maxDigits = 1
+	default:
+		return nil, fmt.Errorf("unknown tokenizer pattern %q", pattern)
+	}
+
+	decoder := make(map[int][]byte, len(ranks))
+	for token, rank := range ranks {
+		decoder[rank] = []byte(token)
+	}
+	for b := 0; b < 256; b++ {
+		if _, ok := ranks[string([]byte{byte(b)})]; !ok {
+			return nil, fmt.Errorf("vocab of tokenizer %s is missing byte token 0x%02x", name, b)
+		}
+	}
+
+	return &BPE{
+		name:      name,
+		ranks:     ranks,
+		decoder:   decoder,
+		maxDigits: maxDigits,
+	}, nil
+}
+
+func (t *BPE) Name() string {
+	return t.name
+}
+
+// Count 计算文本的 token 数量
+func (t *BPE) Count(text string) int {
+	n := 0
+	for _, piece := range t.split(text) {
+		if _, ok := t.ranks[piece]; ok {
+			n++
+			continue
+		}
+		n += len(t.merge([]byte(piece)))
+	}
+	return n
+}
+
+// Encode 将文本编码为 token 排名序列
+func (t *BPE) Encode(text string) []int {
+	var tokens []int
+	for _, piece := range t.split(text) {
+		if rank, ok := t.ranks[piece]; ok {
+			tokens = append(tokens, rank)
+			continue
+		}
+		for _, part := range t.merge([]byte(piece)) {
+			tokens = append(tokens, t.ranks[string(part)])
+		}
+	}
+	return tokens
+}
+
+// Decode 将 token 排名序列解码为文本
+func (t *BPE) Decode(tokens []int) string {
+	var buf bytes.Buffer
+	for _, token := range tokens {
+		buf.Write(t.decoder[token])
+	}
+	return buf.String()
+}
+
+// merge 对预分词片段做字节对合并，每次合并排名最小（最常见）的相邻对
+func (t *BPE) merge(piece []byte) [][]byte {
+	// bounds[i] 为第 i 个片段的起始位置，最后一个元素为片段总长度
+	bounds := make([]int, len(piece)+1)
+	for i := range bounds {
+		bounds[i] = i
+	}
+
+	for len(bounds) > 2 {
+		minRank, minIdx := math.MaxInt, -1
+		for i := 0; i < len(bounds)-2; i++ {
+			if rank, ok := t.ranks[string(piece[bounds[i]:bounds[i+2]])]; ok && rank < minRank {
+				minRank, minIdx = rank, i
+			}
+		}
+		if minIdx < 0 {
+			break
+		}
+		bounds = append(bounds[:minIdx+1], bounds[minIdx+2:]...)
+	}
+
+	parts := make([][]byte, len(bounds)-1)
+	for i := range parts {
+		parts[i] = piece[bounds[i]:bounds[i+1]]
+	}
+	return parts
+}
+
+// split 按 tiktoken 的预分词规则切分文本，依次尝试：
+// 英文缩写 | 可选的一个非字母数字字符 + 字母串 | 数字组 | 可选空格 + 符号串 + 换行 | 含换行的空白 | 空白
+func (t *BPE) split(text string) []string {
+	var pieces []string
+	for i := 0; i < len(text); {
+		n := t.matchPiece(text[i:])
+		pieces = append(pieces, text[i:i+n])
+		i += n
+	}
+	return pieces
+}
+
+// matchPiece 返回文本开头第一个预分词片段的字节长度
+func (t *BPE) matchPiece(s string) int {
+	r0, w0 := utf8.DecodeRuneInString(s)
+
+	// 's|'t|'re|'ve|'m|'ll|'d（不区分大小写）
+	if r0 == '\'' && len(s) > 1 {
+		if len(s) > 2 {
+			switch lower(s[1:3]) {
+			case "re", "ve", "ll":
+				return 3
+			}
+		}
+		switch lower(s[1:2]) {
+		case "s", "t", "m", "d":
+			return 2
+		}
+	}
+
+	// [^\r\n\p{L}\p{N}]?\p{L}+
+	if isLetter(r0) {
+		return w0 + spanLetters(s[w0:])
+	}
+	if r0 != '\r' && r0 != '\n' && !isNumber(r0) {
+		if n := spanLetters(s[w0:]); n > 0 {
+			return w0 + n
+		}
+	}
+
+	// \p{N}{1,maxDigits}
+	if isNumber(r0) {
+		n := 0
+		for digits := 0; digits < t.maxDigits && n < len(s); digits++ {
+			r, w := utf8.DecodeRuneInString(s[n:])
+			if !isNumber(r) {
+				break
+			}
+			n += w
+		}
+		return n
+	}
+
+	// ' '?[^\s\p{L}\p{N}]+[\r\n]*
+	start := 0
+	if r0 == ' ' {
+		start = 1
+	}
+	n := start
+	for n < len(s) {
+		r, w := utf8.DecodeRuneInString(s[n:])
+		if unicode.IsSpace(r) || isLetter(r) || isNumber(r) {
+			break
+		}
+		n += w
+	}
+	if n > start {
+		for n < len(s) && (s[n] == '\r' || s[n] == '\n') {
+			n++
+		}
+		return n
+	}
+
+	// 以下均为空白：\s*[\r\n]+ | \s+(?!\S) | \s+
+	space, lastNewline := 0, -1
+	lastWidth := 0
+	for space < len(s) {
+		r, w := utf8.DecodeRuneInString(s[space:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		if r == '\r' || r == '\n' {
+			lastNewline = space
+		}
+		space += w
+		lastWidth = w
+	}
+	if space == 0 {
+		// 无法识别的字节（如非法 UTF-8），单独成段
+		return w0
+	}
+	if lastNewline >= 0 {
+		return lastNewline + 1
+	}
+	if space == len(s) || space == lastWidth {
+		return space
+	}
+	// 空白后紧跟非空白字符时，最后一个空白留给后续片段
+	return space - lastWidth
+}
+
+// spanLetters 返回开头连续字母的字节长度
+func spanLetters(s string) int {
+	n := 0
+	for n < len(s) {
+		r, w := utf8.DecodeRuneInString(s[n:])
+		if !isLetter(r) {
+			break
+		}
+		n += w
+	}
+	return n
+}
+
+func isLetter(r rune) bool {
+	return r != utf8.RuneError && unicode.IsLetter(r)
+}
+
+func isNumber(r rune) bool {
+	return unicode.IsNumber(r)
+}
+
+func lower(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return string(b)
+}
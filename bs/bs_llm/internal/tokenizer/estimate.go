@@ -0,0 +1,35 @@
+package tokenizer
+
+// Estimator 基于字符数的 token 估算，用于未配置词表的模型
+// 中文约 1.5 个字符 = 1 个 token，其它字符约 4 个字符 = 1 个 token
+type Estimator struct{}
+
+// NewEstimator 创建字符数估算分词器
+func NewEstimator() *Estimator {
+	return &Estimator{}
+}
+
+func (e *Estimator) Name() string {
+	return "estimate"
+}
+
+// Count 估算文本的 token 数量，非空文本至少返回 1
+func (e *Estimator) Count(text string) int {
+	if text == "" {
+		return 0
+	}
+
+	chineseChars := 0
+	for _, r := range text {
+		if r >= 0x4e00 && r <= 0x9fff {
+			chineseChars++
+		}
+	}
+	englishChars := len(text) - chineseChars
+
+	total := int(float64(chineseChars)/1.5) + int(float64(englishChars)/4.0)
+	if total < 1 {
+		total = 1
+	}
+	return total
+}
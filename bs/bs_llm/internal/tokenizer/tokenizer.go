@@ -0,0 +1,166 @@
+package tokenizer
+
+import (
+	"strings"
+	"sync"
+
+	"jxzy/bs/bs_llm/internal/provider"
+)
+
+// 消息格式开销（参考 OpenAI chat 格式）：每条消息的角色/分隔符，带 name 的额外开销，回复引导
+const (
+	tokensPerMessage = 3
+	tokensPerName    = 1
+	tokensPerReply   = 3
+)
+
+// tokensPerMedia 图片、音频片段无法按文本分词，按固定值估算，供应商返回 usage 时以实际用量为准
+const tokensPerMedia = 256
+
+// Tokenizer 分词器
+type Tokenizer interface {
+	// Name 分词器名称
+	Name() string
+	// Count 计算文本的 token 数量
+	Count(text string) int
+}
+
+// Registry 按 model_code 选择分词器
+// 模型编码精确匹配优先，其次为以 * 结尾的最长前缀匹配，均未匹配时使用默认分词器
+type Registry struct {
+	mu       sync.RWMutex
+	exact    map[string]Tokenizer
+	prefixes map[string]Tokenizer
+	fallback Tokenizer
+}
+
+// NewRegistry 创建分词器注册中心，fallback 为空时使用字符数估算
+func NewRegistry(fallback Tokenizer) *Registry {
+	if fallback == nil {
+		fallback = NewEstimator()
+	}
+	return &Registry{
+		exact:    make(map[string]Tokenizer),
+		prefixes: make(map[string]Tokenizer),
+		fallback: fallback,
+	}
+}
+
+// Register 为模型编码注册分词器，模型编码以 * 结尾时按前缀匹配
+func (r *Registry) Register(t Tokenizer, modelCodes ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, code := range modelCodes {
+		if strings.HasSuffix(code, "*") {
+			r.prefixes[strings.TrimSuffix(code, "*")] = t
+		} else {
+			r.exact[code] = t
+		}
+	}
+}
+
+// ForModel 获取模型编码对应的分词器
+func (r *Registry) ForModel(modelCode string) Tokenizer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if t, ok := r.exact[modelCode]; ok {
+		return t
+	}
+	var matched Tokenizer
+	longest := -1
+	for prefix, t := range r.prefixes {
+		if strings.HasPrefix(modelCode, prefix) && len(prefix) > longest {
+			matched = t
+			longest = len(prefix)
+		}
+	}
+	if matched != nil {
+		return matched
+	}
+	return r.fallback
+}
+
+// CountMessages 计算消息列表发送给模型时的 token 数量，包括消息格式开销和回复引导
+func CountMessages(t Tokenizer, messages []*provider.ChatMessage) int {
+	if len(messages) == 0 {
+		return 0
+	}
+	total := tokensPerReply
+	for _, msg := range messages {
+		total += countMessage(t, msg)
+	}
+	return total
+}
+
+// TruncateMessages 截断消息列表以适应上下文窗口
+// 保留全部 system 消息，从最早的非 system 消息开始丢弃，直到总 token 数不超过 maxTokens；
+// 丢弃后开头失去对应 tool_calls 的 tool 消息一并丢弃。maxTokens <= 0 时不截断。
+// 返回截断后的消息列表及其 token 数量，仅保留 system 消息仍超出时返回的消息列表可能超过 maxTokens。
+func TruncateMessages(t Tokenizer, messages []*provider.ChatMessage, maxTokens int) ([]*provider.ChatMessage, int) {
+	total := CountMessages(t, messages)
+	if maxTokens <= 0 || total <= maxTokens {
+		return messages, total
+	}
+
+	counts := make([]int, len(messages))
+	keep := make([]bool, len(messages))
+	budget := maxTokens - tokensPerReply
+	for i, msg := range messages {
+		counts[i] = countMessage(t, msg)
+		if msg.Role == "system" {
+			keep[i] = true
+			budget -= counts[i]
+		}
+	}
+
+	// 从最新的消息向前保留
+	for i := len(messages) - 1; i >= 0; i-- {
+		if keep[i] {
+			continue
+		}
+		if counts[i] > budget {
+			break
+		}
+		keep[i] = true
+		budget -= counts[i]
+	}
+
+	result := make([]*provider.ChatMessage, 0, len(messages))
+	total = tokensPerReply
+	leading := true
+	for i, msg := range messages {
+		if !keep[i] {
+			continue
+		}
+		if msg.Role != "system" {
+			if leading && msg.Role == "tool" {
+				continue
+			}
+			leading = false
+		}
+		result = append(result, msg)
+		total += counts[i]
+	}
+	return result, total
+}
+
+// countMessage 计算单条消息的 token 数量
+func countMessage(t Tokenizer, msg *provider.ChatMessage) int {
+	n := tokensPerMessage + t.Count(msg.Role) + t.Count(msg.Content)
+	if msg.Name != "" {
+		n += tokensPerName + t.Count(msg.Name)
+	}
+	if msg.ToolCallID != "" {
+		n += t.Count(msg.ToolCallID)
+	}
+	for _, call := range msg.ToolCalls {
+		n += t.Count(call.ID) + t.Count(call.Function.Name) + t.Count(call.Function.Arguments)
+	}
+	for _, part := range msg.Parts {
+		if part.Type != provider.ContentPartText {
+			n += tokensPerMedia
+		}
+	}
+	return n
+}
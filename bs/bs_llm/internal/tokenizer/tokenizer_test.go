@@ -0,0 +1,134 @@
+package tokenizer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"jxzy/bs/bs_llm/internal/provider"
+)
+
+// writeTestVocab 写入一个包含全部单字节 token 及少量合并规则的 tiktoken 格式词表
+func writeTestVocab(t *testing.T, merges ...string) string {
+	var sb strings.Builder
+	rank := 0
+	for b := 0; b < 256; b++ {
+		fmt.Fprintf(&sb, "%s %d\n", base64.StdEncoding.EncodeToString([]byte{byte(b)}), rank)
+		rank++
+	}
+	for _, m := range merges {
+		fmt.Fprintf(&sb, "%s %d\n", base64.StdEncoding.EncodeToString([]byte(m)), rank)
+		rank++
+	}
+
+	path := filepath.Join(t.TempDir(), "test.tiktoken")
+	if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+		t.Fatalf("Failed to write vocab: %v", err)
+	}
+	return path
+}
+
+func TestBPESplit(t *testing.T) {
+	bpe, err := LoadBPE("test", writeTestVocab(t), PatternCL100K)
+	if err != nil {
+		t.Fatalf("LoadBPE failed: %v", err)
+	}
+
+	got := bpe.split("Hello world  1234567\n\nit's 你好!!\n")
+	want := []string{"Hello", " world", " ", " ", "123", "456", "7", "\n\n", "it", "'s", " 你好", "!!\n"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("split mismatch\n got: %q\nwant: %q", got, want)
+	}
+
+	qwen, err := LoadBPE("qwen", writeTestVocab(t), PatternQwen)
+	if err != nil {
+		t.Fatalf("LoadBPE failed: %v", err)
+	}
+	if got := qwen.split("123"); len(got) != 3 {
+		t.Errorf("Expected qwen pattern to split digits individually, got %q", got)
+	}
+}
+
+func TestBPEEncode(t *testing.T) {
+	bpe, err := LoadBPE("test", writeTestVocab(t, "he", "ll", "hell", "hello", " w", "or"), PatternCL100K)
+	if err != nil {
+		t.Fatalf("LoadBPE failed: %v", err)
+	}
+
+	tokens := bpe.Encode("hello world")
+	// "hello" 整体命中词表；" world" 合并为 " w" + "or" + "l" + "d"
+	if len(tokens) != 5 || bpe.Count("hello world") != 5 {
+		t.Errorf("Expected 5 tokens, got %v", tokens)
+	}
+	if tokens[0] != 256+3 {
+		t.Errorf("Expected 'hello' to be a single token, got %v", tokens)
+	}
+	if text := bpe.Decode(tokens); text != "hello world" {
+		t.Errorf("Expected round trip 'hello world', got '%s'", text)
+	}
+}
+
+func TestLoadBPEInvalidVocab(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.tiktoken")
+	os.WriteFile(path, []byte("aGk= 0\n"), 0o644)
+	if _, err := LoadBPE("bad", path, PatternCL100K); err == nil {
+		t.Error("Expected error for vocab missing byte tokens")
+	}
+	if _, err := LoadBPE("bad", writeTestVocab(t), "unknown"); err == nil {
+		t.Error("Expected error for unknown pattern")
+	}
+}
+
+func TestRegistryForModel(t *testing.T) {
+	cl100k, _ := LoadBPE("cl100k", writeTestVocab(t), PatternCL100K)
+	qwen, _ := LoadBPE("qwen", writeTestVocab(t), PatternQwen)
+
+	r := NewRegistry(nil)
+	r.Register(cl100k, "gpt-4o", "deepseek-*")
+	r.Register(qwen, "qwen-*", "qwen-vl-*")
+
+	cases := map[string]string{
+		"gpt-4o":        "cl100k",
+		"deepseek-chat": "cl100k",
+		"qwen-plus":     "qwen",
+		"doubao-pro":    "estimate",
+	}
+	for model, want := range cases {
+		if got := r.ForModel(model).Name(); got != want {
+			t.Errorf("ForModel(%s) = %s, want %s", model, got, want)
+		}
+	}
+}
+
+func TestCountAndTruncateMessages(t *testing.T) {
+	bpe, _ := LoadBPE("test", writeTestVocab(t), PatternCL100K)
+	messages := []*provider.ChatMessage{
+		{Role: "system", Content: "be brief"},
+		{Role: "user", Content: "first question"},
+		{Role: "assistant", ToolCalls: []*provider.ToolCall{{ID: "c1", Function: provider.FunctionCall{Name: "f", Arguments: "{}"}}}},
+		{Role: "tool", ToolCallID: "c1", Content: "result"},
+		{Role: "user", Content: "second"},
+	}
+
+	total := CountMessages(bpe, messages)
+	if total <= 0 {
+		t.Fatalf("Expected positive token count, got %d", total)
+	}
+	if kept, n := TruncateMessages(bpe, messages, total); len(kept) != len(messages) || n != total {
+		t.Errorf("Expected no truncation within budget, got %d messages, %d tokens", len(kept), n)
+	}
+
+	// 预算只够 system 消息和最后两条消息时，开头的 tool 消息失去对应的 tool_calls 也应丢弃
+	budget := tokensPerReply + countMessage(bpe, messages[0]) + countMessage(bpe, messages[3]) + countMessage(bpe, messages[4])
+	kept, n := TruncateMessages(bpe, messages, budget)
+	if len(kept) != 2 || kept[0].Role != "system" || kept[1].Content != "second" {
+		t.Errorf("Unexpected truncated messages: %+v", kept)
+	}
+	if n > budget {
+		t.Errorf("Expected truncated tokens within budget %d, got %d", budget, n)
+	}
+}
@@ -22,10 +22,11 @@ if ! command -v protoc &> /dev/null; then
     exit 1
 fi
 
-# 检查goctl是否安装
-if ! command -v goctl &> /dev/null; then
-    echo "❌ goctl 未安装，请先安装 goctl"
-    echo "   安装命令: go install github.com/zeromicro/go-zero/tools/goctl@latest"
+# 检查protoc-gen-go/protoc-gen-go-grpc是否安装
+if ! command -v protoc-gen-go &> /dev/null || ! command -v protoc-gen-go-grpc &> /dev/null; then
+    echo "❌ protoc-gen-go 或 protoc-gen-go-grpc 未安装"
+    echo "   安装命令: go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.31.0"
+    echo "            go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.1.0"
     exit 1
 fi
 
@@ -35,13 +36,15 @@ if [ ! -f "bsllm.proto" ]; then
     exit 1
 fi
 
-echo "🔧 步骤1: 使用 goctl 重新生成proto代码..."
+echo "🔧 步骤1: 使用 protoc 重新生成proto代码..."
 # 删除旧的生成文件
 rm -rf bs_llm/*.pb.go
 rm -rf bs_llm/*_grpc.pb.go
 
 # 重新生成proto代码
-goctl rpc protoc bsllm.proto --go_out=. --go-grpc_out=. --zrpc_out=.
+# bsllm.proto 包含 BsLlmService 和 BsLlmAdminService 两个服务，goctl 单服务模式不支持，
+# 这里只生成 pb 代码，新增 RPC 方法时需在 internal/server、bsllmservice、bsllmadminservice 中手动补充对应方法
+protoc bsllm.proto --go_out=. --go-grpc_out=.
 
 if [ $? -eq 0 ]; then
     echo "✅ Proto代码生成成功"
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.31.0
-// 	protoc        v3.19.4
+// 	protoc        v5.29.3
 // source: bsrag.proto
 
 package bs_rag
@@ -26,12 +26,15 @@ type VectorSearchRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	QueryText string            `protobuf:"bytes,1,opt,name=query_text,json=queryText,proto3" json:"query_text,omitempty"`                                                                    // 查询文本（用于自动向量化）
-	TopK      int32             `protobuf:"varint,2,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`                                                                                  // 返回最相似的k个结果
-	MinScore  float32           `protobuf:"fixed32,3,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`                                                                     // 最小相似度阈值
-	Filters   map[string]string `protobuf:"bytes,4,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // 过滤条件
-	UserId    string            `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                                                             // 用户ID
-	SceneCode string            `protobuf:"bytes,6,opt,name=scene_code,json=sceneCode,proto3" json:"scene_code,omitempty"`                                                                    // 场景编码（用于确定使用的embedding模型和集合名称）
+	QueryText      string            `protobuf:"bytes,1,opt,name=query_text,json=queryText,proto3" json:"query_text,omitempty"`                                                                    // 查询文本（用于自动向量化）
+	TopK           int32             `protobuf:"varint,2,opt,name=top_k,json=topK,proto3" json:"top_k,omitempty"`                                                                                  // 返回最相似的k个结果
+	MinScore       float32           `protobuf:"fixed32,3,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`                                                                     // 最小相似度阈值
+	Filters        map[string]string `protobuf:"bytes,4,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // 过滤条件，元数据字段等值匹配，多个字段同时满足
+	UserId         string            `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                                                             // 用户ID，非空时只检索元数据 user_id 等于该值的文档
+	SceneCode      string            `protobuf:"bytes,6,opt,name=scene_code,json=sceneCode,proto3" json:"scene_code,omitempty"`                                                                    // 场景编码（用于确定使用的embedding模型和集合名称）
+	Filter         *SearchFilter     `protobuf:"bytes,7,opt,name=filter,proto3" json:"filter,omitempty"`                                                                                           // 过滤表达式，与 filters 同时指定时需同时满足
+	Mode           string            `protobuf:"bytes,8,opt,name=mode,proto3" json:"mode,omitempty"`                                                                                               // 检索模式: vector(默认), keyword(BM25关键词), hybrid(两路结果按倒数排名融合)
+	MinRerankScore float32           `protobuf:"fixed32,9,opt,name=min_rerank_score,json=minRerankScore,proto3" json:"min_rerank_score,omitempty"`                                                 // 最小重排序分数，仅在场景启用重排序时生效
 }
 
 func (x *VectorSearchRequest) Reset() {
@@ -108,23 +111,128 @@ func (x *VectorSearchRequest) GetSceneCode() string {
 	return ""
 }
 
+func (x *VectorSearchRequest) GetFilter() *SearchFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *VectorSearchRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *VectorSearchRequest) GetMinRerankScore() float32 {
+	if x != nil {
+		return x.MinRerankScore
+	}
+	return 0
+}
+
+// 元数据过滤表达式
+// op 为 and/or 时组合 children；为 eq/in/gt/gte/lt/lte 时比较 field 对应的元数据
+// 范围比较两侧都是数字时按数值比较，否则按字符串比较
+type SearchFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Op       string          `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`             // 操作符: eq, in, gt, gte, lt, lte, and, or
+	Field    string          `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`       // 元数据字段名
+	Value    string          `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`       // 比较值（eq 和范围比较）
+	Values   []string        `protobuf:"bytes,4,rep,name=values,proto3" json:"values,omitempty"`     // 候选值（in）
+	Children []*SearchFilter `protobuf:"bytes,5,rep,name=children,proto3" json:"children,omitempty"` // 子条件（and/or）
+}
+
+func (x *SearchFilter) Reset() {
+	*x = SearchFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bsrag_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFilter) ProtoMessage() {}
+
+func (x *SearchFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_bsrag_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFilter.ProtoReflect.Descriptor instead.
+func (*SearchFilter) Descriptor() ([]byte, []int) {
+	return file_bsrag_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SearchFilter) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *SearchFilter) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *SearchFilter) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *SearchFilter) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *SearchFilter) GetChildren() []*SearchFilter {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
 // 向量搜索结果
 type VectorSearchResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id       string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                                                     // 文档ID
-	Vector   []float32         `protobuf:"fixed32,2,rep,packed,name=vector,proto3" json:"vector,omitempty"`                                                                                    // 向量
-	Score    float32           `protobuf:"fixed32,3,opt,name=score,proto3" json:"score,omitempty"`                                                                                             // 相似度分数
-	Metadata map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // 元数据
-	Content  string            `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`                                                                                           // 文档内容
+	Id          string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                                                                                     // 文档ID
+	Vector      []float32         `protobuf:"fixed32,2,rep,packed,name=vector,proto3" json:"vector,omitempty"`                                                                                    // 向量
+	Score       float32           `protobuf:"fixed32,3,opt,name=score,proto3" json:"score,omitempty"`                                                                                             // 分数: vector 为相似度，keyword 为 BM25 得分，hybrid 为倒数排名融合得分
+	Metadata    map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // 元数据
+	Content     string            `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`                                                                                           // 文档内容
+	RerankScore float32           `protobuf:"fixed32,6,opt,name=rerank_score,json=rerankScore,proto3" json:"rerank_score,omitempty"`                                                              // 重排序分数，场景未启用重排序时为0
+	VectorScore float32           `protobuf:"fixed32,7,opt,name=vector_score,json=vectorScore,proto3" json:"vector_score,omitempty"`                                                              // 向量相似度，keyword 模式及 hybrid 中只由关键词召回的文档为0
 }
 
 func (x *VectorSearchResult) Reset() {
 	*x = VectorSearchResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bsrag_proto_msgTypes[1]
+		mi := &file_bsrag_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -137,7 +245,7 @@ func (x *VectorSearchResult) String() string {
 func (*VectorSearchResult) ProtoMessage() {}
 
 func (x *VectorSearchResult) ProtoReflect() protoreflect.Message {
-	mi := &file_bsrag_proto_msgTypes[1]
+	mi := &file_bsrag_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -150,7 +258,7 @@ func (x *VectorSearchResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VectorSearchResult.ProtoReflect.Descriptor instead.
 func (*VectorSearchResult) Descriptor() ([]byte, []int) {
-	return file_bsrag_proto_rawDescGZIP(), []int{1}
+	return file_bsrag_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *VectorSearchResult) GetId() string {
@@ -188,6 +296,20 @@ func (x *VectorSearchResult) GetContent() string {
 	return ""
 }
 
+func (x *VectorSearchResult) GetRerankScore() float32 {
+	if x != nil {
+		return x.RerankScore
+	}
+	return 0
+}
+
+func (x *VectorSearchResult) GetVectorScore() float32 {
+	if x != nil {
+		return x.VectorScore
+	}
+	return 0
+}
+
 // 向量查询响应
 type VectorSearchResponse struct {
 	state         protoimpl.MessageState
@@ -197,12 +319,13 @@ type VectorSearchResponse struct {
 	Results      []*VectorSearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`                                   // 搜索结果列表
 	TotalCount   int32                 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`          // 总结果数
 	SearchTimeMs float32               `protobuf:"fixed32,3,opt,name=search_time_ms,json=searchTimeMs,proto3" json:"search_time_ms,omitempty"` // 搜索耗时(毫秒)
+	Reranked     bool                  `protobuf:"varint,4,opt,name=reranked,proto3" json:"reranked,omitempty"`                                // 是否经过重排序，为 true 时结果按 rerank_score 排序
 }
 
 func (x *VectorSearchResponse) Reset() {
 	*x = VectorSearchResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bsrag_proto_msgTypes[2]
+		mi := &file_bsrag_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -215,7 +338,7 @@ func (x *VectorSearchResponse) String() string {
 func (*VectorSearchResponse) ProtoMessage() {}
 
 func (x *VectorSearchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bsrag_proto_msgTypes[2]
+	mi := &file_bsrag_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -228,7 +351,7 @@ func (x *VectorSearchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VectorSearchResponse.ProtoReflect.Descriptor instead.
 func (*VectorSearchResponse) Descriptor() ([]byte, []int) {
-	return file_bsrag_proto_rawDescGZIP(), []int{2}
+	return file_bsrag_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *VectorSearchResponse) GetResults() []*VectorSearchResult {
@@ -252,6 +375,13 @@ func (x *VectorSearchResponse) GetSearchTimeMs() float32 {
 	return 0
 }
 
+func (x *VectorSearchResponse) GetReranked() bool {
+	if x != nil {
+		return x.Reranked
+	}
+	return false
+}
+
 // 向量插入请求
 type VectorInsertRequest struct {
 	state         protoimpl.MessageState
@@ -266,7 +396,7 @@ type VectorInsertRequest struct {
 func (x *VectorInsertRequest) Reset() {
 	*x = VectorInsertRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bsrag_proto_msgTypes[3]
+		mi := &file_bsrag_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -279,7 +409,7 @@ func (x *VectorInsertRequest) String() string {
 func (*VectorInsertRequest) ProtoMessage() {}
 
 func (x *VectorInsertRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bsrag_proto_msgTypes[3]
+	mi := &file_bsrag_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -292,7 +422,7 @@ func (x *VectorInsertRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VectorInsertRequest.ProtoReflect.Descriptor instead.
 func (*VectorInsertRequest) Descriptor() ([]byte, []int) {
-	return file_bsrag_proto_rawDescGZIP(), []int{3}
+	return file_bsrag_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *VectorInsertRequest) GetDocuments() []*VectorDocument {
@@ -331,7 +461,7 @@ type VectorDocument struct {
 func (x *VectorDocument) Reset() {
 	*x = VectorDocument{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bsrag_proto_msgTypes[4]
+		mi := &file_bsrag_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -344,7 +474,7 @@ func (x *VectorDocument) String() string {
 func (*VectorDocument) ProtoMessage() {}
 
 func (x *VectorDocument) ProtoReflect() protoreflect.Message {
-	mi := &file_bsrag_proto_msgTypes[4]
+	mi := &file_bsrag_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -357,7 +487,7 @@ func (x *VectorDocument) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VectorDocument.ProtoReflect.Descriptor instead.
 func (*VectorDocument) Descriptor() ([]byte, []int) {
-	return file_bsrag_proto_rawDescGZIP(), []int{4}
+	return file_bsrag_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *VectorDocument) GetId() string {
@@ -394,15 +524,16 @@ type VectorInsertResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	InsertedCount int32    `protobuf:"varint,1,opt,name=inserted_count,json=insertedCount,proto3" json:"inserted_count,omitempty"` // 成功插入的文档数
-	InsertedIds   []string `protobuf:"bytes,2,rep,name=inserted_ids,json=insertedIds,proto3" json:"inserted_ids,omitempty"`        // 插入的文档ID列表
-	ErrorMessage  string   `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`     // 错误信息(如果有)
+	InsertedCount   int32             `protobuf:"varint,1,opt,name=inserted_count,json=insertedCount,proto3" json:"inserted_count,omitempty"`      // 成功插入的文档数
+	InsertedIds     []string          `protobuf:"bytes,2,rep,name=inserted_ids,json=insertedIds,proto3" json:"inserted_ids,omitempty"`             // 插入的文档ID列表
+	ErrorMessage    string            `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`          // 错误信息(如果有)
+	FailedDocuments []*FailedDocument `protobuf:"bytes,4,rep,name=failed_documents,json=failedDocuments,proto3" json:"failed_documents,omitempty"` // 插入失败的文档，其余文档正常插入
 }
 
 func (x *VectorInsertResponse) Reset() {
 	*x = VectorInsertResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bsrag_proto_msgTypes[5]
+		mi := &file_bsrag_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -415,7 +546,7 @@ func (x *VectorInsertResponse) String() string {
 func (*VectorInsertResponse) ProtoMessage() {}
 
 func (x *VectorInsertResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bsrag_proto_msgTypes[5]
+	mi := &file_bsrag_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -428,7 +559,7 @@ func (x *VectorInsertResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VectorInsertResponse.ProtoReflect.Descriptor instead.
 func (*VectorInsertResponse) Descriptor() ([]byte, []int) {
-	return file_bsrag_proto_rawDescGZIP(), []int{5}
+	return file_bsrag_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *VectorInsertResponse) GetInsertedCount() int32 {
@@ -452,6 +583,69 @@ func (x *VectorInsertResponse) GetErrorMessage() string {
 	return ""
 }
 
+func (x *VectorInsertResponse) GetFailedDocuments() []*FailedDocument {
+	if x != nil {
+		return x.FailedDocuments
+	}
+	return nil
+}
+
+// 插入失败的文档
+type FailedDocument struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`       // 文档ID
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"` // 失败原因
+}
+
+func (x *FailedDocument) Reset() {
+	*x = FailedDocument{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_bsrag_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FailedDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FailedDocument) ProtoMessage() {}
+
+func (x *FailedDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_bsrag_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FailedDocument.ProtoReflect.Descriptor instead.
+func (*FailedDocument) Descriptor() ([]byte, []int) {
+	return file_bsrag_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FailedDocument) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FailedDocument) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 // 向量删除请求
 type VectorDeleteRequest struct {
 	state         protoimpl.MessageState
@@ -466,7 +660,7 @@ type VectorDeleteRequest struct {
 func (x *VectorDeleteRequest) Reset() {
 	*x = VectorDeleteRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bsrag_proto_msgTypes[6]
+		mi := &file_bsrag_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -479,7 +673,7 @@ func (x *VectorDeleteRequest) String() string {
 func (*VectorDeleteRequest) ProtoMessage() {}
 
 func (x *VectorDeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bsrag_proto_msgTypes[6]
+	mi := &file_bsrag_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -492,7 +686,7 @@ func (x *VectorDeleteRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VectorDeleteRequest.ProtoReflect.Descriptor instead.
 func (*VectorDeleteRequest) Descriptor() ([]byte, []int) {
-	return file_bsrag_proto_rawDescGZIP(), []int{6}
+	return file_bsrag_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *VectorDeleteRequest) GetDocumentIds() []string {
@@ -530,7 +724,7 @@ type VectorDeleteResponse struct {
 func (x *VectorDeleteResponse) Reset() {
 	*x = VectorDeleteResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bsrag_proto_msgTypes[7]
+		mi := &file_bsrag_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -543,7 +737,7 @@ func (x *VectorDeleteResponse) String() string {
 func (*VectorDeleteResponse) ProtoMessage() {}
 
 func (x *VectorDeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bsrag_proto_msgTypes[7]
+	mi := &file_bsrag_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -556,7 +750,7 @@ func (x *VectorDeleteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VectorDeleteResponse.ProtoReflect.Descriptor instead.
 func (*VectorDeleteResponse) Descriptor() ([]byte, []int) {
-	return file_bsrag_proto_rawDescGZIP(), []int{7}
+	return file_bsrag_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *VectorDeleteResponse) GetDeletedCount() int32 {
@@ -593,7 +787,7 @@ type VectorizeTextRequest struct {
 func (x *VectorizeTextRequest) Reset() {
 	*x = VectorizeTextRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bsrag_proto_msgTypes[8]
+		mi := &file_bsrag_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -606,7 +800,7 @@ func (x *VectorizeTextRequest) String() string {
 func (*VectorizeTextRequest) ProtoMessage() {}
 
 func (x *VectorizeTextRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bsrag_proto_msgTypes[8]
+	mi := &file_bsrag_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -619,7 +813,7 @@ func (x *VectorizeTextRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VectorizeTextRequest.ProtoReflect.Descriptor instead.
 func (*VectorizeTextRequest) Descriptor() ([]byte, []int) {
-	return file_bsrag_proto_rawDescGZIP(), []int{8}
+	return file_bsrag_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *VectorizeTextRequest) GetText() string {
@@ -649,7 +843,7 @@ type VectorizeTextResponse struct {
 func (x *VectorizeTextResponse) Reset() {
 	*x = VectorizeTextResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_bsrag_proto_msgTypes[9]
+		mi := &file_bsrag_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -662,7 +856,7 @@ func (x *VectorizeTextResponse) String() string {
 func (*VectorizeTextResponse) ProtoMessage() {}
 
 func (x *VectorizeTextResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bsrag_proto_msgTypes[9]
+	mi := &file_bsrag_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -675,7 +869,7 @@ func (x *VectorizeTextResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VectorizeTextResponse.ProtoReflect.Descriptor instead.
 func (*VectorizeTextResponse) Descriptor() ([]byte, []int) {
-	return file_bsrag_proto_rawDescGZIP(), []int{9}
+	return file_bsrag_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *VectorizeTextResponse) GetVector() []float32 {
@@ -696,7 +890,7 @@ var File_bsrag_proto protoreflect.FileDescriptor
 
 var file_bsrag_proto_rawDesc = []byte{
 	0x0a, 0x0b, 0x62, 0x73, 0x72, 0x61, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x62,
-	0x73, 0x5f, 0x72, 0x61, 0x67, 0x22, 0x9e, 0x02, 0x0a, 0x13, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x73, 0x5f, 0x72, 0x61, 0x67, 0x22, 0x8a, 0x03, 0x0a, 0x13, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72,
 	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
 	0x0a, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x09, 0x71, 0x75, 0x65, 0x72, 0x79, 0x54, 0x65, 0x78, 0x74, 0x12, 0x13, 0x0a, 0x05,
@@ -710,65 +904,95 @@ var file_bsrag_proto_rawDesc = []byte{
 	0x72, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73,
 	0x63, 0x65, 0x6e, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x73, 0x63, 0x65, 0x6e, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x1a, 0x3a, 0x0a, 0x0c, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xef, 0x01, 0x0a, 0x12, 0x56, 0x65, 0x63, 0x74, 0x6f,
-	0x72, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a,
-	0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x02, 0x52, 0x06, 0x76,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x44, 0x0a, 0x08, 0x6d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e,
-	0x62, 0x73, 0x5f, 0x72, 0x61, 0x67, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x93, 0x01, 0x0a, 0x14, 0x56, 0x65, 0x63,
-	0x74, 0x6f, 0x72, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x34, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62, 0x73, 0x5f, 0x72, 0x61, 0x67, 0x2e, 0x56, 0x65, 0x63, 0x74,
-	0x6f, 0x72, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07,
-	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c,
-	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f,
-	0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02,
-	0x52, 0x0c, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x22, 0x83,
-	0x01, 0x0a, 0x13, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65,
-	0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x62, 0x73, 0x5f, 0x72,
-	0x61, 0x67, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
-	0x74, 0x52, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x17, 0x0a, 0x07,
-	0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75,
-	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x63, 0x65, 0x6e, 0x65, 0x5f, 0x63,
-	0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x63, 0x65, 0x6e, 0x65,
-	0x43, 0x6f, 0x64, 0x65, 0x22, 0xcd, 0x01, 0x0a, 0x0e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x44,
-	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x40, 0x0a, 0x08, 0x6d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e,
-	0x62, 0x73, 0x5f, 0x72, 0x61, 0x67, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x44, 0x6f, 0x63,
-	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a,
-	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x22, 0x85, 0x01, 0x0a, 0x14, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x49,
-	0x6e, 0x73, 0x65, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a,
-	0x0e, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64,
-	0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x73, 0x65,
-	0x72, 0x74, 0x65, 0x64, 0x49, 0x64, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72,
-	0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x70, 0x0a, 0x13,
+	0x09, 0x73, 0x63, 0x65, 0x6e, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x73, 0x5f,
+	0x72, 0x61, 0x67, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x28, 0x0a, 0x10,
+	0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x72, 0x61, 0x6e, 0x6b, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0e, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x72, 0x61, 0x6e,
+	0x6b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x1a, 0x3a, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x94, 0x01, 0x0a, 0x0c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x6f, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64,
+	0x72, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x73, 0x5f, 0x72,
+	0x61, 0x67, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
+	0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x22, 0xb5, 0x02, 0x0a, 0x12, 0x56, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x03, 0x28, 0x02,
+	0x52, 0x06, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x44,
+	0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x28, 0x2e, 0x62, 0x73, 0x5f, 0x72, 0x61, 0x67, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x21,
+	0x0a, 0x0c, 0x72, 0x65, 0x72, 0x61, 0x6e, 0x6b, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x72, 0x65, 0x72, 0x61, 0x6e, 0x6b, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0xaf, 0x01, 0x0a, 0x14, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x07, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x62, 0x73,
+	0x5f, 0x72, 0x61, 0x67, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0c, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x72, 0x61, 0x6e,
+	0x6b, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x72, 0x61, 0x6e,
+	0x6b, 0x65, 0x64, 0x22, 0x83, 0x01, 0x0a, 0x13, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x49, 0x6e,
+	0x73, 0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x09, 0x64,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x62, 0x73, 0x5f, 0x72, 0x61, 0x67, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x44, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x63,
+	0x65, 0x6e, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x63, 0x65, 0x6e, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x22, 0xcd, 0x01, 0x0a, 0x0e, 0x56, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x12, 0x40, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x24, 0x2e, 0x62, 0x73, 0x5f, 0x72, 0x61, 0x67, 0x2e, 0x56, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x1a, 0x3b, 0x0a, 0x0d,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc8, 0x01, 0x0a, 0x14, 0x56, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x69, 0x6e, 0x73, 0x65,
+	0x72, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x73,
+	0x65, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0b, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x49, 0x64, 0x73, 0x12, 0x23, 0x0a, 0x0d,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x12, 0x41, 0x0a, 0x10, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x64, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x62, 0x73,
+	0x5f, 0x72, 0x61, 0x67, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x44, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x0f, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x44, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x22, 0x36, 0x0a, 0x0e, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x44, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x70, 0x0a, 0x13,
 	0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
 	0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x6f, 0x63, 0x75, 0x6d,
@@ -830,41 +1054,46 @@ func file_bsrag_proto_rawDescGZIP() []byte {
 	return file_bsrag_proto_rawDescData
 }
 
-var file_bsrag_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_bsrag_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_bsrag_proto_goTypes = []interface{}{
 	(*VectorSearchRequest)(nil),   // 0: bs_rag.VectorSearchRequest
-	(*VectorSearchResult)(nil),    // 1: bs_rag.VectorSearchResult
-	(*VectorSearchResponse)(nil),  // 2: bs_rag.VectorSearchResponse
-	(*VectorInsertRequest)(nil),   // 3: bs_rag.VectorInsertRequest
-	(*VectorDocument)(nil),        // 4: bs_rag.VectorDocument
-	(*VectorInsertResponse)(nil),  // 5: bs_rag.VectorInsertResponse
-	(*VectorDeleteRequest)(nil),   // 6: bs_rag.VectorDeleteRequest
-	(*VectorDeleteResponse)(nil),  // 7: bs_rag.VectorDeleteResponse
-	(*VectorizeTextRequest)(nil),  // 8: bs_rag.VectorizeTextRequest
-	(*VectorizeTextResponse)(nil), // 9: bs_rag.VectorizeTextResponse
-	nil,                           // 10: bs_rag.VectorSearchRequest.FiltersEntry
-	nil,                           // 11: bs_rag.VectorSearchResult.MetadataEntry
-	nil,                           // 12: bs_rag.VectorDocument.MetadataEntry
+	(*SearchFilter)(nil),          // 1: bs_rag.SearchFilter
+	(*VectorSearchResult)(nil),    // 2: bs_rag.VectorSearchResult
+	(*VectorSearchResponse)(nil),  // 3: bs_rag.VectorSearchResponse
+	(*VectorInsertRequest)(nil),   // 4: bs_rag.VectorInsertRequest
+	(*VectorDocument)(nil),        // 5: bs_rag.VectorDocument
+	(*VectorInsertResponse)(nil),  // 6: bs_rag.VectorInsertResponse
+	(*FailedDocument)(nil),        // 7: bs_rag.FailedDocument
+	(*VectorDeleteRequest)(nil),   // 8: bs_rag.VectorDeleteRequest
+	(*VectorDeleteResponse)(nil),  // 9: bs_rag.VectorDeleteResponse
+	(*VectorizeTextRequest)(nil),  // 10: bs_rag.VectorizeTextRequest
+	(*VectorizeTextResponse)(nil), // 11: bs_rag.VectorizeTextResponse
+	nil,                           // 12: bs_rag.VectorSearchRequest.FiltersEntry
+	nil,                           // 13: bs_rag.VectorSearchResult.MetadataEntry
+	nil,                           // 14: bs_rag.VectorDocument.MetadataEntry
 }
 var file_bsrag_proto_depIdxs = []int32{
-	10, // 0: bs_rag.VectorSearchRequest.filters:type_name -> bs_rag.VectorSearchRequest.FiltersEntry
-	11, // 1: bs_rag.VectorSearchResult.metadata:type_name -> bs_rag.VectorSearchResult.MetadataEntry
-	1,  // 2: bs_rag.VectorSearchResponse.results:type_name -> bs_rag.VectorSearchResult
-	4,  // 3: bs_rag.VectorInsertRequest.documents:type_name -> bs_rag.VectorDocument
-	12, // 4: bs_rag.VectorDocument.metadata:type_name -> bs_rag.VectorDocument.MetadataEntry
-	0,  // 5: bs_rag.BsRagService.VectorSearch:input_type -> bs_rag.VectorSearchRequest
-	3,  // 6: bs_rag.BsRagService.VectorInsert:input_type -> bs_rag.VectorInsertRequest
-	6,  // 7: bs_rag.BsRagService.VectorDelete:input_type -> bs_rag.VectorDeleteRequest
-	8,  // 8: bs_rag.BsRagService.VectorizeText:input_type -> bs_rag.VectorizeTextRequest
-	2,  // 9: bs_rag.BsRagService.VectorSearch:output_type -> bs_rag.VectorSearchResponse
-	5,  // 10: bs_rag.BsRagService.VectorInsert:output_type -> bs_rag.VectorInsertResponse
-	7,  // 11: bs_rag.BsRagService.VectorDelete:output_type -> bs_rag.VectorDeleteResponse
-	9,  // 12: bs_rag.BsRagService.VectorizeText:output_type -> bs_rag.VectorizeTextResponse
-	9,  // [9:13] is the sub-list for method output_type
-	5,  // [5:9] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	12, // 0: bs_rag.VectorSearchRequest.filters:type_name -> bs_rag.VectorSearchRequest.FiltersEntry
+	1,  // 1: bs_rag.VectorSearchRequest.filter:type_name -> bs_rag.SearchFilter
+	1,  // 2: bs_rag.SearchFilter.children:type_name -> bs_rag.SearchFilter
+	13, // 3: bs_rag.VectorSearchResult.metadata:type_name -> bs_rag.VectorSearchResult.MetadataEntry
+	2,  // 4: bs_rag.VectorSearchResponse.results:type_name -> bs_rag.VectorSearchResult
+	5,  // 5: bs_rag.VectorInsertRequest.documents:type_name -> bs_rag.VectorDocument
+	14, // 6: bs_rag.VectorDocument.metadata:type_name -> bs_rag.VectorDocument.MetadataEntry
+	7,  // 7: bs_rag.VectorInsertResponse.failed_documents:type_name -> bs_rag.FailedDocument
+	0,  // 8: bs_rag.BsRagService.VectorSearch:input_type -> bs_rag.VectorSearchRequest
+	4,  // 9: bs_rag.BsRagService.VectorInsert:input_type -> bs_rag.VectorInsertRequest
+	8,  // 10: bs_rag.BsRagService.VectorDelete:input_type -> bs_rag.VectorDeleteRequest
+	10, // 11: bs_rag.BsRagService.VectorizeText:input_type -> bs_rag.VectorizeTextRequest
+	3,  // 12: bs_rag.BsRagService.VectorSearch:output_type -> bs_rag.VectorSearchResponse
+	6,  // 13: bs_rag.BsRagService.VectorInsert:output_type -> bs_rag.VectorInsertResponse
+	9,  // 14: bs_rag.BsRagService.VectorDelete:output_type -> bs_rag.VectorDeleteResponse
+	11, // 15: bs_rag.BsRagService.VectorizeText:output_type -> bs_rag.VectorizeTextResponse
+	12, // [12:16] is the sub-list for method output_type
+	8,  // [8:12] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_bsrag_proto_init() }
@@ -886,7 +1115,7 @@ func file_bsrag_proto_init() {
 			}
 		}
 		file_bsrag_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VectorSearchResult); i {
+			switch v := v.(*SearchFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -898,7 +1127,7 @@ func file_bsrag_proto_init() {
 			}
 		}
 		file_bsrag_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VectorSearchResponse); i {
+			switch v := v.(*VectorSearchResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -910,7 +1139,7 @@ func file_bsrag_proto_init() {
 			}
 		}
 		file_bsrag_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VectorInsertRequest); i {
+			switch v := v.(*VectorSearchResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -922,7 +1151,7 @@ func file_bsrag_proto_init() {
 			}
 		}
 		file_bsrag_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VectorDocument); i {
+			switch v := v.(*VectorInsertRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -934,7 +1163,7 @@ func file_bsrag_proto_init() {
 			}
 		}
 		file_bsrag_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VectorInsertResponse); i {
+			switch v := v.(*VectorDocument); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -946,7 +1175,7 @@ func file_bsrag_proto_init() {
 			}
 		}
 		file_bsrag_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VectorDeleteRequest); i {
+			switch v := v.(*VectorInsertResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -958,7 +1187,7 @@ func file_bsrag_proto_init() {
 			}
 		}
 		file_bsrag_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VectorDeleteResponse); i {
+			switch v := v.(*FailedDocument); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -970,7 +1199,7 @@ func file_bsrag_proto_init() {
 			}
 		}
 		file_bsrag_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VectorizeTextRequest); i {
+			switch v := v.(*VectorDeleteRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -982,6 +1211,30 @@ func file_bsrag_proto_init() {
 			}
 		}
 		file_bsrag_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VectorDeleteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bsrag_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VectorizeTextRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_bsrag_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*VectorizeTextResponse); i {
 			case 0:
 				return &v.state
@@ -1000,7 +1253,7 @@ func file_bsrag_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_bsrag_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
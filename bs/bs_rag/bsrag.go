@@ -10,6 +10,8 @@ import (
 	"jxzy/bs/bs_rag/internal/svc"
 
 	"github.com/zeromicro/go-zero/core/conf"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/core/proc"
 	"github.com/zeromicro/go-zero/core/service"
 	"github.com/zeromicro/go-zero/zrpc"
 	"google.golang.org/grpc"
@@ -22,10 +24,22 @@ func main() {
 	flag.Parse()
 
 	var c config.Config
-	conf.MustLoad(*configFile, &c)
+	conf.MustLoad(*configFile, &c, conf.UseEnv())
 	ctx := svc.NewServiceContext(c)
 	srv := server.NewBsRagServiceServer(ctx)
 
+	// 退出前关闭向量存储和关键词索引，本地存储在此保存未持久化的数据
+	proc.AddShutdownListener(func() {
+		if err := ctx.VectorProvider.Close(); err != nil {
+			logx.Errorf("Failed to close vector provider: %v", err)
+		}
+		if ctx.KeywordStore != nil {
+			if err := ctx.KeywordStore.Close(); err != nil {
+				logx.Errorf("Failed to close keyword store: %v", err)
+			}
+		}
+	})
+
 	s, err := zrpc.NewServer(c.RpcServerConf, func(grpcServer *grpc.Server) {
 		bs_rag.RegisterBsRagServiceServer(grpcServer, srv)
 
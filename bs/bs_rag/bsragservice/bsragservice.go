@@ -13,14 +13,16 @@ import (
 )
 
 type (
-	VectorDeleteRequest    = bs_rag.VectorDeleteRequest
-	VectorDeleteResponse   = bs_rag.VectorDeleteResponse
-	VectorDocument         = bs_rag.VectorDocument
-	VectorInsertRequest    = bs_rag.VectorInsertRequest
-	VectorInsertResponse   = bs_rag.VectorInsertResponse
-	VectorSearchRequest    = bs_rag.VectorSearchRequest
-	VectorSearchResponse   = bs_rag.VectorSearchResponse
-	VectorSearchResult     = bs_rag.VectorSearchResult
+	FailedDocument       = bs_rag.FailedDocument
+	SearchFilter         = bs_rag.SearchFilter
+	VectorDeleteRequest  = bs_rag.VectorDeleteRequest
+	VectorDeleteResponse = bs_rag.VectorDeleteResponse
+	VectorDocument       = bs_rag.VectorDocument
+	VectorInsertRequest  = bs_rag.VectorInsertRequest
+	VectorInsertResponse = bs_rag.VectorInsertResponse
+	VectorSearchRequest  = bs_rag.VectorSearchRequest
+	VectorSearchResponse = bs_rag.VectorSearchResponse
+	VectorSearchResult   = bs_rag.VectorSearchResult
 
 	BsRagService interface {
 		// 向量相似度搜索
@@ -10,6 +10,9 @@ type Config struct {
 	Collections CollectionsConfig        `json:"Collections"`
 	VectorDB    VectorDBConfig           `json:"VectorDB"`
 	EmbeddingProviders map[string]EmbeddingProviderConfig `json:"EmbeddingProviders"` // provider_code -> provider config
+	BsLlmRpc    zrpc.RpcClientConf       `json:"BsLlmRpc,optional"` // provider_code 为 bs_llm 的场景通过 bs_llm 的 Embed 接口向量化
+	Keyword     KeywordConfig            `json:"Keyword,optional"`  // BM25 关键词索引，用于 keyword 和 hybrid 检索模式
+	EmbeddingBatch EmbeddingBatchConfig  `json:"EmbeddingBatch,optional"` // VectorInsert 批量向量化
 }
 
 type VectorDBConfig struct {
@@ -22,14 +25,29 @@ type MySQLConfig struct {
 }
 
 type FaissConfig struct {
-	IndexPath      string `json:"IndexPath"`
-	IndexType      string `json:"IndexType"`
-	Nlist          int    `json:"Nlist"`
-	Nprobe         int    `json:"Nprobe"`
-	M              int    `json:"M"`
-	EfConstruction int    `json:"EfConstruction"`
-	EfSearch       int    `json:"EfSearch"`
-	MetricType     string `json:"MetricType"`
+	IndexPath        string `json:"IndexPath"`
+	IndexType        string `json:"IndexType"`
+	Nlist            int    `json:"Nlist"`
+	Nprobe           int    `json:"Nprobe"`
+	M                int    `json:"M"`
+	EfConstruction   int    `json:"EfConstruction"`
+	EfSearch         int    `json:"EfSearch"`
+	MetricType       string `json:"MetricType"`
+	SnapshotInterval int    `json:"SnapshotInterval,default=30"` // 快照间隔（秒），0 表示每次写入后立即快照
+}
+
+type KeywordConfig struct {
+	Enabled          bool    `json:"Enabled,optional"`
+	IndexPath        string  `json:"IndexPath,default=./data/keyword_indexes"`
+	SnapshotInterval int     `json:"SnapshotInterval,default=30"` // 快照间隔（秒），0 表示每次写入后立即快照
+	K1               float64 `json:"K1,default=1.2"`              // BM25 词频饱和参数
+	B                float64 `json:"B,default=0.75"`              // BM25 文档长度归一化参数
+}
+
+type EmbeddingBatchConfig struct {
+	Concurrency   int `json:"Concurrency,default=4"`     // 同时调用的批次数
+	MaxRetries    int `json:"MaxRetries,default=2"`      // 批次临时错误的重试次数
+	RetryInterval int `json:"RetryInterval,default=500"` // 首次重试间隔（毫秒），之后每次翻倍
 }
 
 type DashVectorConfig struct {
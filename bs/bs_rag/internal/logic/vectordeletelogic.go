@@ -66,6 +66,12 @@ func (l *VectorDeleteLogic) VectorDelete(in *bs_rag.VectorDeleteRequest) (*bs_ra
 		}, nil
 	}
 
+	if l.svcCtx.KeywordStore != nil {
+		if err := l.svcCtx.KeywordStore.Delete(collectionName, in.DocumentIds); err != nil {
+			l.Logger.Errorf("Failed to snapshot keyword index for collection %s: %v", collectionName, err)
+		}
+	}
+
 	return &bs_rag.VectorDeleteResponse{
 		DeletedCount: int32(len(in.DocumentIds)),
 		DeletedIds:   in.DocumentIds,
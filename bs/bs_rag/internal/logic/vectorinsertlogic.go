@@ -3,8 +3,11 @@ package logic
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"jxzy/bs/bs_rag/bs_rag"
+	"jxzy/bs/bs_rag/internal/provider/embedding/batch"
+	"jxzy/bs/bs_rag/internal/provider/keyword"
 	"jxzy/bs/bs_rag/internal/provider/vectorstore/types"
 	"jxzy/bs/bs_rag/internal/svc"
 	"jxzy/common/logger"
@@ -68,33 +71,48 @@ func (l *VectorInsertLogic) VectorInsert(in *bs_rag.VectorInsertRequest) (*bs_ra
 		}, nil
 	}
 
-	// 转换文档
-	documents := make([]types.Document, 0, len(in.Documents))
-	insertedIds := make([]string, 0, len(in.Documents))
-
+	// 跳过没有文本的文档，其余文档批量向量化，单个文档失败不影响其他文档
+	var failed []*bs_rag.FailedDocument
+	valid := make([]*bs_rag.VectorDocument, 0, len(in.Documents))
+	texts := make([]string, 0, len(in.Documents))
 	for _, doc := range in.Documents {
-		// 参数验证
 		if doc.Text == "" {
 			l.Logger.Errorf("Document id %s has no text, skipping", doc.Id)
+			failed = append(failed, &bs_rag.FailedDocument{Id: doc.Id, Error: "text is empty"})
 			continue
 		}
+		valid = append(valid, doc)
+		texts = append(texts, doc.Text)
+	}
 
-		// 自动生成向量
-		l.Logger.Infof("Auto-generating vector for document id: %s, text length: %d, scene_code: %s", doc.Id, len(doc.Text), in.SceneCode)
-		vector, err := embeddingProvider.GenerateEmbedding(doc.Text)
-		if err != nil {
+	batchConfig := l.svcCtx.Config.EmbeddingBatch
+	l.Logger.Infof("Generating vectors for %d documents, scene_code: %s", len(texts), in.SceneCode)
+	embedded := batch.Embed(l.ctx, embeddingProvider, texts, batch.Options{
+		Concurrency:   batchConfig.Concurrency,
+		MaxRetries:    batchConfig.MaxRetries,
+		RetryInterval: time.Duration(batchConfig.RetryInterval) * time.Millisecond,
+	})
+
+	// 转换文档
+	documents := make([]types.Document, 0, len(valid))
+	keywordDocuments := make([]keyword.Document, 0, len(valid))
+	insertedIds := make([]string, 0, len(valid))
+	for i, doc := range valid {
+		if err := embedded[i].Err; err != nil {
 			l.Logger.Errorf("Failed to generate embedding for document id %s: %v", doc.Id, err)
-			return &bs_rag.VectorInsertResponse{
-				InsertedCount: 0,
-				InsertedIds:   []string{},
-				ErrorMessage:  fmt.Sprintf("生成向量失败 (文档ID: %s): %v", doc.Id, err),
-			}, nil
+			failed = append(failed, &bs_rag.FailedDocument{Id: doc.Id, Error: fmt.Sprintf("生成向量失败: %v", err)})
+			continue
 		}
-		l.Logger.Debugf("Generated vector for document id %s: length=%d", doc.Id, len(vector))
 
 		documents = append(documents, types.Document{
 			ID:       doc.Id,
-			Vector:   vector,
+			Vector:   embedded[i].Vector,
+			Metadata: doc.Metadata,
+			Content:  doc.Content,
+		})
+		keywordDocuments = append(keywordDocuments, keyword.Document{
+			ID:       doc.Id,
+			Text:     doc.Text,
 			Metadata: doc.Metadata,
 			Content:  doc.Content,
 		})
@@ -103,9 +121,10 @@ func (l *VectorInsertLogic) VectorInsert(in *bs_rag.VectorInsertRequest) (*bs_ra
 
 	if len(documents) == 0 {
 		return &bs_rag.VectorInsertResponse{
-			InsertedCount: 0,
-			InsertedIds:   []string{},
-			ErrorMessage:  "没有有效的文档可插入",
+			InsertedCount:   0,
+			InsertedIds:     []string{},
+			ErrorMessage:    "没有有效的文档可插入",
+			FailedDocuments: failed,
 		}, nil
 	}
 
@@ -113,16 +132,29 @@ func (l *VectorInsertLogic) VectorInsert(in *bs_rag.VectorInsertRequest) (*bs_ra
 	err = l.svcCtx.VectorProvider.Insert(l.ctx, collectionName, documents)
 	if err != nil {
 		return &bs_rag.VectorInsertResponse{
-			InsertedCount: 0,
-			InsertedIds:   []string{},
-			ErrorMessage:  fmt.Sprintf("插入向量失败: %v", err),
+			InsertedCount:   0,
+			InsertedIds:     []string{},
+			ErrorMessage:    fmt.Sprintf("插入向量失败: %v", err),
+			FailedDocuments: failed,
 		}, nil
 	}
 
-	l.Logger.Infof("Successfully inserted %d documents to collection: %s", len(documents), collectionName)
+	// 同步写入关键词索引，快照失败不影响本次插入，内存中的索引已更新
+	if l.svcCtx.KeywordStore != nil {
+		if err := l.svcCtx.KeywordStore.Insert(collectionName, keywordDocuments); err != nil {
+			l.Logger.Errorf("Failed to snapshot keyword index for collection %s: %v", collectionName, err)
+		}
+	}
+
+	l.Logger.Infof("Successfully inserted %d documents to collection: %s, failed: %d", len(documents), collectionName, len(failed))
 
-	return &bs_rag.VectorInsertResponse{
-		InsertedCount: int32(len(documents)),
-		InsertedIds:   insertedIds,
-	}, nil
+	resp := &bs_rag.VectorInsertResponse{
+		InsertedCount:   int32(len(documents)),
+		InsertedIds:     insertedIds,
+		FailedDocuments: failed,
+	}
+	if len(failed) > 0 {
+		resp.ErrorMessage = fmt.Sprintf("%d 个文档插入失败", len(failed))
+	}
+	return resp, nil
 }
@@ -57,7 +57,7 @@ func (l *VectorizeTextLogic) VectorizeText(in *bs_rag.VectorizeTextRequest) (*bs
 	l.Logger.Infof("Vectorizing text, length: %d, scene_code: %s", len(in.Text), in.SceneCode)
 
 	// 生成向量
-	vector, err := embeddingProvider.GenerateEmbedding(in.Text)
+	vector, err := embeddingProvider.GenerateEmbedding(l.ctx, in.Text)
 	if err != nil {
 		l.Logger.Errorf("Failed to generate embedding: %v", err)
 		return &bs_rag.VectorizeTextResponse{
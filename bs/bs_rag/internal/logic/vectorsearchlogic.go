@@ -2,9 +2,14 @@ package logic
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 
 	"jxzy/bs/bs_rag/bs_rag"
+	"jxzy/bs/bs_rag/internal/provider/vectorstore/types"
 	"jxzy/bs/bs_rag/internal/svc"
+	"jxzy/common/errorx"
 	"jxzy/common/logger"
 
 	"github.com/zeromicro/go-zero/core/logx"
@@ -26,7 +31,7 @@ func NewVectorSearchLogic(ctx context.Context, svcCtx *svc.ServiceContext) *Vect
 	}
 }
 
-// 向量相似度搜索
+// 向量相似度搜索，mode 为 keyword 或 hybrid 时使用关键词索引
 func (l *VectorSearchLogic) VectorSearch(in *bs_rag.VectorSearchRequest) (*bs_rag.VectorSearchResponse, error) {
 	// 参数验证
 	if in.QueryText == "" {
@@ -47,29 +52,23 @@ func (l *VectorSearchLogic) VectorSearch(in *bs_rag.VectorSearchRequest) (*bs_ra
 		}, nil
 	}
 
-	// 根据scene_code获取embedding provider
-	embeddingProvider, _, err := l.svcCtx.GetEmbeddingProvider(l.ctx, in.SceneCode)
+	filter, err := buildSearchFilter(in.UserId, in.Filters, in.Filter)
 	if err != nil {
-		l.Logger.Errorf("Failed to get embedding provider for scene_code %s: %v", in.SceneCode, err)
-		return &bs_rag.VectorSearchResponse{
-			Results:      []*bs_rag.VectorSearchResult{},
-			TotalCount:   0,
-			SearchTimeMs: 0,
-		}, nil
+		l.Logger.Errorf("Invalid search filter: %v", err)
+		return nil, errorx.NewCodeErrorf(errorx.ErrCodeParamError, "invalid filter: %v", err)
 	}
 
-	// 自动生成查询向量
-	l.Logger.Infof("Generating query vector from text, length: %d, scene_code: %s", len(in.QueryText), in.SceneCode)
-	queryVector, err := embeddingProvider.GenerateEmbedding(in.QueryText)
-	if err != nil {
-		l.Logger.Errorf("Failed to generate embedding for query text: %v", err)
-		return &bs_rag.VectorSearchResponse{
-			Results:      []*bs_rag.VectorSearchResult{},
-			TotalCount:   0,
-			SearchTimeMs: 0,
-		}, nil
+	mode := searchMode(strings.ToLower(in.Mode))
+	switch mode {
+	case "":
+		mode = searchModeVector
+	case searchModeVector, searchModeKeyword, searchModeHybrid:
+	default:
+		return nil, errorx.NewCodeErrorf(errorx.ErrCodeParamError, "unsupported mode %q, available: vector, keyword, hybrid", in.Mode)
+	}
+	if mode != searchModeVector && l.svcCtx.KeywordStore == nil {
+		return nil, errorx.NewCodeErrorf(errorx.ErrCodeParamError, "mode %s requires the keyword index, enable Keyword in config", mode)
 	}
-	l.Logger.Debugf("Generated query vector, length: %d", len(queryVector))
 
 	if in.TopK <= 0 {
 		in.TopK = 10
@@ -86,27 +85,247 @@ func (l *VectorSearchLogic) VectorSearch(in *bs_rag.VectorSearchRequest) (*bs_ra
 		}, nil
 	}
 
-	// 执行搜索
-	results, err := l.svcCtx.VectorProvider.Search(l.ctx, collectionName, queryVector, int(in.TopK), float32(in.MinScore))
+	// 场景启用重排序时召回 top_k*rerank_factor 条候选，重排后再截断；获取失败时不重排
+	reranker, rerankFactor, err := l.svcCtx.GetReranker(l.ctx, in.SceneCode)
 	if err != nil {
-		return nil, err
+		l.Logger.Errorf("Failed to get reranker for scene_code %s, skip rerank: %v", in.SceneCode, err)
+	}
+	recallK := int(in.TopK)
+	if reranker != nil {
+		recallK *= rerankFactor
+	}
+
+	// hybrid 模式每路多取候选参与融合
+	candidates := recallK
+	if mode == searchModeHybrid {
+		candidates *= hybridCandidateFactor
+	}
+
+	var vectorResults []types.SearchResult
+	if mode != searchModeKeyword {
+		// 根据scene_code获取embedding provider
+		embeddingProvider, _, err := l.svcCtx.GetEmbeddingProvider(l.ctx, in.SceneCode)
+		if err != nil {
+			l.Logger.Errorf("Failed to get embedding provider for scene_code %s: %v", in.SceneCode, err)
+			return &bs_rag.VectorSearchResponse{
+				Results:      []*bs_rag.VectorSearchResult{},
+				TotalCount:   0,
+				SearchTimeMs: 0,
+			}, nil
+		}
+
+		// 自动生成查询向量
+		l.Logger.Infof("Generating query vector from text, length: %d, scene_code: %s", len(in.QueryText), in.SceneCode)
+		queryVector, err := embeddingProvider.GenerateEmbedding(l.ctx, in.QueryText)
+		if err != nil {
+			l.Logger.Errorf("Failed to generate embedding for query text: %v", err)
+			return &bs_rag.VectorSearchResponse{
+				Results:      []*bs_rag.VectorSearchResult{},
+				TotalCount:   0,
+				SearchTimeMs: 0,
+			}, nil
+		}
+		l.Logger.Debugf("Generated query vector, length: %d", len(queryVector))
+
+		// 执行搜索
+		vectorResults, err = l.svcCtx.VectorProvider.Search(l.ctx, collectionName, queryVector, candidates, float32(in.MinScore), filter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// hybrid 模式的 score 为融合得分，向量相似度单独返回，供调用方按相似度过滤
+	vectorScores := make(map[string]float32, len(vectorResults))
+	for _, result := range vectorResults {
+		vectorScores[result.ID] = result.Score
+	}
+
+	results := vectorResults
+	if mode != searchModeVector {
+		keywordResults := l.svcCtx.KeywordStore.Search(collectionName, in.QueryText, candidates, filter)
+		results = keywordResults
+		if mode == searchModeHybrid {
+			results = fuseRRF(recallK, vectorResults, keywordResults)
+		}
+		l.Logger.Infof("Search mode %s - vector results: %d, keyword results: %d, fused: %d",
+			mode, len(vectorResults), len(keywordResults), len(results))
+	}
+
+	// 再次校验过滤条件，避免向量数据库未正确执行过滤时返回其他用户的数据
+	matched := make([]types.SearchResult, 0, len(results))
+	for _, result := range results {
+		if !filter.Match(result.Metadata) {
+			l.Logger.Errorf("Vector provider returned document %s not matching filter %s", result.ID, filter)
+			continue
+		}
+		matched = append(matched, result)
+	}
+	results = matched
+
+	var rerankScores []float32
+	if reranker != nil && len(results) > 0 {
+		documents := make([]string, len(results))
+		for i, result := range results {
+			documents[i] = result.Content
+		}
+		scores, err := reranker.Rerank(l.ctx, in.QueryText, documents)
+		if err == nil && len(scores) != len(results) {
+			err = fmt.Errorf("got %d scores for %d documents", len(scores), len(results))
+		}
+		if err != nil {
+			l.Logger.Errorf("Failed to rerank %d candidates for scene_code %s, keep recall order: %v", len(results), in.SceneCode, err)
+		} else {
+			results, rerankScores = applyRerank(results, scores, float32(in.MinRerankScore))
+		}
+	}
+	if len(results) > int(in.TopK) {
+		results = results[:in.TopK]
 	}
 
 	// 转换结果
-	protoResults := make([]*bs_rag.VectorSearchResult, len(results))
+	protoResults := make([]*bs_rag.VectorSearchResult, 0, len(results))
 	for i, result := range results {
-		protoResults[i] = &bs_rag.VectorSearchResult{
-			Id:       result.ID,
-			Vector:   result.Vector,
-			Score:    result.Score,
-			Metadata: result.Metadata,
-			Content:  result.Content,
+		protoResult := &bs_rag.VectorSearchResult{
+			Id:          result.ID,
+			Vector:      result.Vector,
+			Score:       result.Score,
+			Metadata:    result.Metadata,
+			Content:     result.Content,
+			VectorScore: vectorScores[result.ID],
+		}
+		if rerankScores != nil {
+			protoResult.RerankScore = rerankScores[i]
 		}
+		protoResults = append(protoResults, protoResult)
 	}
 
 	return &bs_rag.VectorSearchResponse{
 		Results:      protoResults,
 		TotalCount:   int32(len(protoResults)),
 		SearchTimeMs: 0, // TODO: 添加实际搜索时间
+		Reranked:     rerankScores != nil,
 	}, nil
 }
+
+// applyRerank 按重排序分数降序排列候选并过滤低于 minScore 的结果，返回排序后的结果及对应的分数
+// 分数相同时保持召回顺序
+func applyRerank(results []types.SearchResult, scores []float32, minScore float32) ([]types.SearchResult, []float32) {
+	order := make([]int, 0, len(results))
+	for i := range results {
+		if scores[i] >= minScore {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool { return scores[order[a]] > scores[order[b]] })
+
+	reranked := make([]types.SearchResult, len(order))
+	rerankScores := make([]float32, len(order))
+	for i, idx := range order {
+		reranked[i] = results[idx]
+		rerankScores[i] = scores[idx]
+	}
+	return reranked, rerankScores
+}
+
+// buildSearchFilter 合并 user_id、filters 的等值条件和 filter 表达式，均为空时返回 nil
+// userId 非空时只检索该用户的文档，不依赖调用方在 filters 中指定
+func buildSearchFilter(userId string, filters map[string]string, expr *bs_rag.SearchFilter) (*types.Filter, error) {
+	fields := make([]string, 0, len(filters))
+	for field := range filters {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	conditions := make([]*types.Filter, 0, len(fields)+2)
+	if userId != "" {
+		conditions = append(conditions, types.Eq(userIdField, userId))
+	}
+	for _, field := range fields {
+		conditions = append(conditions, types.Eq(field, filters[field]))
+	}
+	if expr != nil {
+		f, err := filterFromProto(expr, 1)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, f)
+	}
+
+	filter := types.And(conditions...)
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// userIdField 文档元数据中的用户ID字段
+const userIdField = "user_id"
+
+// maxFilterDepth 过滤表达式的最大嵌套层数
+const maxFilterDepth = 8
+
+func filterFromProto(in *bs_rag.SearchFilter, depth int) (*types.Filter, error) {
+	if depth > maxFilterDepth {
+		return nil, fmt.Errorf("filter nesting exceeds %d levels", maxFilterDepth)
+	}
+	f := &types.Filter{
+		Op:     types.FilterOp(strings.ToLower(in.Op)),
+		Field:  in.Field,
+		Value:  in.Value,
+		Values: in.Values,
+	}
+	for _, child := range in.Children {
+		c, err := filterFromProto(child, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		f.Children = append(f.Children, c)
+	}
+	return f, nil
+}
+
+// searchMode 检索模式
+type searchMode string
+
+const (
+	searchModeVector  searchMode = "vector"  // 向量相似度
+	searchModeKeyword searchMode = "keyword" // BM25 关键词
+	searchModeHybrid  searchMode = "hybrid"  // 两路结果按倒数排名融合
+)
+
+const (
+	// hybridCandidateFactor hybrid 模式每路候选数为 top_k 的倍数
+	hybridCandidateFactor = 3
+	// rrfK 倒数排名融合的平滑常数，取常用的60
+	rrfK = 60
+)
+
+// fuseRRF 按倒数排名融合多路结果，score = Σ 1/(rrfK + rank)，rank 从1开始
+// 文档的向量、元数据和内容取首次出现的结果，得分相同时保持先出现的顺序
+func fuseRRF(topK int, lists ...[]types.SearchResult) []types.SearchResult {
+	fused := make(map[string]*types.SearchResult)
+	var order []string
+	for _, list := range lists {
+		for rank, r := range list {
+			score := float32(1.0 / float64(rrfK+rank+1))
+			if f, ok := fused[r.ID]; ok {
+				f.Score += score
+				continue
+			}
+			result := r
+			result.Score = score
+			fused[r.ID] = &result
+			order = append(order, r.ID)
+		}
+	}
+
+	results := make([]types.SearchResult, len(order))
+	for i, id := range order {
+		results[i] = *fused[id]
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	if len(results) > topK {
+		results = results[:topK]
+	}
+	return results
+}
@@ -0,0 +1,80 @@
+package logic
+
+import (
+	"testing"
+
+	"jxzy/bs/bs_rag/bs_rag"
+	"jxzy/bs/bs_rag/internal/provider/vectorstore/types"
+)
+
+func TestFuseRRF(t *testing.T) {
+	vector := []types.SearchResult{{ID: "a", Score: 0.9, Vector: []float32{1}}, {ID: "b", Score: 0.8}, {ID: "c", Score: 0.7}}
+	keyword := []types.SearchResult{{ID: "c", Score: 12}, {ID: "d", Score: 8}, {ID: "a", Score: 3}}
+
+	results := fuseRRF(3, vector, keyword)
+	ids := make([]string, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	// a: 1/61+1/63, c: 1/63+1/61, b: 1/62, d: 1/62；同分保持先出现的顺序
+	if len(ids) != 3 || ids[0] != "a" || ids[1] != "c" || ids[2] != "b" {
+		t.Fatalf("Unexpected fused order: %v", ids)
+	}
+	if score := results[0].Score; score < 1.0/61+1.0/63-1e-6 || score > 1.0/61+1.0/63+1e-6 || len(results[0].Vector) != 1 {
+		t.Errorf("Unexpected fused result: %+v", results[0])
+	}
+}
+
+func TestBuildSearchFilter(t *testing.T) {
+	filter, err := buildSearchFilter("", map[string]string{"user_id": "u1", "type": "doc"}, &bs_rag.SearchFilter{
+		Op: "OR",
+		Children: []*bs_rag.SearchFilter{
+			{Op: "in", Field: "file_id", Values: []string{"1", "2"}},
+			{Op: "gte", Field: "size", Value: "10"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("buildSearchFilter failed: %v", err)
+	}
+	if filter.String() != `(type eq "doc" and user_id eq "u1" and (file_id in ["1" "2"] or size gte "10"))` {
+		t.Errorf("Unexpected filter: %s", filter)
+	}
+	if filter, _ := buildSearchFilter("", nil, nil); filter != nil {
+		t.Errorf("Expected nil filter, got %s", filter)
+	}
+
+	// user_id 与调用方的过滤条件同时满足
+	filter, err = buildSearchFilter("u1", map[string]string{"type": "doc"}, nil)
+	if err != nil || filter.String() != `(user_id eq "u1" and type eq "doc")` {
+		t.Errorf("Expected user scoped filter, got %s, %v", filter, err)
+	}
+
+	nested := &bs_rag.SearchFilter{Op: "eq", Field: "a", Value: "1"}
+	for i := 0; i < maxFilterDepth; i++ {
+		nested = &bs_rag.SearchFilter{Op: "and", Children: []*bs_rag.SearchFilter{nested}}
+	}
+	if _, err := buildSearchFilter("", nil, nested); err == nil {
+		t.Error("Expected error for deeply nested filter")
+	}
+	if _, err := buildSearchFilter("", nil, &bs_rag.SearchFilter{Op: "like", Field: "a"}); err == nil {
+		t.Error("Expected error for unsupported op")
+	}
+}
+
+func TestApplyRerank(t *testing.T) {
+	results := []types.SearchResult{{ID: "a", Score: 0.9}, {ID: "b", Score: 0.8}, {ID: "c", Score: 0.7}, {ID: "d", Score: 0.6}}
+	scores := []float32{0.2, 0.9, 0.05, 0.9}
+
+	reranked, rerankScores := applyRerank(results, scores, 0.1)
+	ids := make([]string, len(reranked))
+	for i, r := range reranked {
+		ids[i] = r.ID
+	}
+	// b、d 同分保持召回顺序，c 低于最小分数被过滤，召回分数保留
+	if len(ids) != 3 || ids[0] != "b" || ids[1] != "d" || ids[2] != "a" {
+		t.Fatalf("Unexpected reranked order: %v", ids)
+	}
+	if rerankScores[0] != 0.9 || rerankScores[2] != 0.2 || reranked[0].Score != 0.8 {
+		t.Errorf("Unexpected scores: %v %+v", rerankScores, reranked[0])
+	}
+}
@@ -46,6 +46,9 @@ type (
 		ModelName       string    `db:"model_name"`
 		VectorDimension int64     `db:"vector_dimension"`
 		CollectionName  string    `db:"collection_name"`
+		RerankProvider  string    `db:"rerank_provider"`
+		RerankModel     string    `db:"rerank_model"`
+		RerankFactor    int64     `db:"rerank_factor"`
 		Deleted         int64     `db:"deleted"`
 		CreatedAt       time.Time `db:"created_at"`
 		UpdatedAt       time.Time `db:"updated_at"`
@@ -94,14 +97,14 @@ func (m *defaultEmbeddingSceneModel) FindOneBySceneCode(ctx context.Context, sce
 }
 
 func (m *defaultEmbeddingSceneModel) Insert(ctx context.Context, data *EmbeddingScene) (sql.Result, error) {
-	query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?, ?, ?, ?, ?, ?)", m.table, embeddingSceneRowsExpectAutoSet)
-	ret, err := m.conn.ExecCtx(ctx, query, data.SceneCode, data.SceneName, data.ProviderCode, data.ProviderName, data.ModelCode, data.ModelName, data.VectorDimension, data.CollectionName, data.Deleted)
+	query := fmt.Sprintf("insert into %s (%s) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", m.table, embeddingSceneRowsExpectAutoSet)
+	ret, err := m.conn.ExecCtx(ctx, query, data.SceneCode, data.SceneName, data.ProviderCode, data.ProviderName, data.ModelCode, data.ModelName, data.VectorDimension, data.CollectionName, data.RerankProvider, data.RerankModel, data.RerankFactor, data.Deleted)
 	return ret, err
 }
 
 func (m *defaultEmbeddingSceneModel) Update(ctx context.Context, newData *EmbeddingScene) error {
 	query := fmt.Sprintf("update %s set %s where `id` = ?", m.table, embeddingSceneRowsWithPlaceHolder)
-	_, err := m.conn.ExecCtx(ctx, query, newData.SceneCode, newData.SceneName, newData.ProviderCode, newData.ProviderName, newData.ModelCode, newData.ModelName, newData.VectorDimension, newData.CollectionName, newData.Deleted, newData.Id)
+	_, err := m.conn.ExecCtx(ctx, query, newData.SceneCode, newData.SceneName, newData.ProviderCode, newData.ProviderName, newData.ModelCode, newData.ModelName, newData.VectorDimension, newData.CollectionName, newData.RerankProvider, newData.RerankModel, newData.RerankFactor, newData.Deleted, newData.Id)
 	return err
 }
 
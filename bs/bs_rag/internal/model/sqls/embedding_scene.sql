@@ -3,14 +3,23 @@ CREATE TABLE embedding_scene (
     id INT AUTO_INCREMENT PRIMARY KEY COMMENT '主键ID',
     scene_code VARCHAR(50) NOT NULL DEFAULT '' COMMENT '场景编码（上游调用时使用的标识）',
     scene_name VARCHAR(100) NOT NULL DEFAULT '' COMMENT '场景名称',
-    provider_code VARCHAR(50) NOT NULL DEFAULT '' COMMENT 'embedding提供商编码（如bailian等）',
+    provider_code VARCHAR(50) NOT NULL DEFAULT 'bs_llm' COMMENT 'embedding提供商编码（bs_llm-通过bs_llm调用，bailian-直连百炼）',
     provider_name VARCHAR(100) NOT NULL DEFAULT '' COMMENT 'embedding提供商名称',
     model_code VARCHAR(50) NOT NULL DEFAULT '' COMMENT 'embedding模型编码（如text-embedding-v4等）',
     model_name VARCHAR(100) NOT NULL DEFAULT '' COMMENT 'embedding模型名称',
     vector_dimension INT NOT NULL DEFAULT 1024 COMMENT 'embedding向量维度',
     collection_name VARCHAR(100) NOT NULL DEFAULT '' COMMENT '向量数据库集合名称',
+    rerank_provider VARCHAR(50) NOT NULL DEFAULT '' COMMENT '重排序提供商（bailian-百炼gte-rerank，lexical-本地词重叠，空表示不重排）',
+    rerank_model VARCHAR(50) NOT NULL DEFAULT '' COMMENT '重排序模型编码（如gte-rerank-v2，lexical不需要）',
+    rerank_factor INT NOT NULL DEFAULT 3 COMMENT '重排序候选倍数，召回top_k*rerank_factor条候选后重排',
     deleted TINYINT NOT NULL DEFAULT 0 COMMENT '是否删除（1-删除，0-未删除）',
     created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
     updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
     unique key uk_scene_code (scene_code)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='embedding场景映射表（关联场景与对应的embedding提供商及模型）';
+
+-- 已有表升级
+-- ALTER TABLE embedding_scene ADD COLUMN rerank_provider VARCHAR(50) NOT NULL DEFAULT '' COMMENT '重排序提供商（bailian-百炼gte-rerank，lexical-本地词重叠，空表示不重排）' AFTER collection_name;
+-- ALTER TABLE embedding_scene ADD COLUMN rerank_model VARCHAR(50) NOT NULL DEFAULT '' COMMENT '重排序模型编码（如gte-rerank-v2，lexical不需要）' AFTER rerank_provider;
+-- ALTER TABLE embedding_scene ADD COLUMN rerank_factor INT NOT NULL DEFAULT 3 COMMENT '重排序候选倍数，召回top_k*rerank_factor条候选后重排' AFTER rerank_model;
+-- ALTER TABLE embedding_scene ALTER COLUMN provider_code SET DEFAULT 'bs_llm';
@@ -2,6 +2,7 @@ package bailian
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,11 +10,18 @@ import (
 	"os"
 	"time"
 
+	etypes "jxzy/bs/bs_rag/internal/provider/embedding/types"
 	consts "jxzy/common/const"
 
 	"github.com/zeromicro/go-zero/core/logx"
 )
 
+const (
+	// maxBatchSize 百炼 text-embedding-v3/v4 单次最多10条文本
+	maxBatchSize = 10
+	apiEndpoint  = "https://dashscope.aliyuncs.com/api/v1/services/embeddings/text-embedding/text-embedding"
+)
+
 // Provider 阿里云百炼嵌入模型实现
 type Provider struct {
 	logger          logx.Logger
@@ -39,15 +47,36 @@ func NewBailianEmbeddingProvider(apiKey string, modelCode string, vectorDimensio
 }
 
 // GenerateEmbedding 生成文本的向量表示
-func (p *Provider) GenerateEmbedding(text string) ([]float32, error) {
-	return p.callBailianEmbeddingAPI(text)
+func (p *Provider) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
+	embeddings, err := p.callBailianEmbeddingAPI(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	p.logger.Debugf("Generated embedding vector for text '%s': length=%d", text, len(embeddings[0]))
+	return embeddings[0], nil
 }
 
-func (p *Provider) callBailianEmbeddingAPI(text string) ([]float32, error) {
+// GenerateEmbeddings 批量生成向量，一次请求最多 maxBatchSize 条
+func (p *Provider) GenerateEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) > maxBatchSize {
+		return nil, fmt.Errorf("batch size %d exceeds limit %d", len(texts), maxBatchSize)
+	}
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+	return p.callBailianEmbeddingAPI(ctx, texts)
+}
+
+func (p *Provider) MaxBatchSize() int {
+	return maxBatchSize
+}
+
+// callBailianEmbeddingAPI 返回的向量按 text_index 还原为 texts 的顺序
+func (p *Provider) callBailianEmbeddingAPI(ctx context.Context, texts []string) ([][]float32, error) {
 	requestBody := map[string]interface{}{
 		"model": p.modelCode,
 		"input": map[string]interface{}{
-			"texts": []string{text},
+			"texts": texts,
 		},
 		"parameters": map[string]interface{}{
 			"dimensions": p.vectorDimension,
@@ -59,7 +88,7 @@ func (p *Provider) callBailianEmbeddingAPI(text string) ([]float32, error) {
 		return nil, fmt.Errorf("marshal request body failed: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", "https://dashscope.aliyuncs.com/api/v1/services/embeddings/text-embedding/text-embedding", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", apiEndpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("create request failed: %w", err)
 	}
@@ -82,12 +111,13 @@ func (p *Provider) callBailianEmbeddingAPI(text string) ([]float32, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
+		return nil, &etypes.StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	var response struct {
 		Output struct {
 			Embeddings []struct {
+				TextIndex int       `json:"text_index"`
 				Embedding []float32 `json:"embedding"`
 			} `json:"embeddings"`
 		} `json:"output"`
@@ -97,13 +127,18 @@ func (p *Provider) callBailianEmbeddingAPI(text string) ([]float32, error) {
 		return nil, fmt.Errorf("parse response failed: %w", err)
 	}
 
-	if len(response.Output.Embeddings) == 0 {
-		return nil, fmt.Errorf("no embeddings in response")
+	if len(response.Output.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("got %d embeddings for %d texts", len(response.Output.Embeddings), len(texts))
 	}
 
-	embedding := response.Output.Embeddings[0].Embedding
-	p.logger.Debugf("Generated embedding vector for text '%s': length=%d", text, len(embedding))
-	return embedding, nil
+	embeddings := make([][]float32, len(texts))
+	for _, e := range response.Output.Embeddings {
+		if e.TextIndex < 0 || e.TextIndex >= len(texts) || embeddings[e.TextIndex] != nil {
+			return nil, fmt.Errorf("invalid text_index %d in response", e.TextIndex)
+		}
+		embeddings[e.TextIndex] = e.Embedding
+	}
+	return embeddings, nil
 }
 
 func (p *Provider) getBailianAPIKey() string {
@@ -0,0 +1,93 @@
+package batch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	etypes "jxzy/bs/bs_rag/internal/provider/embedding/types"
+)
+
+// Options 批量向量化参数
+type Options struct {
+	Concurrency   int           // 同时调用的批次数，小于1时按1处理
+	MaxRetries    int           // 批次失败后的重试次数
+	RetryInterval time.Duration // 首次重试间隔，之后每次翻倍
+}
+
+// Result 单条文本的向量化结果，Err 不为 nil 时 Vector 为空
+type Result struct {
+	Vector []float32
+	Err    error
+}
+
+// Embed 按 provider 的 MaxBatchSize 切分 texts，最多 Concurrency 个批次同时调用，临时错误按指数退避重试
+// 批次返回参数错误时逐条调用一次，使一条文本出错不影响同批的其他文本；返回结果与 texts 一一对应
+func Embed(ctx context.Context, provider etypes.EmbeddingProvider, texts []string, opts Options) []Result {
+	results := make([]Result, len(texts))
+	size := provider.MaxBatchSize()
+	if size < 1 {
+		size = 1
+	}
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for start := 0; start < len(texts); start += size {
+		end := start + size
+		if end > len(texts) {
+			end = len(texts)
+		}
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(start, end int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			embedBatch(ctx, provider, texts[start:end], results[start:end], opts)
+		}(start, end)
+	}
+	wg.Wait()
+	return results
+}
+
+func embedBatch(ctx context.Context, provider etypes.EmbeddingProvider, texts []string, results []Result, opts Options) {
+	vectors, err := withRetry(ctx, opts, func() ([][]float32, error) {
+		return provider.GenerateEmbeddings(ctx, texts)
+	})
+	if err == nil {
+		for i, v := range vectors {
+			results[i] = Result{Vector: v}
+		}
+		return
+	}
+	// 鉴权失败、重试后仍失败的临时错误等对每条文本都一样，不再逐条调用
+	if len(texts) == 1 || ctx.Err() != nil || !etypes.IsInvalidInput(err) {
+		for i := range results {
+			results[i] = Result{Err: err}
+		}
+		return
+	}
+	for i, text := range texts {
+		v, err := provider.GenerateEmbedding(ctx, text)
+		results[i] = Result{Vector: v, Err: err}
+	}
+}
+
+func withRetry(ctx context.Context, opts Options, call func() ([][]float32, error)) ([][]float32, error) {
+	interval := opts.RetryInterval
+	for attempt := 0; ; attempt++ {
+		vectors, err := call()
+		if err == nil || attempt >= opts.MaxRetries || !etypes.IsTransient(err) {
+			return vectors, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(interval):
+		}
+		interval *= 2
+	}
+}
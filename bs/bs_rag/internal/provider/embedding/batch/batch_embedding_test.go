@@ -0,0 +1,121 @@
+package batch
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	etypes "jxzy/bs/bs_rag/internal/provider/embedding/types"
+)
+
+// fakeProvider 向量为文本长度，文本为 "bad" 时整批返回参数错误，前 failFirst 次调用返回 failErr
+type fakeProvider struct {
+	batchSize int
+	failFirst int32
+	failErr   error
+	calls     int32
+	running   int32
+	peak      int32
+	mu        sync.Mutex
+	sizes     []int
+}
+
+func (p *fakeProvider) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
+	vectors, err := p.GenerateEmbeddings(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return vectors[0], nil
+}
+
+func (p *fakeProvider) GenerateEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	running := atomic.AddInt32(&p.running, 1)
+	defer atomic.AddInt32(&p.running, -1)
+	for {
+		peak := atomic.LoadInt32(&p.peak)
+		if running <= peak || atomic.CompareAndSwapInt32(&p.peak, peak, running) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	p.mu.Lock()
+	p.sizes = append(p.sizes, len(texts))
+	p.mu.Unlock()
+	if atomic.AddInt32(&p.calls, 1) <= p.failFirst {
+		return nil, p.failErr
+	}
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		if text == "bad" {
+			return nil, &etypes.StatusError{StatusCode: http.StatusBadRequest, Body: "invalid text"}
+		}
+		vectors[i] = []float32{float32(len(text))}
+	}
+	return vectors, nil
+}
+
+func (p *fakeProvider) MaxBatchSize() int {
+	return p.batchSize
+}
+
+func TestEmbedBatches(t *testing.T) {
+	p := &fakeProvider{batchSize: 3}
+	texts := []string{"a", "bb", "ccc", "dddd", "eeeee", "ffffff", "g"}
+	results := Embed(context.Background(), p, texts, Options{Concurrency: 2})
+
+	for i, r := range results {
+		if r.Err != nil || len(r.Vector) != 1 || int(r.Vector[0]) != len(texts[i]) {
+			t.Fatalf("Unexpected result %d: %+v", i, r)
+		}
+	}
+	if len(p.sizes) != 3 {
+		t.Errorf("Expected 3 batches, got %v", p.sizes)
+	}
+	if p.peak > 2 {
+		t.Errorf("Concurrency exceeded limit: %d", p.peak)
+	}
+}
+
+func TestEmbedRetry(t *testing.T) {
+	p := &fakeProvider{batchSize: 10, failFirst: 2, failErr: &etypes.StatusError{StatusCode: http.StatusServiceUnavailable}}
+	results := Embed(context.Background(), p, []string{"a", "bb"}, Options{MaxRetries: 2, RetryInterval: time.Millisecond})
+	if results[0].Err != nil || results[1].Err != nil {
+		t.Fatalf("Expected success after retries: %+v", results)
+	}
+	if p.calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", p.calls)
+	}
+}
+
+func TestEmbedIsolatesFailedText(t *testing.T) {
+	p := &fakeProvider{batchSize: 10}
+	results := Embed(context.Background(), p, []string{"a", "bad", "ccc"}, Options{MaxRetries: 1, RetryInterval: time.Millisecond})
+	if results[0].Err != nil || results[2].Err != nil || int(results[2].Vector[0]) != 3 {
+		t.Errorf("Expected other texts to succeed: %+v", results)
+	}
+	if results[1].Err == nil {
+		t.Error("Expected failure for bad text")
+	}
+	// 参数错误不重试：整批1次 + 逐条3次
+	if p.calls != 4 {
+		t.Errorf("Expected 4 calls, got %d", p.calls)
+	}
+}
+
+func TestEmbedPermanentFailure(t *testing.T) {
+	// 鉴权失败不重试，也不逐条调用
+	p := &fakeProvider{batchSize: 10, failFirst: 10, failErr: &etypes.StatusError{StatusCode: http.StatusUnauthorized}}
+	results := Embed(context.Background(), p, []string{"a", "bb", "ccc"}, Options{MaxRetries: 2, RetryInterval: time.Millisecond})
+	for i, r := range results {
+		if r.Err == nil {
+			t.Errorf("Expected failure for text %d", i)
+		}
+	}
+	if p.calls != 1 {
+		t.Errorf("Expected 1 call, got %d", p.calls)
+	}
+}
@@ -0,0 +1,86 @@
+package bsllm
+
+import (
+	"context"
+	"fmt"
+
+	"jxzy/bs/bs_llm/bsllmservice"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// maxBatchSize 单次 Embed 请求的文本数，bs_llm 按供应商上限再分批
+const maxBatchSize = 32
+
+// Provider 通过 bs_llm 的 Embed 接口向量化，供应商凭证、用量和耗时由 bs_llm 统一管理和记录
+// bs_llm 中需要配置与 embedding_scene.scene_code 同名的场景，场景的模型为向量模型
+type Provider struct {
+	logger          logx.Logger
+	client          bsllmservice.BsLlmService
+	sceneCode       string
+	vectorDimension int64
+}
+
+// NewBsLlmEmbeddingProvider 构造函数
+func NewBsLlmEmbeddingProvider(client bsllmservice.BsLlmService, sceneCode string, vectorDimension int64) *Provider {
+	return &Provider{
+		logger:          logx.WithContext(context.Background()),
+		client:          client,
+		sceneCode:       sceneCode,
+		vectorDimension: vectorDimension,
+	}
+}
+
+// GenerateEmbedding 生成文本的向量表示
+func (p *Provider) GenerateEmbedding(ctx context.Context, text string) ([]float32, error) {
+	resp, err := p.client.Embed(ctx, &bsllmservice.EmbedRequest{
+		SceneCode:  p.sceneCode,
+		Texts:      []string{text},
+		Dimensions: p.vectorDimension,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bs_llm embed failed: %w", err)
+	}
+	if len(resp.Embeddings) == 0 {
+		return nil, fmt.Errorf("no embeddings in response")
+	}
+
+	embedding := resp.Embeddings[0].Values
+	p.logger.Debugf("Generated embedding vector via bs_llm - SceneCode: %s, Model: %s, Length: %d", p.sceneCode, resp.ModelId, len(embedding))
+	return embedding, nil
+}
+
+// GenerateEmbeddings 通过一次 Embed 请求批量生成向量
+func (p *Provider) GenerateEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) > maxBatchSize {
+		return nil, fmt.Errorf("batch size %d exceeds limit %d", len(texts), maxBatchSize)
+	}
+	if len(texts) == 0 {
+		return [][]float32{}, nil
+	}
+	resp, err := p.client.Embed(ctx, &bsllmservice.EmbedRequest{
+		SceneCode:  p.sceneCode,
+		Texts:      texts,
+		Dimensions: p.vectorDimension,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bs_llm embed failed: %w", err)
+	}
+	if len(resp.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("got %d embeddings for %d texts", len(resp.Embeddings), len(texts))
+	}
+
+	embeddings := make([][]float32, len(texts))
+	for _, e := range resp.Embeddings {
+		if e.Index < 0 || int(e.Index) >= len(texts) || embeddings[e.Index] != nil {
+			return nil, fmt.Errorf("invalid embedding index %d in response", e.Index)
+		}
+		embeddings[e.Index] = e.Values
+	}
+	p.logger.Debugf("Generated %d embedding vectors via bs_llm - SceneCode: %s, Model: %s", len(texts), p.sceneCode, resp.ModelId)
+	return embeddings, nil
+}
+
+func (p *Provider) MaxBatchSize() int {
+	return maxBatchSize
+}
@@ -2,8 +2,10 @@ package factory
 
 import (
 	"fmt"
+	"jxzy/bs/bs_llm/bsllmservice"
 	"jxzy/bs/bs_rag/internal/config"
 	bailian "jxzy/bs/bs_rag/internal/provider/embedding/bailian"
+	bsllm "jxzy/bs/bs_rag/internal/provider/embedding/bsllm"
 	etypes "jxzy/bs/bs_rag/internal/provider/embedding/types"
 )
 
@@ -17,6 +19,13 @@ type BailianProviderConfig struct {
 	VectorDimension int64
 }
 
+// BsLlmProviderConfig bs_llm provider配置
+type BsLlmProviderConfig struct {
+	Client          bsllmservice.BsLlmService
+	SceneCode       string // bs_llm 中的场景编码
+	VectorDimension int64
+}
+
 // NewProvider 根据类型与配置创建嵌入模型提供者
 func (f *EmbeddingProviderFactory) NewProvider(t etypes.EmbeddingProviderType, cfg interface{}) (etypes.EmbeddingProvider, error) {
 	switch t {
@@ -37,6 +46,12 @@ func (f *EmbeddingProviderFactory) NewProvider(t etypes.EmbeddingProviderType, c
 			}
 		}
 		return bailian.NewBailianEmbeddingProvider(c.APIKey, c.ModelCode, c.VectorDimension), nil
+	case etypes.EmbeddingProviderTypeBsLlm:
+		c, ok := cfg.(BsLlmProviderConfig)
+		if !ok || c.Client == nil {
+			return nil, fmt.Errorf("invalid config type for bs_llm embedding provider")
+		}
+		return bsllm.NewBsLlmEmbeddingProvider(c.Client, c.SceneCode, c.VectorDimension), nil
 	default:
 		return nil, fmt.Errorf("unsupported embedding provider type: %s", t)
 	}
@@ -1,17 +1,23 @@
 package types
 
+import "context"
+
 // EmbeddingProvider 向量化提供者接口
 type EmbeddingProvider interface {
-    // GenerateEmbedding 根据文本生成向量表示
-    GenerateEmbedding(text string) ([]float32, error)
+	// GenerateEmbedding 根据文本生成向量表示
+	GenerateEmbedding(ctx context.Context, text string) ([]float32, error)
+	// GenerateEmbeddings 批量生成向量，返回顺序与 texts 一致，texts 数量不超过 MaxBatchSize
+	GenerateEmbeddings(ctx context.Context, texts []string) ([][]float32, error)
+	// MaxBatchSize 单次调用允许的最大文本数
+	MaxBatchSize() int
 }
 
 // EmbeddingProviderType 枚举可用的嵌入模型提供者
 type EmbeddingProviderType string
 
 const (
-    // EmbeddingProviderTypeBailian 阿里云百炼嵌入模型
-    EmbeddingProviderTypeBailian EmbeddingProviderType = "bailian"
+	// EmbeddingProviderTypeBailian 阿里云百炼嵌入模型
+	EmbeddingProviderTypeBailian EmbeddingProviderType = "bailian"
+	// EmbeddingProviderTypeBsLlm 通过 bs_llm 的 Embed 接口调用，统一记录用量
+	EmbeddingProviderTypeBsLlm EmbeddingProviderType = "bs_llm"
 )
-
-
@@ -0,0 +1,196 @@
+package keyword
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	vtypes "jxzy/bs/bs_rag/internal/provider/vectorstore/types"
+)
+
+// Document 写入关键词索引的文档，Text 为参与分词的文本
+type Document struct {
+	ID       string
+	Text     string
+	Metadata map[string]string
+	Content  string
+}
+
+// indexedDocument 已分词的文档
+type indexedDocument struct {
+	Metadata map[string]string
+	Content  string
+	Terms    map[string]int // 词 -> 词频
+	Length   int
+}
+
+// index 单个集合的 BM25 倒排索引，读写由 mu 保护
+type index struct {
+	mu          sync.RWMutex
+	name        string
+	docs        map[string]*indexedDocument
+	postings    map[string]map[string]int // 词 -> 文档ID -> 词频
+	totalLength int
+
+	version uint64 // 每次写入递增，新建集合从1开始
+	saved   uint64 // 最近一次快照对应的版本
+}
+
+// indexSnapshot 集合快照，倒排表在加载时由文档重建
+type indexSnapshot struct {
+	Version int
+	Name    string
+	Docs    map[string]*indexedDocument
+}
+
+func newIndex(name string) *index {
+	return &index{
+		name:     name,
+		docs:     make(map[string]*indexedDocument),
+		postings: make(map[string]map[string]int),
+		version:  1,
+	}
+}
+
+// upsert 写入文档，ID 已存在时覆盖
+func (x *index) upsert(documents []Document) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	for _, doc := range documents {
+		x.remove(doc.ID)
+		terms := make(map[string]int)
+		tokens := Tokenize(doc.Text)
+		for _, t := range tokens {
+			terms[t]++
+		}
+		x.add(doc.ID, &indexedDocument{
+			Metadata: doc.Metadata,
+			Content:  doc.Content,
+			Terms:    terms,
+			Length:   len(tokens),
+		})
+	}
+	x.version++
+}
+
+// delete 删除文档，不存在的ID忽略
+func (x *index) delete(documentIDs []string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+
+	for _, id := range documentIDs {
+		x.remove(id)
+	}
+	x.version++
+}
+
+func (x *index) add(id string, doc *indexedDocument) {
+	x.docs[id] = doc
+	x.totalLength += doc.Length
+	for term, tf := range doc.Terms {
+		postings, ok := x.postings[term]
+		if !ok {
+			postings = make(map[string]int)
+			x.postings[term] = postings
+		}
+		postings[id] = tf
+	}
+}
+
+func (x *index) remove(id string) {
+	doc, ok := x.docs[id]
+	if !ok {
+		return
+	}
+	for term := range doc.Terms {
+		delete(x.postings[term], id)
+		if len(x.postings[term]) == 0 {
+			delete(x.postings, term)
+		}
+	}
+	x.totalLength -= doc.Length
+	delete(x.docs, id)
+}
+
+// search 按 BM25 返回得分最高的 topK 个满足 filter 的文档，得分相同时按ID排序
+func (x *index) search(query string, topK int, filter *vtypes.Filter, k1, b float64) []vtypes.SearchResult {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	if len(x.docs) == 0 {
+		return nil
+	}
+	n := float64(len(x.docs))
+	avgLength := float64(x.totalLength) / n
+
+	scores := make(map[string]float64)
+	seen := make(map[string]bool)
+	for _, term := range Tokenize(query) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		postings := x.postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+		df := float64(len(postings))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, tf := range postings {
+			length := float64(x.docs[id].Length)
+			f := float64(tf)
+			scores[id] += idf * f * (k1 + 1) / (f + k1*(1-b+b*length/avgLength))
+		}
+	}
+
+	results := make([]vtypes.SearchResult, 0, len(scores))
+	for id, score := range scores {
+		doc := x.docs[id]
+		if !filter.Match(doc.Metadata) {
+			continue
+		}
+		results = append(results, vtypes.SearchResult{
+			ID:       id,
+			Score:    float32(score),
+			Metadata: doc.Metadata,
+			Content:  doc.Content,
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+	if len(results) > topK {
+		results = results[:topK]
+	}
+	return results
+}
+
+// Snapshot 有未保存的修改时持有读锁编码快照
+func (x *index) Snapshot(encode func(data interface{}) error) (uint64, bool, error) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	if x.version == x.saved {
+		return x.version, false, nil
+	}
+	return x.version, true, encode(&indexSnapshot{Version: snapshotVersion, Name: x.name, Docs: x.docs})
+}
+
+// MarkSaved 记录最近一次快照对应的版本
+func (x *index) MarkSaved(version uint64) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.saved = version
+}
+
+func restoreIndex(s *indexSnapshot) *index {
+	x := newIndex(s.Name)
+	for id, doc := range s.Docs {
+		x.add(id, doc)
+	}
+	x.saved = x.version
+	return x
+}
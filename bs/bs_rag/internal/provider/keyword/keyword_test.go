@@ -0,0 +1,103 @@
+package keyword
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"jxzy/bs/bs_rag/internal/config"
+	vtypes "jxzy/bs/bs_rag/internal/provider/vectorstore/types"
+)
+
+func TestTokenize(t *testing.T) {
+	cases := map[string][]string{
+		"报错码E-1023怎么处理": {"报错", "错码", "e-1023", "e", "1023", "怎么", "么处", "处理"},
+		"升级到 v2.1.0 版本": {"升级", "级到", "v2.1.0", "v2", "1", "0", "版本"},
+		"Hello, World!": {"hello", "world"},
+		"型号ABC123。":     {"型号", "abc123"},
+		"a-":            {"a"},
+		"":              nil,
+	}
+	for text, expected := range cases {
+		if got := Tokenize(text); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Tokenize(%q) = %q, expected %q", text, got, expected)
+		}
+	}
+}
+
+func newTestStore(t *testing.T, dir string) *Store {
+	t.Helper()
+	s, err := NewStore(config.KeywordConfig{IndexPath: dir, K1: 1.2, B: 0.75})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	return s
+}
+
+func TestSearch(t *testing.T) {
+	s := newTestStore(t, t.TempDir())
+	s.Insert("kb", []Document{
+		{ID: "1", Text: "打印机出现错误码E-1023时请检查纸盒", Metadata: map[string]string{"user_id": "u1"}, Content: "纸盒"},
+		{ID: "2", Text: "打印机出现错误码E-2048时请重启设备", Metadata: map[string]string{"user_id": "u1"}},
+		{ID: "3", Text: "错误码说明：错误码用于定位打印机问题", Metadata: map[string]string{"user_id": "u2"}},
+		{ID: "4", Text: "今天天气很好", Metadata: map[string]string{"user_id": "u1"}},
+	})
+
+	// 完整编号命中的文档排在只命中编号前缀的文档之前
+	results := s.Search("kb", "E-1023 怎么办", 10, nil)
+	if len(results) != 2 || results[0].ID != "1" || results[0].Content != "纸盒" || results[0].Score < 2*results[1].Score {
+		t.Fatalf("Expected exact code match first, got %+v", results)
+	}
+
+	// 词频高的文档得分高
+	results = s.Search("kb", "错误码", 10, nil)
+	if len(results) != 3 || results[0].ID != "3" {
+		t.Errorf("Unexpected ranking: %+v", results)
+	}
+	results = s.Search("kb", "错误码", 10, vtypes.Eq("user_id", "u1"))
+	if len(results) != 2 || results[0].ID != "1" || results[1].ID != "2" {
+		t.Errorf("Unexpected filtered results: %+v", results)
+	}
+	if results := s.Search("kb", "错误码", 1, nil); len(results) != 1 {
+		t.Errorf("Expected topK to limit results, got %d", len(results))
+	}
+
+	// 覆盖写入和删除
+	s.Insert("kb", []Document{{ID: "1", Text: "纸盒已更换"}})
+	if results := s.Search("kb", "1023", 10, nil); len(results) != 0 {
+		t.Errorf("Expected overwritten document to be reindexed, got %+v", results)
+	}
+	s.Delete("kb", []string{"2", "missing"})
+	if results := s.Search("kb", "E-2048", 10, nil); len(results) != 0 {
+		t.Errorf("Expected deleted document to be removed, got %+v", results)
+	}
+	if s.Count("kb") != 3 || s.Count("missing") != 0 {
+		t.Errorf("Unexpected count: %d", s.Count("kb"))
+	}
+	if results := s.Search("missing", "错误码", 10, nil); len(results) != 0 {
+		t.Errorf("Expected empty results for missing collection")
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	dir := t.TempDir()
+	s := newTestStore(t, dir)
+	s.config.SnapshotInterval = 3600
+	for i := 0; i < 20; i++ {
+		s.Insert("a/b", []Document{{ID: fmt.Sprint(i), Text: fmt.Sprintf("产品编号 SKU-%d 说明", i), Metadata: map[string]string{"n": fmt.Sprint(i)}}})
+	}
+	s.Delete("a/b", []string{"3"})
+	expected := s.Search("a/b", "产品 SKU-5", 5, nil)
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	reloaded := newTestStore(t, dir)
+	if reloaded.Count("a/b") != 19 {
+		t.Errorf("Expected 19 documents after reload, got %d", reloaded.Count("a/b"))
+	}
+	actual := reloaded.Search("a/b", "产品 SKU-5", 5, nil)
+	if !reflect.DeepEqual(actual, expected) || actual[0].ID != "5" || actual[0].Metadata["n"] != "5" {
+		t.Errorf("Search results changed after reload:\n%+v\n%+v", expected, actual)
+	}
+}
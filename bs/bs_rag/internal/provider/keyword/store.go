@@ -0,0 +1,154 @@
+package keyword
+
+import (
+	"encoding/gob"
+	"fmt"
+	"sync"
+	"time"
+
+	"jxzy/bs/bs_rag/internal/config"
+	"jxzy/bs/bs_rag/internal/provider/snapshot"
+	vtypes "jxzy/bs/bs_rag/internal/provider/vectorstore/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+const (
+	// snapshotVersion 快照格式版本，格式不兼容时递增
+	snapshotVersion = 1
+	snapshotExt     = ".kw"
+)
+
+// Store 按集合维护 BM25 关键词倒排索引，与向量集合同名，数据按集合快照到 IndexPath，重启时加载
+type Store struct {
+	config    config.KeywordConfig
+	indexes   map[string]*index
+	mutex     sync.RWMutex
+	snapshots *snapshot.Store
+}
+
+func NewStore(cfg config.KeywordConfig) (*Store, error) {
+	if cfg.K1 <= 0 {
+		cfg.K1 = 1.2
+	}
+	if cfg.B < 0 || cfg.B > 1 {
+		return nil, fmt.Errorf("invalid BM25 parameter b %v, must be within [0, 1]", cfg.B)
+	}
+	s := &Store{
+		config:  cfg,
+		indexes: make(map[string]*index),
+	}
+	snapshots, err := snapshot.NewStore(cfg.IndexPath, snapshotExt, "keyword index", s.collections)
+	if err != nil {
+		return nil, err
+	}
+	s.snapshots = snapshots
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	if cfg.SnapshotInterval > 0 {
+		snapshots.Start(time.Duration(cfg.SnapshotInterval) * time.Second)
+	}
+	return s, nil
+}
+
+// Insert 写入文档，集合不存在时自动创建
+func (s *Store) Insert(collectionName string, documents []Document) error {
+	if len(documents) == 0 {
+		return nil
+	}
+	x := s.getOrCreateIndex(collectionName)
+	x.upsert(documents)
+	return s.afterWrite(x)
+}
+
+// Delete 删除文档，集合不存在时忽略
+func (s *Store) Delete(collectionName string, documentIDs []string) error {
+	x := s.getIndex(collectionName)
+	if x == nil {
+		return nil
+	}
+	x.delete(documentIDs)
+	return s.afterWrite(x)
+}
+
+// Search 按 BM25 检索，返回的 Score 为 BM25 得分
+func (s *Store) Search(collectionName, query string, topK int, filter *vtypes.Filter) []vtypes.SearchResult {
+	x := s.getIndex(collectionName)
+	if x == nil {
+		return []vtypes.SearchResult{}
+	}
+	return x.search(query, topK, filter, s.config.K1, s.config.B)
+}
+
+// Count 集合中的文档数
+func (s *Store) Count(collectionName string) int {
+	x := s.getIndex(collectionName)
+	if x == nil {
+		return 0
+	}
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.docs)
+}
+
+// Close 停止定时快照并保存所有未持久化的集合
+func (s *Store) Close() error {
+	return s.snapshots.Close()
+}
+
+func (s *Store) getIndex(name string) *index {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.indexes[name]
+}
+
+func (s *Store) getOrCreateIndex(name string) *index {
+	if x := s.getIndex(name); x != nil {
+		return x
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if x, ok := s.indexes[name]; ok {
+		return x
+	}
+	x := newIndex(name)
+	s.indexes[name] = x
+	return x
+}
+
+// afterWrite 未开启定时快照时每次写入后立即保存
+func (s *Store) afterWrite(x *index) error {
+	if s.config.SnapshotInterval > 0 {
+		return nil
+	}
+	return s.snapshots.Save(x.name, x)
+}
+
+// collections 当前全部集合，用于定时快照
+func (s *Store) collections() map[string]snapshot.Collection {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	collections := make(map[string]snapshot.Collection, len(s.indexes))
+	for name, x := range s.indexes {
+		collections[name] = x
+	}
+	return collections
+}
+
+// load 加载 IndexPath 下的全部集合快照
+func (s *Store) load() error {
+	return s.snapshots.Load(func(dec *gob.Decoder) error {
+		var snap indexSnapshot
+		if err := dec.Decode(&snap); err != nil {
+			return err
+		}
+		if snap.Version != snapshotVersion {
+			return fmt.Errorf("unsupported snapshot version %d", snap.Version)
+		}
+		x := restoreIndex(&snap)
+		s.indexes[x.name] = x
+		logx.Infof("Loaded keyword index %s - Documents: %d, Terms: %d", x.name, len(x.docs), len(x.postings))
+		return nil
+	})
+}
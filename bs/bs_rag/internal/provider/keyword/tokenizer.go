@@ -0,0 +1,68 @@
+package keyword
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenize 将文本切分为检索词
+// 连续的字母数字转小写后作为一个词，由 - _ . / 连接的编号（如 E-1023、v2.1.0）同时保留整体和各部分；
+// 中日韩文字没有分隔符，按相邻两字切分（bigram），单独的一个字保留单字；其余字符作为分隔符
+func Tokenize(text string) []string {
+	var tokens []string
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case isCJK(r):
+			j := i
+			for j < len(runes) && isCJK(runes[j]) {
+				j++
+			}
+			tokens = appendBigrams(tokens, runes[i:j])
+			i = j
+		case isWord(r):
+			j := i
+			for j < len(runes) && (isWord(runes[j]) || (isConnector(runes[j]) && j+1 < len(runes) && isWord(runes[j+1]))) {
+				j++
+			}
+			tokens = appendWord(tokens, strings.ToLower(string(runes[i:j])))
+			i = j
+		default:
+			i++
+		}
+	}
+	return tokens
+}
+
+func appendBigrams(tokens []string, run []rune) []string {
+	if len(run) == 1 {
+		return append(tokens, string(run))
+	}
+	for i := 0; i+1 < len(run); i++ {
+		tokens = append(tokens, string(run[i:i+2]))
+	}
+	return tokens
+}
+
+func appendWord(tokens []string, word string) []string {
+	tokens = append(tokens, word)
+	parts := strings.FieldsFunc(word, isConnector)
+	if len(parts) > 1 {
+		tokens = append(tokens, parts...)
+	}
+	return tokens
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
+
+func isWord(r rune) bool {
+	return (unicode.IsLetter(r) || unicode.IsDigit(r)) && !isCJK(r)
+}
+
+func isConnector(r rune) bool {
+	return r == '-' || r == '_' || r == '.' || r == '/'
+}
@@ -0,0 +1,118 @@
+package bailian
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	defaultEndpoint = "https://dashscope.aliyuncs.com/api/v1/services/rerank/text-rerank/text-rerank"
+	defaultModel    = "gte-rerank-v2"
+)
+
+// Reranker 阿里云百炼 gte-rerank 重排序实现，分数为模型给出的相关性，取值 [0, 1]
+type Reranker struct {
+	apiKey    string
+	modelCode string
+	endpoint  string
+	client    *http.Client
+}
+
+// NewBailianReranker 构造函数，endpoint 为空时使用百炼的默认地址
+func NewBailianReranker(apiKey, modelCode, endpoint string) *Reranker {
+	if modelCode == "" {
+		modelCode = defaultModel
+	}
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	return &Reranker{
+		apiKey:    apiKey,
+		modelCode: modelCode,
+		endpoint:  endpoint,
+		client:    &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (r *Reranker) Rerank(ctx context.Context, query string, documents []string) ([]float32, error) {
+	if len(documents) == 0 {
+		return []float32{}, nil
+	}
+
+	requestBody := map[string]interface{}{
+		"model": r.modelCode,
+		"input": map[string]interface{}{
+			"query":     query,
+			"documents": documents,
+		},
+		"parameters": map[string]interface{}{
+			"return_documents": false,
+			"top_n":            len(documents),
+		},
+	}
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return nil, fmt.Errorf("marshal request body failed: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("create request failed: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+r.getAPIKey())
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response body failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var response struct {
+		Output struct {
+			Results []struct {
+				Index          int     `json:"index"`
+				RelevanceScore float32 `json:"relevance_score"`
+			} `json:"results"`
+		} `json:"output"`
+	}
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return nil, fmt.Errorf("parse response failed: %w", err)
+	}
+	if len(response.Output.Results) != len(documents) {
+		return nil, fmt.Errorf("rerank returned %d results for %d documents", len(response.Output.Results), len(documents))
+	}
+
+	// 返回结果按分数排序，按 index 还原为文档顺序
+	scores := make([]float32, len(documents))
+	seen := make([]bool, len(documents))
+	for _, result := range response.Output.Results {
+		if result.Index < 0 || result.Index >= len(documents) || seen[result.Index] {
+			return nil, fmt.Errorf("rerank returned invalid document index %d", result.Index)
+		}
+		seen[result.Index] = true
+		scores[result.Index] = result.RelevanceScore
+	}
+	return scores, nil
+}
+
+func (r *Reranker) getAPIKey() string {
+	if r.apiKey != "" {
+		return r.apiKey
+	}
+	return os.Getenv("BAILIAN_API_KEY")
+}
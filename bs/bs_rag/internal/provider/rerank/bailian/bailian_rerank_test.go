@@ -0,0 +1,45 @@
+package bailian
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBailianRerank(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req struct {
+			Model string `json:"model"`
+			Input struct {
+				Query     string   `json:"query"`
+				Documents []string `json:"documents"`
+			} `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Model != "gte-rerank-v2" || len(req.Input.Documents) != 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// 返回结果按分数降序，index 对应请求中的文档位置
+		w.Write([]byte(`{"output":{"results":[{"index":1,"relevance_score":0.93},{"index":0,"relevance_score":0.12}]}}`))
+	}))
+	defer server.Close()
+
+	r := NewBailianReranker("test-key", "", server.URL)
+	scores, err := r.Rerank(context.Background(), "query", []string{"doc0", "doc1"})
+	if err != nil {
+		t.Fatalf("Rerank failed: %v", err)
+	}
+	if len(scores) != 2 || scores[0] != 0.12 || scores[1] != 0.93 {
+		t.Errorf("Unexpected scores: %v", scores)
+	}
+
+	if _, err := NewBailianReranker("wrong-key", "", server.URL).Rerank(context.Background(), "query", []string{"doc0", "doc1"}); err == nil {
+		t.Error("Expected error for unauthorized request")
+	}
+}
@@ -0,0 +1,35 @@
+package factory
+
+import (
+	"fmt"
+
+	bailian "jxzy/bs/bs_rag/internal/provider/rerank/bailian"
+	lexical "jxzy/bs/bs_rag/internal/provider/rerank/lexical"
+	rtypes "jxzy/bs/bs_rag/internal/provider/rerank/types"
+)
+
+// RerankerFactory 工厂：创建重排序实现
+type RerankerFactory struct{}
+
+// BailianRerankerConfig 百炼重排序配置
+type BailianRerankerConfig struct {
+	APIKey    string
+	ModelCode string
+	Endpoint  string // 为空时使用百炼默认地址
+}
+
+// NewReranker 根据类型与配置创建重排序实现
+func (f *RerankerFactory) NewReranker(t rtypes.RerankerType, cfg interface{}) (rtypes.Reranker, error) {
+	switch t {
+	case rtypes.RerankerTypeBailian:
+		c, ok := cfg.(BailianRerankerConfig)
+		if !ok {
+			return nil, fmt.Errorf("invalid config type for bailian reranker")
+		}
+		return bailian.NewBailianReranker(c.APIKey, c.ModelCode, c.Endpoint), nil
+	case rtypes.RerankerTypeLexical:
+		return lexical.NewLexicalReranker(), nil
+	default:
+		return nil, fmt.Errorf("unsupported reranker type: %s", t)
+	}
+}
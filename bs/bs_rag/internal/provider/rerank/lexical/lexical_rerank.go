@@ -0,0 +1,37 @@
+package lexical
+
+import (
+	"context"
+
+	"jxzy/bs/bs_rag/internal/provider/keyword"
+)
+
+// Reranker 本地词重叠重排序，分数为查询词在文档中出现的比例，取值 [0, 1]
+// 分词与关键词索引一致，适合没有外部重排序服务时提升编号、术语类查询的排序
+type Reranker struct{}
+
+func NewLexicalReranker() *Reranker {
+	return &Reranker{}
+}
+
+func (r *Reranker) Rerank(ctx context.Context, query string, documents []string) ([]float32, error) {
+	queryTerms := make(map[string]struct{})
+	for _, t := range keyword.Tokenize(query) {
+		queryTerms[t] = struct{}{}
+	}
+
+	scores := make([]float32, len(documents))
+	if len(queryTerms) == 0 {
+		return scores, nil
+	}
+	for i, doc := range documents {
+		matched := make(map[string]struct{})
+		for _, t := range keyword.Tokenize(doc) {
+			if _, ok := queryTerms[t]; ok {
+				matched[t] = struct{}{}
+			}
+		}
+		scores[i] = float32(len(matched)) / float32(len(queryTerms))
+	}
+	return scores, nil
+}
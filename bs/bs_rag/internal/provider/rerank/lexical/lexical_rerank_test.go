@@ -0,0 +1,21 @@
+package lexical
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLexicalRerank(t *testing.T) {
+	scores, err := NewLexicalReranker().Rerank(context.Background(), "E-1023 报错", []string{
+		"设备上报错误码 E-1023，需要重启",
+		"E-2048 表示网络超时",
+		"",
+	})
+	if err != nil {
+		t.Fatalf("Rerank failed: %v", err)
+	}
+	// 查询词: e-1023, e, 1023, 报错
+	if scores[0] != 1 || scores[1] != 0.25 || scores[2] != 0 {
+		t.Errorf("Unexpected scores: %v", scores)
+	}
+}
@@ -0,0 +1,19 @@
+package types
+
+import "context"
+
+// Reranker 重排序接口，对召回的候选文档按与查询的相关性重新打分
+type Reranker interface {
+	// Rerank 返回每个文档的相关性分数，顺序与 documents 一致，分数越大越相关
+	Rerank(ctx context.Context, query string, documents []string) ([]float32, error)
+}
+
+// RerankerType 枚举可用的重排序实现
+type RerankerType string
+
+const (
+	// RerankerTypeBailian 阿里云百炼 gte-rerank 交叉编码模型
+	RerankerTypeBailian RerankerType = "bailian"
+	// RerankerTypeLexical 本地词重叠打分，不依赖外部服务
+	RerankerTypeLexical RerankerType = "lexical"
+)
@@ -0,0 +1,164 @@
+package snapshot
+
+import (
+	"encoding/gob"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// Collection 可快照的集合，由实现方维护版本号：每次写入递增，快照成功后记录已保存的版本
+type Collection interface {
+	// Snapshot 持有读锁调用 encode 写入快照数据，返回快照对应的版本；没有未保存的修改时不调用 encode，ok 为 false
+	Snapshot(encode func(data interface{}) error) (version uint64, ok bool, err error)
+	// MarkSaved 记录最近一次快照对应的版本
+	MarkSaved(version uint64)
+}
+
+// Store 将集合按名称 gob 编码快照到目录，每个集合一个文件，支持定时快照和启动时加载
+type Store struct {
+	dir      string
+	ext      string
+	kind     string // 集合类型，用于错误信息
+	list     func() map[string]Collection
+	mutex    sync.Mutex // 串行化快照写入和删除
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewStore 创建快照目录，list 返回当前全部集合，用于定时快照和关闭时保存
+func NewStore(dir, ext, kind string, list func() map[string]Collection) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s snapshot directory: %w", kind, err)
+	}
+	return &Store{
+		dir:  dir,
+		ext:  ext,
+		kind: kind,
+		list: list,
+		stop: make(chan struct{}),
+	}, nil
+}
+
+// Start 每隔 interval 保存有修改的集合
+func (s *Store) Start(interval time.Duration) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.SaveAll(); err != nil {
+					logx.Errorf("Failed to snapshot %s: %v", s.kind, err)
+				}
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Close 停止定时快照并保存所有未持久化的集合
+func (s *Store) Close() error {
+	s.stopOnce.Do(func() { close(s.stop) })
+	s.wg.Wait()
+	return s.SaveAll()
+}
+
+// SaveAll 保存全部有修改的集合
+func (s *Store) SaveAll() error {
+	var errs []string
+	for name, c := range s.list() {
+		if err := s.Save(name, c); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Save 将集合写入临时文件后重命名，保证快照文件总是完整的；没有未保存的修改时跳过
+func (s *Store) Save(name string, c Collection) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	path := s.path(name)
+	var tmp *os.File
+	version, ok, err := c.Snapshot(func(data interface{}) error {
+		f, err := os.CreateTemp(s.dir, filepath.Base(path)+".tmp*")
+		if err != nil {
+			return err
+		}
+		tmp = f
+		return gob.NewEncoder(f).Encode(data)
+	})
+	if tmp != nil {
+		if err == nil {
+			err = tmp.Sync()
+		}
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(tmp.Name(), path)
+		}
+		if err != nil {
+			os.Remove(tmp.Name())
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to snapshot %s %s: %w", s.kind, name, err)
+	}
+	if ok {
+		c.MarkSaved(version)
+	}
+	return nil
+}
+
+// Remove 删除集合的快照文件，文件不存在时忽略
+func (s *Store) Remove(name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := os.Remove(s.path(name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove snapshot of %s %s: %w", s.kind, name, err)
+	}
+	return nil
+}
+
+// Load 依次解码目录下的全部快照文件
+func (s *Store) Load(load func(dec *gob.Decoder) error) error {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*"+s.ext))
+	if err != nil {
+		return fmt.Errorf("failed to list %s snapshots: %w", s.kind, err)
+	}
+	for _, path := range paths {
+		if err := loadFile(path, load); err != nil {
+			return fmt.Errorf("failed to load %s snapshot %s: %w", s.kind, path, err)
+		}
+	}
+	return nil
+}
+
+func loadFile(path string, load func(dec *gob.Decoder) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return load(gob.NewDecoder(f))
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, url.PathEscape(name)+s.ext)
+}
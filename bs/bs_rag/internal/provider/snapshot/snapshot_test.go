@@ -0,0 +1,71 @@
+package snapshot
+
+import (
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testCollection 以字符串为内容的测试集合
+type testCollection struct {
+	data    string
+	version uint64
+	saved   uint64
+}
+
+func (c *testCollection) Snapshot(encode func(data interface{}) error) (uint64, bool, error) {
+	if c.version == c.saved {
+		return c.version, false, nil
+	}
+	return c.version, true, encode(c.data)
+}
+
+func (c *testCollection) MarkSaved(version uint64) { c.saved = version }
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	collections := map[string]Collection{
+		"a/b":   &testCollection{data: "first", version: 1},
+		"saved": &testCollection{data: "unchanged", version: 1, saved: 1},
+	}
+	s, err := NewStore(dir, ".snap", "test collection", func() map[string]Collection { return collections })
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// 只保存有修改的集合，不残留临时文件
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	if len(files) != 1 || filepath.Base(files[0]) != "a%2Fb.snap" {
+		t.Fatalf("Unexpected snapshot files: %v", files)
+	}
+	if c := collections["a/b"].(*testCollection); c.saved != 1 {
+		t.Errorf("Expected saved version to be recorded, got %d", c.saved)
+	}
+
+	var loaded []string
+	err = s.Load(func(dec *gob.Decoder) error {
+		var data string
+		if err := dec.Decode(&data); err != nil {
+			return err
+		}
+		loaded = append(loaded, data)
+		return nil
+	})
+	if err != nil || len(loaded) != 1 || loaded[0] != "first" {
+		t.Errorf("Unexpected loaded snapshots: %v, %v", loaded, err)
+	}
+
+	if err := s.Remove("a/b"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := os.Stat(files[0]); !os.IsNotExist(err) {
+		t.Errorf("Expected snapshot file to be removed, got %v", err)
+	}
+	if err := s.Remove("missing"); err != nil {
+		t.Errorf("Expected missing snapshot to be ignored, got %v", err)
+	}
+}
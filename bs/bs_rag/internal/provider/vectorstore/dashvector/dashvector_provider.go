@@ -84,7 +84,7 @@ type insertDocsRequest struct {
 type searchRequest struct {
     Vector        []float32              `json:"vector"`
     TopK          int                    `json:"topk"`
-    Filter        map[string]interface{} `json:"filter,omitempty"`
+    Filter        string                 `json:"filter,omitempty"`
     IncludeVector bool                   `json:"include_vector,omitempty"`
     IncludeFields bool                   `json:"include_fields,omitempty"`
 }
@@ -109,11 +109,13 @@ func NewDashVectorProvider(config DashVectorConfig) *DashVectorProvider {
     return &DashVectorProvider{config: config, httpClient: httpClient, baseURL: baseURL}
 }
 
-func (p *DashVectorProvider) Search(ctx context.Context, collectionName string, queryVector []float32, topK int, minScore float32) ([]types.SearchResult, error) {
+func (p *DashVectorProvider) Search(ctx context.Context, collectionName string, queryVector []float32, topK int, minScore float32, filter *types.Filter) ([]types.SearchResult, error) {
     logger := logx.WithContext(ctx)
-    logger.Infof("Starting vector search - collection: %s, vector_dim: %d, topK: %d, minScore: %.3f", collectionName, len(queryVector), topK, minScore)
+    logger.Infof("Starting vector search - collection: %s, vector_dim: %d, topK: %d, minScore: %.3f, filter: %s", collectionName, len(queryVector), topK, minScore, filter)
 
-    reqBody := searchRequest{Vector: queryVector, TopK: topK, IncludeVector: false, IncludeFields: true}
+    filterExpr, err := buildFilter(filter)
+    if err != nil { return nil, err }
+    reqBody := searchRequest{Vector: queryVector, TopK: topK, Filter: filterExpr, IncludeVector: false, IncludeFields: true}
     url := fmt.Sprintf("%s/v1/collections/%s/query", p.baseURL, collectionName)
     var searchResults []searchResult
     if err := p.makeRequest(ctx, "POST", url, reqBody, &searchResults); err != nil {
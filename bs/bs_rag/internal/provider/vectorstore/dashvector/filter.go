@@ -0,0 +1,60 @@
+package dashvector
+
+import (
+	"fmt"
+	"strings"
+
+	"jxzy/bs/bs_rag/internal/provider/vectorstore/types"
+)
+
+// comparators 比较操作符对应的 DashVector 过滤语法
+var comparators = map[types.FilterOp]string{
+	types.FilterOpEq:  "=",
+	types.FilterOpGt:  ">",
+	types.FilterOpGte: ">=",
+	types.FilterOpLt:  "<",
+	types.FilterOpLte: "<=",
+}
+
+// buildFilter 将过滤表达式翻译为 DashVector 的 SQL where 子句
+// 元数据写入时均为字符串字段，等值和 in 按字符串比较；范围比较的值为数字时不加引号，需要集合中该字段为数值类型
+func buildFilter(f *types.Filter) (string, error) {
+	if f == nil {
+		return "", nil
+	}
+	if err := f.Validate(); err != nil {
+		return "", err
+	}
+	return filterExpr(f), nil
+}
+
+func filterExpr(f *types.Filter) string {
+	switch f.Op {
+	case types.FilterOpAnd, types.FilterOpOr:
+		parts := make([]string, len(f.Children))
+		for i, child := range f.Children {
+			parts[i] = filterExpr(child)
+		}
+		return "(" + strings.Join(parts, " "+string(f.Op)+" ") + ")"
+	case types.FilterOpIn:
+		parts := make([]string, len(f.Values))
+		for i, v := range f.Values {
+			parts[i] = fmt.Sprintf("%s = %s", f.Field, quote(v))
+		}
+		return "(" + strings.Join(parts, " or ") + ")"
+	case types.FilterOpEq:
+		return fmt.Sprintf("%s = %s", f.Field, quote(f.Value))
+	}
+	value := quote(f.Value)
+	if types.IsNumeric(f.Value) {
+		value = f.Value
+	}
+	return fmt.Sprintf("%s %s %s", f.Field, comparators[f.Op], value)
+}
+
+// quote 转义为单引号字符串
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `\'`)
+	return "'" + s + "'"
+}
@@ -0,0 +1,43 @@
+package dashvector
+
+import (
+	"testing"
+
+	"jxzy/bs/bs_rag/internal/provider/vectorstore/types"
+)
+
+func TestBuildFilter(t *testing.T) {
+	cases := []struct {
+		filter *types.Filter
+		expr   string
+	}{
+		{nil, ""},
+		{types.Eq("user_id", "u1"), "user_id = 'u1'"},
+		{types.Eq("title", `it's a \ test`), `title = 'it\'s a \\ test'`},
+		{types.In("type", "pdf", "doc"), "(type = 'pdf' or type = 'doc')"},
+		{&types.Filter{Op: types.FilterOpGte, Field: "size", Value: "100"}, "size >= 100"},
+		{&types.Filter{Op: types.FilterOpLt, Field: "date", Value: "2024-01-01"}, "date < '2024-01-01'"},
+		{&types.Filter{Op: types.FilterOpGt, Field: "score", Value: "NaN"}, "score > 'NaN'"},
+		{&types.Filter{Op: types.FilterOpLt, Field: "score", Value: "-Inf"}, "score < '-Inf'"},
+		{&types.Filter{Op: types.FilterOpGte, Field: "score", Value: "infinity"}, "score >= 'infinity'"},
+		{&types.Filter{Op: types.FilterOpLte, Field: "size", Value: "0x1p3"}, "size <= '0x1p3'"},
+		{&types.Filter{Op: types.FilterOpGt, Field: "size", Value: "-2e3"}, "size > -2e3"},
+		{
+			types.And(types.Eq("user_id", "u1"), types.Or(types.Eq("type", "pdf"), &types.Filter{Op: types.FilterOpGt, Field: "size", Value: "1.5"})),
+			"(user_id = 'u1' and (type = 'pdf' or size > 1.5))",
+		},
+	}
+	for i, tc := range cases {
+		expr, err := buildFilter(tc.filter)
+		if err != nil {
+			t.Fatalf("case %d: unexpected error: %v", i, err)
+		}
+		if expr != tc.expr {
+			t.Errorf("case %d: expected %q, got %q", i, tc.expr, expr)
+		}
+	}
+
+	if _, err := buildFilter(types.Eq("a or 1=1", "x")); err == nil {
+		t.Error("Expected error for invalid field name")
+	}
+}
@@ -15,7 +15,7 @@ func (f *VectorProviderFactory) NewVectorProvider(providerType types.VectorProvi
     switch providerType {
     case types.VectorProviderTypeFaiss:
         if faissConfig, ok := cfg.(config.FaissConfig); ok {
-            return faiss.NewFaissProvider(faissConfig), nil
+            return faiss.NewFaissProvider(faissConfig)
         }
         return nil, types.ErrInvalidConfig
     case types.VectorProviderTypeDashVector:
@@ -0,0 +1,285 @@
+package faiss
+
+import (
+	"fmt"
+	"sync"
+
+	"jxzy/bs/bs_rag/internal/config"
+	ptypes "jxzy/bs/bs_rag/internal/provider/vectorstore/types"
+)
+
+// snapshotVersion 快照格式版本，格式不兼容时递增
+const snapshotVersion = 1
+
+// compactMinNodes 节点数少于该值时不重建索引
+const compactMinNodes = 64
+
+// collection 单个集合的数据和索引，读写由 mu 保护，支持并发检索
+type collection struct {
+	mu        sync.RWMutex
+	name      string
+	dimension int
+	indexType string
+	metric    string
+	config    config.FaissConfig
+
+	nodes   []*node           // 按写入顺序，下标即节点ID
+	ids     map[string]uint32 // 文档ID -> 节点ID
+	removed int               // 已删除节点数
+	index   vectorIndex
+
+	version uint64 // 每次写入递增，新建集合从1开始
+	saved   uint64 // 最近一次快照对应的版本
+	dropped bool   // 集合已删除，不再快照
+}
+
+// node 节点数据，COSINE 度量时 Doc.Vector 为归一化后的向量
+type node struct {
+	Doc     ptypes.Document
+	Deleted bool
+}
+
+// collectionSnapshot 集合快照，gob 编码后写入 IndexPath
+type collectionSnapshot struct {
+	Version   int
+	Name      string
+	Dimension int
+	IndexType string
+	Metric    string
+	Nodes     []*node
+	Graph     *hnswSnapshot
+}
+
+func newCollection(name string, dimension int, indexType, metric string, cfg config.FaissConfig) *collection {
+	c := &collection{
+		name:      name,
+		dimension: dimension,
+		indexType: indexType,
+		metric:    metric,
+		config:    cfg,
+		ids:       make(map[string]uint32),
+		version:   1,
+	}
+	c.index = c.newIndex()
+	return c
+}
+
+func (c *collection) newIndex() vectorIndex {
+	if c.indexType == IndexTypeHNSW {
+		return newHNSWIndex(c, c.config.M, c.config.EfConstruction, c.config.EfSearch)
+	}
+	return &flatIndex{store: c}
+}
+
+func (c *collection) vector(id uint32) []float32 { return c.nodes[id].Doc.Vector }
+func (c *collection) deleted(id uint32) bool     { return c.nodes[id].Deleted }
+func (c *collection) size() int                  { return len(c.nodes) }
+func (c *collection) distance(a, b []float32) float32 {
+	return distance(c.metric, a, b)
+}
+
+// upsert 写入文档，ID 已存在时覆盖；任一文档维度不符时整批不写入
+func (c *collection) upsert(documents []ptypes.Document) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, doc := range documents {
+		if doc.ID == "" {
+			return fmt.Errorf("document id is required")
+		}
+		if len(doc.Vector) != c.dimension {
+			return fmt.Errorf("document %s dimension mismatch: expected %d, got %d", doc.ID, c.dimension, len(doc.Vector))
+		}
+	}
+
+	for _, doc := range documents {
+		if doc.Metadata != nil {
+			metadata := make(map[string]string, len(doc.Metadata))
+			for k, v := range doc.Metadata {
+				metadata[k] = v
+			}
+			doc.Metadata = metadata
+		}
+		if c.metric == MetricCosine {
+			doc.Vector = normalize(doc.Vector)
+		} else {
+			doc.Vector = append([]float32(nil), doc.Vector...)
+		}
+
+		c.markDeleted(doc.ID)
+		id := uint32(len(c.nodes))
+		c.nodes = append(c.nodes, &node{Doc: doc})
+		c.ids[doc.ID] = id
+		c.index.add(id)
+	}
+	c.version++
+	c.compact()
+	return nil
+}
+
+// remove 删除文档，不存在的ID忽略
+func (c *collection) remove(documentIDs []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, docID := range documentIDs {
+		c.markDeleted(docID)
+	}
+	c.version++
+	c.compact()
+}
+
+func (c *collection) markDeleted(docID string) {
+	if id, ok := c.ids[docID]; ok {
+		c.nodes[id].Deleted = true
+		delete(c.ids, docID)
+		c.removed++
+	}
+}
+
+// compact 删除节点超过一半时只保留有效节点重建索引
+func (c *collection) compact() {
+	if len(c.nodes) < compactMinNodes || c.removed*2 <= len(c.nodes) {
+		return
+	}
+	nodes := make([]*node, 0, len(c.ids))
+	for _, n := range c.nodes {
+		if !n.Deleted {
+			nodes = append(nodes, n)
+		}
+	}
+	c.nodes = c.nodes[:0]
+	c.ids = make(map[string]uint32, len(nodes))
+	c.removed = 0
+	c.index = c.newIndex()
+	for _, n := range nodes {
+		id := uint32(len(c.nodes))
+		c.nodes = append(c.nodes, n)
+		c.ids[n.Doc.ID] = id
+		c.index.add(id)
+	}
+}
+
+// search 检索满足 filter 的最相似的 topK 个文档，过滤低于 minScore 的结果
+func (c *collection) search(queryVector []float32, topK int, minScore float32, filter *ptypes.Filter) ([]ptypes.SearchResult, error) {
+	if len(queryVector) != c.dimension {
+		return nil, fmt.Errorf("query vector dimension mismatch: expected %d, got %d", c.dimension, len(queryVector))
+	}
+	if c.metric == MetricCosine {
+		queryVector = normalize(queryVector)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var accept func(id uint32) bool
+	if filter != nil {
+		accept = func(id uint32) bool { return filter.Match(c.nodes[id].Doc.Metadata) }
+	}
+	hits := c.index.search(queryVector, topK, accept)
+	results := make([]ptypes.SearchResult, 0, len(hits))
+	for _, hit := range hits {
+		s := score(c.metric, hit.dist)
+		if s < minScore {
+			continue
+		}
+		doc := c.nodes[hit.id].Doc
+		results = append(results, ptypes.SearchResult{
+			ID:       doc.ID,
+			Score:    s,
+			Vector:   doc.Vector,
+			Metadata: doc.Metadata,
+			Content:  doc.Content,
+		})
+	}
+	return results, nil
+}
+
+func (c *collection) info() *ptypes.CollectionInfo {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	metadata := map[string]string{"provider": "faiss", "metric": c.metric}
+	if c.indexType == IndexTypeHNSW {
+		metadata["m"] = fmt.Sprint(c.config.M)
+		metadata["ef_construction"] = fmt.Sprint(c.config.EfConstruction)
+		metadata["ef_search"] = fmt.Sprint(c.config.EfSearch)
+	}
+	return &ptypes.CollectionInfo{
+		Name:          c.name,
+		Dimension:     c.dimension,
+		IndexType:     c.indexType,
+		DocumentCount: len(c.ids),
+		Metadata:      metadata,
+		Exists:        true,
+	}
+}
+
+// Snapshot 有未保存的修改时持有读锁编码快照，集合已删除时跳过
+func (c *collection) Snapshot(encode func(data interface{}) error) (uint64, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.dropped || c.version == c.saved {
+		return c.version, false, nil
+	}
+	return c.version, true, encode(c.snapshot())
+}
+
+// MarkSaved 记录最近一次快照对应的版本
+func (c *collection) MarkSaved(version uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.saved = version
+}
+
+// drop 标记集合已删除
+func (c *collection) drop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dropped = true
+}
+
+// snapshot 生成快照，调用方需持有读锁
+func (c *collection) snapshot() *collectionSnapshot {
+	s := &collectionSnapshot{
+		Version:   snapshotVersion,
+		Name:      c.name,
+		Dimension: c.dimension,
+		IndexType: c.indexType,
+		Metric:    c.metric,
+		Nodes:     c.nodes,
+	}
+	if h, ok := c.index.(*hnswIndex); ok {
+		s.Graph = h.snapshot()
+	}
+	return s
+}
+
+// restoreCollection 从快照恢复集合，索引类型和度量以快照为准
+func restoreCollection(s *collectionSnapshot, cfg config.FaissConfig) (*collection, error) {
+	if s.Version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", s.Version)
+	}
+	c := newCollection(s.Name, s.Dimension, s.IndexType, s.Metric, cfg)
+	c.nodes = s.Nodes
+	for id, n := range c.nodes {
+		if n.Deleted {
+			c.removed++
+			continue
+		}
+		c.ids[n.Doc.ID] = uint32(id)
+	}
+
+	if h, ok := c.index.(*hnswIndex); ok {
+		if s.Graph == nil || len(s.Graph.Friends) != len(c.nodes) {
+			// 图结构缺失或不完整时重建
+			for id := range c.nodes {
+				h.add(uint32(id))
+			}
+		} else {
+			h.restore(s.Graph)
+		}
+	}
+	c.saved = c.version
+	return c, nil
+}
@@ -1,86 +1,238 @@
 package faiss
 
 import (
-    "context"
-    "fmt"
-    "os"
-    "sync"
-
-    "jxzy/bs/bs_rag/internal/config"
-    ptypes "jxzy/bs/bs_rag/internal/provider/vectorstore/types"
-    consts "jxzy/common/const"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"jxzy/bs/bs_rag/internal/config"
+	"jxzy/bs/bs_rag/internal/provider/snapshot"
+	ptypes "jxzy/bs/bs_rag/internal/provider/vectorstore/types"
+
+	"github.com/zeromicro/go-zero/core/logx"
 )
 
-type FaissProvider struct {
-    config      config.FaissConfig
-    collections map[string]*FaissCollection
-    mutex       sync.RWMutex
-}
-
-type FaissCollection struct {
-    Name          string
-    Dimension     int
-    IndexType     string
-    DocumentCount int
-}
+// 未配置时的默认参数
+const (
+	defaultM              = 16
+	defaultEfConstruction = 200
+	defaultEfSearch       = 50
+	snapshotExt           = ".idx"
+)
 
-func NewFaissProvider(config config.FaissConfig) *FaissProvider {
-    if err := os.MkdirAll(config.IndexPath, 0755); err != nil { panic(fmt.Sprintf("Failed to create index directory: %v", err)) }
-    return &FaissProvider{config: config, collections: make(map[string]*FaissCollection)}
+// FaissProvider 进程内向量存储，支持 Flat 精确检索和 HNSW 近似检索
+// 集合在首次写入时按向量维度自动创建，数据按集合快照到 IndexPath，重启时加载
+type FaissProvider struct {
+	config      config.FaissConfig
+	collections map[string]*collection
+	mutex       sync.RWMutex
+	snapshots   *snapshot.Store
 }
 
-func (p *FaissProvider) GetCollection(name string) (*FaissCollection, error) {
-    p.mutex.Lock(); defer p.mutex.Unlock()
-    if c, ok := p.collections[name]; ok { return c, nil }
-    c := &FaissCollection{Name: name, Dimension: consts.FaissDefaultDimension, IndexType: p.config.IndexType, DocumentCount: 0}
-    p.collections[name] = c
-    return c, nil
+func NewFaissProvider(cfg config.FaissConfig) (*FaissProvider, error) {
+	indexType, ok := parseIndexType(cfg.IndexType)
+	if cfg.IndexType == "" {
+		indexType, ok = IndexTypeHNSW, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("unsupported faiss index type %q, available: Flat, HNSW", cfg.IndexType)
+	}
+	metric, ok := parseMetric(cfg.MetricType)
+	if cfg.MetricType == "" {
+		metric, ok = MetricCosine, true
+	}
+	if !ok {
+		return nil, fmt.Errorf("unsupported faiss metric type %q, available: L2, IP, COSINE", cfg.MetricType)
+	}
+	cfg.IndexType = indexType
+	cfg.MetricType = metric
+	if cfg.M <= 1 {
+		cfg.M = defaultM
+	}
+	if cfg.EfConstruction <= 0 {
+		cfg.EfConstruction = defaultEfConstruction
+	}
+	if cfg.EfSearch <= 0 {
+		cfg.EfSearch = defaultEfSearch
+	}
+
+	p := &FaissProvider{
+		config:      cfg,
+		collections: make(map[string]*collection),
+	}
+	snapshots, err := snapshot.NewStore(cfg.IndexPath, snapshotExt, "faiss collection", p.snapshotCollections)
+	if err != nil {
+		return nil, err
+	}
+	p.snapshots = snapshots
+	if err := p.load(); err != nil {
+		return nil, err
+	}
+
+	if cfg.SnapshotInterval > 0 {
+		snapshots.Start(time.Duration(cfg.SnapshotInterval) * time.Second)
+	}
+	return p, nil
 }
 
-func (p *FaissProvider) Search(ctx context.Context, collectionName string, queryVector []float32, topK int, minScore float32) ([]ptypes.SearchResult, error) {
-    if _, err := p.GetCollection(collectionName); err != nil { return nil, fmt.Errorf("failed to get collection %s: %v", collectionName, err) }
-    results := []ptypes.SearchResult{{ID: "doc1", Score: 0.95, Vector: queryVector, Metadata: map[string]string{"source": "test"}, Content: "This is a test document"}}
-    return results, nil
+func (p *FaissProvider) Search(ctx context.Context, collectionName string, queryVector []float32, topK int, minScore float32, filter *ptypes.Filter) ([]ptypes.SearchResult, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	c := p.getCollection(collectionName)
+	if c == nil {
+		return []ptypes.SearchResult{}, nil
+	}
+	return c.search(queryVector, topK, minScore, filter)
 }
 
 func (p *FaissProvider) Insert(ctx context.Context, collectionName string, documents []ptypes.Document) error {
-    c, err := p.GetCollection(collectionName); if err != nil { return fmt.Errorf("failed to get collection %s: %v", collectionName, err) }
-    c.DocumentCount += len(documents)
-    return nil
+	if len(documents) == 0 {
+		return nil
+	}
+	c, err := p.getOrCreateCollection(collectionName, len(documents[0].Vector))
+	if err != nil {
+		return err
+	}
+	if err := c.upsert(documents); err != nil {
+		return fmt.Errorf("failed to insert into collection %s: %w", collectionName, err)
+	}
+	return p.afterWrite(c)
 }
 
 func (p *FaissProvider) Delete(ctx context.Context, collectionName string, documentIDs []string) error {
-    c, err := p.GetCollection(collectionName); if err != nil { return fmt.Errorf("failed to get collection %s: %v", collectionName, err) }
-    c.DocumentCount -= len(documentIDs); if c.DocumentCount < 0 { c.DocumentCount = 0 }
-    return nil
+	c := p.getCollection(collectionName)
+	if c == nil {
+		return ptypes.ErrCollectionNotFound
+	}
+	c.remove(documentIDs)
+	return p.afterWrite(c)
 }
 
 func (p *FaissProvider) GetCollectionInfo(ctx context.Context, collectionName string) (*ptypes.CollectionInfo, error) {
-    c, err := p.GetCollection(collectionName); if err != nil { return nil, fmt.Errorf("failed to get collection %s: %v", collectionName, err) }
-    return &ptypes.CollectionInfo{Name: c.Name, Dimension: c.Dimension, IndexType: c.IndexType, DocumentCount: c.DocumentCount, Metadata: map[string]string{"provider": "faiss"}}, nil
+	c := p.getCollection(collectionName)
+	if c == nil {
+		return &ptypes.CollectionInfo{Name: collectionName, Exists: false}, nil
+	}
+	return c.info(), nil
 }
 
+// CreateCollection 创建集合，indexType 为空时使用配置的索引类型
 func (p *FaissProvider) CreateCollection(ctx context.Context, collectionName string, dimension int, indexType string) error {
-    c := &FaissCollection{Name: collectionName, Dimension: dimension, IndexType: indexType, DocumentCount: 0}
-    p.mutex.Lock(); defer p.mutex.Unlock(); p.collections[collectionName] = c
-    return nil
+	if dimension <= 0 {
+		return fmt.Errorf("invalid dimension %d", dimension)
+	}
+	if indexType == "" {
+		indexType = p.config.IndexType
+	}
+	normalized, ok := parseIndexType(indexType)
+	if !ok {
+		return fmt.Errorf("unsupported faiss index type %q, available: Flat, HNSW", indexType)
+	}
+
+	p.mutex.Lock()
+	if _, ok := p.collections[collectionName]; ok {
+		p.mutex.Unlock()
+		return fmt.Errorf("collection %s already exists", collectionName)
+	}
+	c := newCollection(collectionName, dimension, normalized, p.config.MetricType, p.config)
+	p.collections[collectionName] = c
+	p.mutex.Unlock()
+
+	return p.afterWrite(c)
 }
 
 func (p *FaissProvider) DeleteCollection(ctx context.Context, collectionName string) error {
-    p.mutex.Lock(); defer p.mutex.Unlock()
-    if _, ok := p.collections[collectionName]; !ok { return ptypes.ErrCollectionNotFound }
-    delete(p.collections, collectionName)
-    return nil
+	p.mutex.Lock()
+	c, ok := p.collections[collectionName]
+	if !ok {
+		p.mutex.Unlock()
+		return ptypes.ErrCollectionNotFound
+	}
+	delete(p.collections, collectionName)
+	p.mutex.Unlock()
+
+	// 已删除的集合不再写入快照
+	c.drop()
+	return p.snapshots.Remove(collectionName)
 }
 
 func (p *FaissProvider) ListCollections(ctx context.Context) ([]string, error) {
-    p.mutex.RLock(); defer p.mutex.RUnlock()
-    names := make([]string, 0, len(p.collections))
-    for n := range p.collections { names = append(names, n) }
-    return names, nil
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	names := make([]string, 0, len(p.collections))
+	for n := range p.collections {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Close 停止定时快照并保存所有未持久化的集合
+func (p *FaissProvider) Close() error {
+	return p.snapshots.Close()
 }
 
-func (p *FaissProvider) Close() error { return nil }
+func (p *FaissProvider) getCollection(name string) *collection {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.collections[name]
+}
 
+func (p *FaissProvider) getOrCreateCollection(name string, dimension int) (*collection, error) {
+	if c := p.getCollection(name); c != nil {
+		return c, nil
+	}
+	if dimension <= 0 {
+		return nil, fmt.Errorf("invalid dimension %d", dimension)
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if c, ok := p.collections[name]; ok {
+		return c, nil
+	}
+	c := newCollection(name, dimension, p.config.IndexType, p.config.MetricType, p.config)
+	p.collections[name] = c
+	logx.Infof("Created faiss collection %s - Dimension: %d, IndexType: %s, Metric: %s", name, dimension, c.indexType, c.metric)
+	return c, nil
+}
 
+// afterWrite 未开启定时快照时每次写入后立即保存
+func (p *FaissProvider) afterWrite(c *collection) error {
+	if p.config.SnapshotInterval > 0 {
+		return nil
+	}
+	return p.snapshots.Save(c.name, c)
+}
 
+// snapshotCollections 当前全部集合，用于定时快照
+func (p *FaissProvider) snapshotCollections() map[string]snapshot.Collection {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	collections := make(map[string]snapshot.Collection, len(p.collections))
+	for name, c := range p.collections {
+		collections[name] = c
+	}
+	return collections
+}
+
+// load 加载 IndexPath 下的全部集合快照
+func (p *FaissProvider) load() error {
+	return p.snapshots.Load(func(dec *gob.Decoder) error {
+		var s collectionSnapshot
+		if err := dec.Decode(&s); err != nil {
+			return err
+		}
+		c, err := restoreCollection(&s, p.config)
+		if err != nil {
+			return err
+		}
+		p.collections[c.name] = c
+		logx.Infof("Loaded faiss collection %s - Documents: %d, IndexType: %s, Metric: %s", c.name, len(c.ids), c.indexType, c.metric)
+		return nil
+	})
+}
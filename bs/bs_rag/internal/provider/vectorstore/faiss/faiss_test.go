@@ -0,0 +1,274 @@
+package faiss
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"jxzy/bs/bs_rag/internal/config"
+	ptypes "jxzy/bs/bs_rag/internal/provider/vectorstore/types"
+)
+
+func newTestProvider(t *testing.T, dir, indexType, metric string) *FaissProvider {
+	t.Helper()
+	p, err := NewFaissProvider(config.FaissConfig{
+		IndexPath:      dir,
+		IndexType:      indexType,
+		M:              16,
+		EfConstruction: 100,
+		EfSearch:       64,
+		MetricType:     metric,
+	})
+	if err != nil {
+		t.Fatalf("NewFaissProvider failed: %v", err)
+	}
+	return p
+}
+
+func randomDocuments(rng *rand.Rand, n, dimension int) []ptypes.Document {
+	docs := make([]ptypes.Document, n)
+	for i := range docs {
+		vector := make([]float32, dimension)
+		for j := range vector {
+			vector[j] = rng.Float32()*2 - 1
+		}
+		docs[i] = ptypes.Document{
+			ID:       fmt.Sprintf("doc%d", i),
+			Vector:   vector,
+			Metadata: map[string]string{"n": fmt.Sprint(i)},
+			Content:  fmt.Sprintf("content %d", i),
+		}
+	}
+	return docs
+}
+
+func TestHNSWRecall(t *testing.T) {
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(1))
+	docs := randomDocuments(rng, 2000, 32)
+	queries := randomDocuments(rng, 50, 32)
+
+	flat := newTestProvider(t, t.TempDir(), IndexTypeFlat, MetricL2)
+	hnsw := newTestProvider(t, t.TempDir(), IndexTypeHNSW, MetricL2)
+	for _, p := range []*FaissProvider{flat, hnsw} {
+		if err := p.Insert(ctx, "docs", docs); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+	}
+
+	const topK = 10
+	hits := 0
+	for _, q := range queries {
+		expected, _ := flat.Search(ctx, "docs", q.Vector, topK, 0, nil)
+		actual, err := hnsw.Search(ctx, "docs", q.Vector, topK, 0, nil)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		ids := make(map[string]bool)
+		for _, r := range expected {
+			ids[r.ID] = true
+		}
+		for i, r := range actual {
+			if ids[r.ID] {
+				hits++
+			}
+			if i > 0 && r.Score > actual[i-1].Score {
+				t.Fatalf("Results not sorted by score: %+v", actual)
+			}
+		}
+	}
+	if recall := float64(hits) / float64(topK*len(queries)); recall < 0.9 {
+		t.Errorf("HNSW recall too low: %.3f", recall)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	ctx := context.Background()
+	docs := []ptypes.Document{
+		{ID: "a", Vector: []float32{1, 0}},
+		{ID: "b", Vector: []float32{0, 2}},
+		{ID: "c", Vector: []float32{3, 3}},
+	}
+	cases := []struct {
+		metric string
+		first  string
+		score  float32
+	}{
+		{MetricCosine, "a", 0.9487}, // 3/sqrt(10)
+		{MetricIP, "c", 12},
+		{MetricL2, "c", 1.0 / 3}, // 距离为2
+	}
+	for _, tc := range cases {
+		p := newTestProvider(t, t.TempDir(), IndexTypeFlat, tc.metric)
+		if err := p.Insert(ctx, "docs", docs); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		results, err := p.Search(ctx, "docs", []float32{3, 1}, 3, -100, nil)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if results[0].ID != tc.first || results[0].Score < tc.score-0.001 || results[0].Score > tc.score+0.001 {
+			t.Errorf("%s: unexpected top result %+v", tc.metric, results[0])
+		}
+	}
+
+	p := newTestProvider(t, t.TempDir(), IndexTypeFlat, MetricCosine)
+	p.Insert(ctx, "docs", docs)
+	results, _ := p.Search(ctx, "docs", []float32{1, 0}, 3, 0.5, nil)
+	if len(results) != 2 {
+		t.Errorf("Expected min score to filter orthogonal vector, got %+v", results)
+	}
+}
+
+func TestUpsertAndDelete(t *testing.T) {
+	ctx := context.Background()
+	for _, indexType := range []string{IndexTypeFlat, IndexTypeHNSW} {
+		p := newTestProvider(t, t.TempDir(), indexType, MetricCosine)
+		docs := randomDocuments(rand.New(rand.NewSource(2)), 200, 8)
+		if err := p.Insert(ctx, "docs", docs); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+
+		// 覆盖写入同一ID
+		updated := ptypes.Document{ID: "doc0", Vector: []float32{1, 1, 1, 1, 1, 1, 1, 1}, Content: "updated"}
+		if err := p.Insert(ctx, "docs", []ptypes.Document{updated}); err != nil {
+			t.Fatalf("Insert failed: %v", err)
+		}
+		results, _ := p.Search(ctx, "docs", updated.Vector, 1, 0, nil)
+		if len(results) != 1 || results[0].ID != "doc0" || results[0].Content != "updated" {
+			t.Errorf("%s: expected updated document, got %+v", indexType, results)
+		}
+
+		// 删除超过一半触发重建
+		ids := make([]string, 0, 150)
+		for i := 0; i < 150; i++ {
+			ids = append(ids, fmt.Sprintf("doc%d", i))
+		}
+		if err := p.Delete(ctx, "docs", ids); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		info, _ := p.GetCollectionInfo(ctx, "docs")
+		if info.DocumentCount != 50 || info.Dimension != 8 || info.IndexType != indexType {
+			t.Errorf("%s: unexpected collection info %+v", indexType, info)
+		}
+		results, _ = p.Search(ctx, "docs", updated.Vector, 100, -1, nil)
+		if len(results) != 50 {
+			t.Errorf("%s: expected 50 results after delete, got %d", indexType, len(results))
+		}
+		for _, r := range results {
+			var n int
+			fmt.Sscanf(r.ID, "doc%d", &n)
+			if n < 150 {
+				t.Errorf("%s: deleted document %s returned", indexType, r.ID)
+			}
+		}
+
+		if err := p.Insert(ctx, "docs", []ptypes.Document{{ID: "bad", Vector: []float32{1}}}); err == nil {
+			t.Errorf("%s: expected dimension mismatch error", indexType)
+		}
+	}
+}
+
+func TestFilteredSearch(t *testing.T) {
+	ctx := context.Background()
+	docs := randomDocuments(rand.New(rand.NewSource(5)), 1000, 16)
+	for i := range docs {
+		docs[i].Metadata["user_id"] = fmt.Sprintf("u%d", i%50)
+	}
+	for _, indexType := range []string{IndexTypeFlat, IndexTypeHNSW} {
+		p := newTestProvider(t, t.TempDir(), indexType, MetricCosine)
+		p.Insert(ctx, "docs", docs)
+
+		// 每个用户只有20条，过滤后 HNSW 结果不足时回退到暴力检索
+		filter := ptypes.And(ptypes.Eq("user_id", "u7"), &ptypes.Filter{Op: ptypes.FilterOpLt, Field: "n", Value: "500"})
+		results, err := p.Search(ctx, "docs", docs[0].Vector, 20, -1, filter)
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) != 10 {
+			t.Errorf("%s: expected 10 results, got %d", indexType, len(results))
+		}
+		for _, r := range results {
+			if !filter.Match(r.Metadata) {
+				t.Errorf("%s: result %s does not match filter: %v", indexType, r.ID, r.Metadata)
+			}
+		}
+
+		if _, err := p.Search(ctx, "docs", docs[0].Vector, 5, 0, &ptypes.Filter{Op: "like"}); err == nil {
+			t.Errorf("%s: expected error for invalid filter", indexType)
+		}
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	docs := randomDocuments(rand.New(rand.NewSource(3)), 300, 16)
+
+	p := newTestProvider(t, dir, IndexTypeHNSW, MetricCosine)
+	p.config.SnapshotInterval = 3600
+	if err := p.Insert(ctx, "a/b", docs); err != nil {
+		t.Fatalf("Insert failed: %v", err)
+	}
+	p.Delete(ctx, "a/b", []string{"doc1"})
+	p.Insert(ctx, "other", docs[:10])
+	p.DeleteCollection(ctx, "other")
+	expected, _ := p.Search(ctx, "a/b", docs[5].Vector, 5, 0, nil)
+	if err := p.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// 以不同的配置重启，集合保留原有的索引类型和度量
+	reloaded := newTestProvider(t, dir, IndexTypeFlat, MetricL2)
+	names, _ := reloaded.ListCollections(ctx)
+	if fmt.Sprint(names) != "[a/b]" {
+		t.Fatalf("Unexpected collections after reload: %v", names)
+	}
+	info, _ := reloaded.GetCollectionInfo(ctx, "a/b")
+	if info.DocumentCount != 299 || info.IndexType != IndexTypeHNSW || info.Metadata["metric"] != MetricCosine {
+		t.Errorf("Unexpected collection info after reload: %+v", info)
+	}
+	actual, _ := reloaded.Search(ctx, "a/b", docs[5].Vector, 5, 0, nil)
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("Search results changed after reload:\n%+v\n%+v", expected, actual)
+	}
+	if actual[0].ID != "doc5" || actual[0].Metadata["n"] != "5" || actual[0].Content != "content 5" {
+		t.Errorf("Unexpected top result after reload: %+v", actual[0])
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	ctx := context.Background()
+	p := newTestProvider(t, t.TempDir(), IndexTypeHNSW, MetricCosine)
+	docs := randomDocuments(rand.New(rand.NewSource(4)), 400, 16)
+	p.Insert(ctx, "docs", docs[:100])
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(2)
+		go func(w int) {
+			defer wg.Done()
+			for i := 100 + w; i < len(docs); i += 4 {
+				if err := p.Insert(ctx, "docs", docs[i:i+1]); err != nil {
+					t.Errorf("Insert failed: %v", err)
+				}
+			}
+		}(w)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if _, err := p.Search(ctx, "docs", docs[i].Vector, 5, 0, nil); err != nil {
+					t.Errorf("Search failed: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	info, _ := p.GetCollectionInfo(ctx, "docs")
+	if info.DocumentCount != len(docs) {
+		t.Errorf("Expected %d documents, got %d", len(docs), info.DocumentCount)
+	}
+}
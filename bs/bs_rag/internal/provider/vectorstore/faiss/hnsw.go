@@ -0,0 +1,254 @@
+package faiss
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// hnswIndex 分层可导航小世界图索引
+// 删除的节点保留在图中用于导航，只是不出现在结果里，由 collection 在删除比例过高时重建
+type hnswIndex struct {
+	store          vectorStore
+	m              int // 每层最大连接数，第0层为 2*m
+	efConstruction int
+	efSearch       int
+	levelMult      float64
+	rng            *rand.Rand
+
+	friends  [][][]uint32 // friends[节点][层] 邻居列表
+	entry    int64        // 入口节点，-1 表示空图
+	maxLevel int
+}
+
+// hnswSnapshot 图结构快照，与节点数据一起持久化，重启时无需重建
+type hnswSnapshot struct {
+	Friends  [][][]uint32
+	Entry    int64
+	MaxLevel int
+}
+
+func newHNSWIndex(store vectorStore, m, efConstruction, efSearch int) *hnswIndex {
+	return &hnswIndex{
+		store:          store,
+		m:              m,
+		efConstruction: efConstruction,
+		efSearch:       efSearch,
+		levelMult:      1 / math.Log(float64(m)),
+		rng:            rand.New(rand.NewSource(time.Now().UnixNano())),
+		entry:          -1,
+	}
+}
+
+func (h *hnswIndex) add(id uint32) {
+	level := h.randomLevel()
+	for len(h.friends) <= int(id) {
+		h.friends = append(h.friends, nil)
+	}
+	h.friends[id] = make([][]uint32, level+1)
+
+	if h.entry < 0 {
+		h.entry = int64(id)
+		h.maxLevel = level
+		return
+	}
+
+	query := h.store.vector(id)
+	ep := h.scoredNode(query, uint32(h.entry))
+	for l := h.maxLevel; l > level; l-- {
+		ep = h.greedy(query, ep, l)
+	}
+	for l := minInt(level, h.maxLevel); l >= 0; l-- {
+		// 构建时删除节点也参与连接，保证图的连通性
+		candidates := h.searchLayer(query, ep, h.efConstruction, l, nil)
+		neighbors := h.selectNeighbors(candidates, h.m)
+		h.friends[id][l] = nodeIDs(neighbors)
+		for _, n := range neighbors {
+			h.connect(n.id, id, l)
+		}
+		ep = candidates[0]
+	}
+	if level > h.maxLevel {
+		h.maxLevel = level
+		h.entry = int64(id)
+	}
+}
+
+// search 过滤条件下结果不足 k 个时改为暴力检索，保证选择性高的过滤条件也能召回
+func (h *hnswIndex) search(query []float32, k int, accept func(id uint32) bool) []scored {
+	if h.entry < 0 || k <= 0 {
+		return nil
+	}
+	ep := h.scoredNode(query, uint32(h.entry))
+	for l := h.maxLevel; l > 0; l-- {
+		ep = h.greedy(query, ep, l)
+	}
+	include := accept
+	if include == nil {
+		include = func(id uint32) bool { return true }
+	}
+	results := h.searchLayer(query, ep, maxInt(h.efSearch, k), 0, include)
+	if accept != nil && len(results) < k {
+		return bruteForce(h.store, query, k, accept)
+	}
+	if len(results) > k {
+		results = results[:k]
+	}
+	return results
+}
+
+func (h *hnswIndex) snapshot() *hnswSnapshot {
+	return &hnswSnapshot{Friends: h.friends, Entry: h.entry, MaxLevel: h.maxLevel}
+}
+
+func (h *hnswIndex) restore(s *hnswSnapshot) {
+	h.friends = s.Friends
+	h.entry = s.Entry
+	h.maxLevel = s.MaxLevel
+}
+
+// randomLevel 按指数衰减分布随机节点层数
+func (h *hnswIndex) randomLevel() int {
+	return int(math.Floor(-math.Log(1-h.rng.Float64()) * h.levelMult))
+}
+
+func (h *hnswIndex) maxConnections(level int) int {
+	if level == 0 {
+		return 2 * h.m
+	}
+	return h.m
+}
+
+func (h *hnswIndex) scoredNode(query []float32, id uint32) scored {
+	return scored{id: id, dist: h.store.distance(query, h.store.vector(id))}
+}
+
+// greedy 在单层上贪心地移动到离查询向量最近的节点
+func (h *hnswIndex) greedy(query []float32, ep scored, level int) scored {
+	for changed := true; changed; {
+		changed = false
+		for _, f := range h.neighbors(ep.id, level) {
+			if s := h.scoredNode(query, f); s.dist < ep.dist {
+				ep = s
+				changed = true
+			}
+		}
+	}
+	return ep
+}
+
+// searchLayer 在单层上做 ef 宽度的最佳优先搜索，返回按距离升序的结果
+// accept 不为 nil 时为检索，删除和不满足条件的节点只用于导航，不计入结果；为 nil 时为构建，全部节点计入结果
+func (h *hnswIndex) searchLayer(query []float32, ep scored, ef, level int, accept func(id uint32) bool) []scored {
+	include := func(id uint32) bool { return true }
+	if accept != nil {
+		include = func(id uint32) bool { return !h.store.deleted(id) && accept(id) }
+	}
+	visited := map[uint32]struct{}{ep.id: {}}
+	candidates := &minHeap{ep}
+	results := &maxHeap{}
+	if include(ep.id) {
+		heap.Push(results, ep)
+	}
+
+	for candidates.Len() > 0 {
+		c := heap.Pop(candidates).(scored)
+		if results.Len() >= ef && c.dist > (*results)[0].dist {
+			break
+		}
+		for _, f := range h.neighbors(c.id, level) {
+			if _, ok := visited[f]; ok {
+				continue
+			}
+			visited[f] = struct{}{}
+			s := h.scoredNode(query, f)
+			if results.Len() < ef || s.dist < (*results)[0].dist {
+				heap.Push(candidates, s)
+				if !include(f) {
+					continue
+				}
+				heap.Push(results, s)
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+	return sortedAsc(*results)
+}
+
+// selectNeighbors 启发式选择邻居：候选离已选邻居比离目标更近时跳过，保留不同方向的连接
+// 不足 m 个时用跳过的候选补齐
+func (h *hnswIndex) selectNeighbors(candidates []scored, m int) []scored {
+	if len(candidates) <= m {
+		return candidates
+	}
+	selected := make([]scored, 0, m)
+	var pruned []scored
+	for _, c := range candidates {
+		if len(selected) >= m {
+			break
+		}
+		good := true
+		for _, s := range selected {
+			if h.store.distance(h.store.vector(c.id), h.store.vector(s.id)) < c.dist {
+				good = false
+				break
+			}
+		}
+		if good {
+			selected = append(selected, c)
+		} else {
+			pruned = append(pruned, c)
+		}
+	}
+	for i := 0; len(selected) < m && i < len(pruned); i++ {
+		selected = append(selected, pruned[i])
+	}
+	return selected
+}
+
+// connect 添加 from -> to 的连接，超过最大连接数时重新选择邻居
+func (h *hnswIndex) connect(from, to uint32, level int) {
+	friends := append(h.friends[from][level], to)
+	if len(friends) <= h.maxConnections(level) {
+		h.friends[from][level] = friends
+		return
+	}
+	base := h.store.vector(from)
+	candidates := make([]scored, len(friends))
+	for i, f := range friends {
+		candidates[i] = scored{id: f, dist: h.store.distance(base, h.store.vector(f))}
+	}
+	h.friends[from][level] = nodeIDs(h.selectNeighbors(sortedAsc(candidates), h.maxConnections(level)))
+}
+
+func (h *hnswIndex) neighbors(id uint32, level int) []uint32 {
+	if level >= len(h.friends[id]) {
+		return nil
+	}
+	return h.friends[id][level]
+}
+
+func nodeIDs(items []scored) []uint32 {
+	ids := make([]uint32, len(items))
+	for i, item := range items {
+		ids[i] = item.id
+	}
+	return ids
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
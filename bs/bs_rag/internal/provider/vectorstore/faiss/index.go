@@ -0,0 +1,174 @@
+package faiss
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+	"strings"
+)
+
+// 索引类型
+const (
+	IndexTypeFlat = "Flat" // 精确检索，逐条计算距离
+	IndexTypeHNSW = "HNSW" // 近似检索，分层可导航小世界图
+)
+
+// 距离度量类型
+const (
+	MetricL2     = "L2"     // 欧氏距离，score = 1 / (1 + 距离)
+	MetricIP     = "IP"     // 内积，score = 内积
+	MetricCosine = "COSINE" // 余弦相似度，向量写入时归一化，score = 余弦相似度
+)
+
+// parseIndexType 规范化索引类型，不区分大小写
+func parseIndexType(indexType string) (string, bool) {
+	switch strings.ToUpper(indexType) {
+	case "FLAT":
+		return IndexTypeFlat, true
+	case "HNSW":
+		return IndexTypeHNSW, true
+	}
+	return "", false
+}
+
+// parseMetric 规范化距离度量类型，不区分大小写
+func parseMetric(metric string) (string, bool) {
+	switch strings.ToUpper(metric) {
+	case MetricL2:
+		return MetricL2, true
+	case MetricIP:
+		return MetricIP, true
+	case MetricCosine:
+		return MetricCosine, true
+	}
+	return "", false
+}
+
+// vectorStore 索引访问向量的接口，节点ID为写入顺序
+type vectorStore interface {
+	vector(id uint32) []float32
+	deleted(id uint32) bool
+	size() int
+	distance(a, b []float32) float32
+}
+
+// vectorIndex 向量索引，调用方负责并发控制
+type vectorIndex interface {
+	// add 将已写入 vectorStore 的节点加入索引
+	add(id uint32)
+	// search 返回距离最近的 k 个未删除且满足 accept 的节点，按距离升序；accept 为 nil 时不过滤
+	search(query []float32, k int, accept func(id uint32) bool) []scored
+}
+
+// scored 节点及其与查询向量的距离，距离越小越相似
+type scored struct {
+	id   uint32
+	dist float32
+}
+
+// distance 按度量类型计算距离，越小越相似；IP 和 COSINE 为内积的相反数
+func distance(metric string, a, b []float32) float32 {
+	if metric == MetricL2 {
+		var sum float32
+		for i := range a {
+			d := a[i] - b[i]
+			sum += d * d
+		}
+		return sum
+	}
+	return -dot(a, b)
+}
+
+// score 将距离转换为相似度分数，越大越相似
+func score(metric string, dist float32) float32 {
+	if metric == MetricL2 {
+		return 1 / (1 + float32(math.Sqrt(float64(dist))))
+	}
+	return -dist
+}
+
+func dot(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
+
+// normalize 返回归一化后的向量副本，零向量原样返回
+func normalize(v []float32) []float32 {
+	norm := math.Sqrt(float64(dot(v, v)))
+	out := make([]float32, len(v))
+	if norm == 0 {
+		copy(out, v)
+		return out
+	}
+	for i, x := range v {
+		out[i] = float32(float64(x) / norm)
+	}
+	return out
+}
+
+// flatIndex 精确检索索引，不维护额外结构
+type flatIndex struct {
+	store vectorStore
+}
+
+func (f *flatIndex) add(id uint32) {}
+
+func (f *flatIndex) search(query []float32, k int, accept func(id uint32) bool) []scored {
+	return bruteForce(f.store, query, k, accept)
+}
+
+// bruteForce 逐条计算距离，返回最近的 k 个节点
+func bruteForce(store vectorStore, query []float32, k int, accept func(id uint32) bool) []scored {
+	results := &maxHeap{}
+	for i := 0; i < store.size(); i++ {
+		id := uint32(i)
+		if store.deleted(id) || (accept != nil && !accept(id)) {
+			continue
+		}
+		d := store.distance(query, store.vector(id))
+		if results.Len() < k {
+			heap.Push(results, scored{id: id, dist: d})
+		} else if d < (*results)[0].dist {
+			(*results)[0] = scored{id: id, dist: d}
+			heap.Fix(results, 0)
+		}
+	}
+	return sortedAsc(*results)
+}
+
+// sortedAsc 按距离升序排序
+func sortedAsc(items []scored) []scored {
+	sort.Slice(items, func(i, j int) bool { return items[i].dist < items[j].dist })
+	return items
+}
+
+// minHeap 距离最小的在堆顶
+type minHeap []scored
+
+func (h minHeap) Len() int            { return len(h) }
+func (h minHeap) Less(i, j int) bool  { return h[i].dist < h[j].dist }
+func (h minHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *minHeap) Push(x interface{}) { *h = append(*h, x.(scored)) }
+func (h *minHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// maxHeap 距离最大的在堆顶
+type maxHeap []scored
+
+func (h maxHeap) Len() int            { return len(h) }
+func (h maxHeap) Less(i, j int) bool  { return h[i].dist > h[j].dist }
+func (h maxHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *maxHeap) Push(x interface{}) { *h = append(*h, x.(scored)) }
+func (h *maxHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
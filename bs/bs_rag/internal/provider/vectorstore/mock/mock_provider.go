@@ -32,13 +32,14 @@ func NewMockProvider() *MockProvider {
     return &MockProvider{collections: make(map[string]*MockCollection)}
 }
 
-func (p *MockProvider) Search(ctx context.Context, collectionName string, queryVector []float32, topK int, minScore float32) ([]types.SearchResult, error) {
+func (p *MockProvider) Search(ctx context.Context, collectionName string, queryVector []float32, topK int, minScore float32, filter *types.Filter) ([]types.SearchResult, error) {
     p.mutex.RLock(); defer p.mutex.RUnlock()
     c, ok := p.collections[collectionName]
     if !ok { return nil, types.ErrCollectionNotFound }
     results := make([]types.SearchResult, 0)
     for _, d := range c.Documents {
         if len(results) >= topK { break }
+        if !filter.Match(d.Metadata) { continue }
         score := float32(0.8)
         if score >= minScore { results = append(results, types.SearchResult{ID: d.ID, Score: score, Vector: d.Vector, Metadata: d.Metadata, Content: d.Content}) }
     }
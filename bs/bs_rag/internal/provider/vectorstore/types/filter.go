@@ -0,0 +1,191 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FilterOp 过滤操作符
+type FilterOp string
+
+const (
+	FilterOpEq  FilterOp = "eq"  // 等于
+	FilterOpIn  FilterOp = "in"  // 属于 Values 之一
+	FilterOpGt  FilterOp = "gt"  // 大于
+	FilterOpGte FilterOp = "gte" // 大于等于
+	FilterOpLt  FilterOp = "lt"  // 小于
+	FilterOpLte FilterOp = "lte" // 小于等于
+	FilterOpAnd FilterOp = "and" // Children 全部满足
+	FilterOpOr  FilterOp = "or"  // Children 任一满足
+)
+
+// fieldNamePattern 元数据字段名，限制字符集以便安全地翻译为各向量数据库的过滤语法
+var fieldNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// numericPattern 按数值比较的十进制数，不包括 NaN、Inf 和十六进制浮点数
+var numericPattern = regexp.MustCompile(`^-?\d+(\.\d+)?([eE][-+]?\d+)?$`)
+
+// Filter 元数据过滤表达式，nil 表示不过滤
+// 范围比较时两侧都能解析为数字则按数值比较，否则按字符串比较；文档缺少该字段时不满足条件
+type Filter struct {
+	Op       FilterOp
+	Field    string
+	Value    string
+	Values   []string
+	Children []*Filter
+}
+
+// Eq 字段等于 value
+func Eq(field, value string) *Filter {
+	return &Filter{Op: FilterOpEq, Field: field, Value: value}
+}
+
+// In 字段属于 values 之一
+func In(field string, values ...string) *Filter {
+	return &Filter{Op: FilterOpIn, Field: field, Values: values}
+}
+
+// And 组合多个条件，忽略 nil；没有条件时返回 nil，只有一个时直接返回该条件
+func And(filters ...*Filter) *Filter {
+	return combine(FilterOpAnd, filters)
+}
+
+// Or 任一条件满足，规则同 And
+func Or(filters ...*Filter) *Filter {
+	return combine(FilterOpOr, filters)
+}
+
+func combine(op FilterOp, filters []*Filter) *Filter {
+	children := make([]*Filter, 0, len(filters))
+	for _, f := range filters {
+		if f != nil {
+			children = append(children, f)
+		}
+	}
+	switch len(children) {
+	case 0:
+		return nil
+	case 1:
+		return children[0]
+	}
+	return &Filter{Op: op, Children: children}
+}
+
+// Validate 校验操作符、字段名和操作数
+func (f *Filter) Validate() error {
+	if f == nil {
+		return nil
+	}
+	switch f.Op {
+	case FilterOpAnd, FilterOpOr:
+		if len(f.Children) == 0 {
+			return fmt.Errorf("%s filter requires children", f.Op)
+		}
+		for _, child := range f.Children {
+			if child == nil {
+				return fmt.Errorf("%s filter has nil child", f.Op)
+			}
+			if err := child.Validate(); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FilterOpEq, FilterOpIn, FilterOpGt, FilterOpGte, FilterOpLt, FilterOpLte:
+		if !fieldNamePattern.MatchString(f.Field) {
+			return fmt.Errorf("invalid filter field %q", f.Field)
+		}
+		if f.Op == FilterOpIn && len(f.Values) == 0 {
+			return fmt.Errorf("in filter on %s requires values", f.Field)
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported filter op %q", f.Op)
+}
+
+// Match 判断文档元数据是否满足过滤条件
+func (f *Filter) Match(metadata map[string]string) bool {
+	if f == nil {
+		return true
+	}
+	switch f.Op {
+	case FilterOpAnd:
+		for _, child := range f.Children {
+			if !child.Match(metadata) {
+				return false
+			}
+		}
+		return true
+	case FilterOpOr:
+		for _, child := range f.Children {
+			if child.Match(metadata) {
+				return true
+			}
+		}
+		return false
+	}
+
+	value, ok := metadata[f.Field]
+	if !ok {
+		return false
+	}
+	switch f.Op {
+	case FilterOpEq:
+		return value == f.Value
+	case FilterOpIn:
+		for _, v := range f.Values {
+			if value == v {
+				return true
+			}
+		}
+		return false
+	case FilterOpGt:
+		return compareValues(value, f.Value) > 0
+	case FilterOpGte:
+		return compareValues(value, f.Value) >= 0
+	case FilterOpLt:
+		return compareValues(value, f.Value) < 0
+	case FilterOpLte:
+		return compareValues(value, f.Value) <= 0
+	}
+	return false
+}
+
+// String 返回便于日志输出的表达式
+func (f *Filter) String() string {
+	if f == nil {
+		return ""
+	}
+	switch f.Op {
+	case FilterOpAnd, FilterOpOr:
+		parts := make([]string, len(f.Children))
+		for i, child := range f.Children {
+			parts[i] = child.String()
+		}
+		return "(" + strings.Join(parts, " "+string(f.Op)+" ") + ")"
+	case FilterOpIn:
+		return fmt.Sprintf("%s in %q", f.Field, f.Values)
+	}
+	return fmt.Sprintf("%s %s %q", f.Field, f.Op, f.Value)
+}
+
+// IsNumeric 判断过滤值是否按数值比较
+func IsNumeric(value string) bool {
+	return numericPattern.MatchString(value)
+}
+
+func compareValues(a, b string) int {
+	if IsNumeric(a) && IsNumeric(b) {
+		x, _ := strconv.ParseFloat(a, 64)
+		y, _ := strconv.ParseFloat(b, 64)
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
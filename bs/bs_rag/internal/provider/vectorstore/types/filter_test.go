@@ -0,0 +1,77 @@
+package types
+
+import "testing"
+
+func TestFilterMatch(t *testing.T) {
+	metadata := map[string]string{"user_id": "u1", "type": "doc", "size": "120", "date": "2024-05-01"}
+	cases := []struct {
+		filter *Filter
+		match  bool
+	}{
+		{nil, true},
+		{Eq("user_id", "u1"), true},
+		{Eq("user_id", "u2"), false},
+		{Eq("missing", ""), false},
+		{In("type", "pdf", "doc"), true},
+		{In("type", "pdf"), false},
+		{&Filter{Op: FilterOpGt, Field: "size", Value: "99"}, true}, // 数值比较，字符串比较时 "120" < "99"
+		{&Filter{Op: FilterOpLte, Field: "size", Value: "120"}, true},
+		{&Filter{Op: FilterOpLt, Field: "size", Value: "120"}, false},
+		{&Filter{Op: FilterOpGte, Field: "date", Value: "2024-01-01"}, true},
+		{And(Eq("user_id", "u1"), In("type", "pdf")), false},
+		{Or(Eq("user_id", "u2"), In("type", "doc")), true},
+		{And(Eq("user_id", "u1"), Or(Eq("type", "pdf"), &Filter{Op: FilterOpGt, Field: "size", Value: "100"})), true},
+	}
+	for i, tc := range cases {
+		if err := tc.filter.Validate(); err != nil {
+			t.Fatalf("case %d: unexpected validation error: %v", i, err)
+		}
+		if got := tc.filter.Match(metadata); got != tc.match {
+			t.Errorf("case %d: %s expected %v, got %v", i, tc.filter, tc.match, got)
+		}
+	}
+}
+
+func TestIsNumeric(t *testing.T) {
+	for _, v := range []string{"0", "-12", "1.5", "2e3", "1.5E-2"} {
+		if !IsNumeric(v) {
+			t.Errorf("Expected %q to be numeric", v)
+		}
+	}
+	for _, v := range []string{"", "NaN", "nan", "Inf", "-Inf", "infinity", "0x1p3", "0x10", "1_000", "+1", ".5", "1.", " 1", "2024-01-01"} {
+		if IsNumeric(v) {
+			t.Errorf("Expected %q not to be numeric", v)
+		}
+	}
+
+	// 非十进制数按字符串比较
+	metadata := map[string]string{"score": "NaN", "tag": "0x1p3"}
+	if !(&Filter{Op: FilterOpGt, Field: "score", Value: "Inf"}).Match(metadata) {
+		t.Error(`Expected "NaN" > "Inf" in string comparison`)
+	}
+	if !(&Filter{Op: FilterOpLt, Field: "tag", Value: "5"}).Match(metadata) {
+		t.Error(`Expected "0x1p3" < "5" in string comparison`)
+	}
+}
+
+func TestFilterValidate(t *testing.T) {
+	invalid := []*Filter{
+		{Op: "like", Field: "a", Value: "b"},
+		Eq("user_id = 'x' or 1", "1"),
+		In("type"),
+		{Op: FilterOpAnd},
+		{Op: FilterOpOr, Children: []*Filter{Eq("a", "1"), {Op: FilterOpEq}}},
+	}
+	for i, f := range invalid {
+		if err := f.Validate(); err == nil {
+			t.Errorf("case %d: expected validation error for %s", i, f)
+		}
+	}
+
+	if And() != nil || And(nil, nil) != nil {
+		t.Error("Expected nil for empty And")
+	}
+	if f := Eq("a", "1"); And(nil, f) != f {
+		t.Error("Expected single condition to be returned as is")
+	}
+}
@@ -6,7 +6,8 @@ import (
 
 // VectorProvider 向量数据库提供者接口
 type VectorProvider interface {
-    Search(ctx context.Context, collectionName string, queryVector []float32, topK int, minScore float32) ([]SearchResult, error)
+    // Search 检索最相似的 topK 个文档，filter 不为 nil 时只返回元数据满足条件的文档
+    Search(ctx context.Context, collectionName string, queryVector []float32, topK int, minScore float32, filter *Filter) ([]SearchResult, error)
     Insert(ctx context.Context, collectionName string, documents []Document) error
     Delete(ctx context.Context, collectionName string, documentIDs []string) error
     GetCollectionInfo(ctx context.Context, collectionName string) (*CollectionInfo, error)
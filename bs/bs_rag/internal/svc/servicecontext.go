@@ -3,21 +3,29 @@ package svc
 import (
 	"context"
 	"fmt"
+	"jxzy/bs/bs_llm/bsllmservice"
 	"jxzy/bs/bs_rag/internal/config"
 	"jxzy/bs/bs_rag/internal/model"
 	efactory "jxzy/bs/bs_rag/internal/provider/embedding/factory"
 	etypes "jxzy/bs/bs_rag/internal/provider/embedding/types"
+	"jxzy/bs/bs_rag/internal/provider/keyword"
+	rfactory "jxzy/bs/bs_rag/internal/provider/rerank/factory"
+	rtypes "jxzy/bs/bs_rag/internal/provider/rerank/types"
 	vfactory "jxzy/bs/bs_rag/internal/provider/vectorstore/factory"
 	vtypes "jxzy/bs/bs_rag/internal/provider/vectorstore/types"
 
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/zeromicro/go-zero/core/logx"
 	"github.com/zeromicro/go-zero/core/stores/sqlx"
+	"github.com/zeromicro/go-zero/zrpc"
 )
 
 type ServiceContext struct {
 	Config              config.Config
 	VectorProvider      vtypes.VectorProvider
 	EmbeddingSceneModel model.EmbeddingSceneModel
+	LlmRpc              bsllmservice.BsLlmService // 未配置 BsLlmRpc 时为 nil
+	KeywordStore        *keyword.Store            // 未启用 Keyword 时为 nil
 }
 
 func NewServiceContext(c config.Config) *ServiceContext {
@@ -51,10 +59,31 @@ func NewServiceContext(c config.Config) *ServiceContext {
 		embeddingSceneModel = model.NewEmbeddingSceneModel(conn)
 	}
 
+	// 初始化LLM RPC客户端
+	var llmRpc bsllmservice.BsLlmService
+	if c.BsLlmRpc.Target != "" || len(c.BsLlmRpc.Endpoints) > 0 {
+		client, err := zrpc.NewClient(c.BsLlmRpc)
+		if err == nil {
+			llmRpc = bsllmservice.NewBsLlmService(client)
+		} else {
+			logx.Errorf("Failed to connect to LLM RPC: %v", err)
+		}
+	}
+
+	var keywordStore *keyword.Store
+	if c.Keyword.Enabled {
+		keywordStore, err = keyword.NewStore(c.Keyword)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to create keyword store: %v", err))
+		}
+	}
+
 	return &ServiceContext{
 		Config:              c,
 		VectorProvider:      vectorProvider,
 		EmbeddingSceneModel: embeddingSceneModel,
+		LlmRpc:              llmRpc,
+		KeywordStore:        keywordStore,
 	}
 }
 
@@ -66,16 +95,32 @@ func (s *ServiceContext) GetEmbeddingProvider(ctx context.Context, sceneCode str
 		return nil, 0, fmt.Errorf("failed to find embedding scene by scene_code %s: %w", sceneCode, err)
 	}
 
+	// 创建embedding provider
+	eFactory := &efactory.EmbeddingProviderFactory{}
+	var embeddingProvider etypes.EmbeddingProvider
+
+	// bs_llm 统一管理供应商凭证，不需要本地的provider配置，provider_code 为空时默认使用 bs_llm
+	if scene.ProviderCode == "" || scene.ProviderCode == string(etypes.EmbeddingProviderTypeBsLlm) {
+		if s.LlmRpc == nil {
+			return nil, 0, fmt.Errorf("BsLlmRpc is not configured for embedding scene %s", scene.SceneCode)
+		}
+		embeddingProvider, err = eFactory.NewProvider(etypes.EmbeddingProviderTypeBsLlm, efactory.BsLlmProviderConfig{
+			Client:          s.LlmRpc,
+			SceneCode:       scene.SceneCode,
+			VectorDimension: scene.VectorDimension,
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to create embedding provider: %w", err)
+		}
+		return embeddingProvider, scene.VectorDimension, nil
+	}
+
 	// 从配置中获取provider配置
 	providerConfig, ok := s.Config.EmbeddingProviders[scene.ProviderCode]
 	if !ok {
 		return nil, 0, fmt.Errorf("provider_code %s not found in config", scene.ProviderCode)
 	}
 
-	// 创建embedding provider
-	eFactory := &efactory.EmbeddingProviderFactory{}
-	var embeddingProvider etypes.EmbeddingProvider
-
 	switch scene.ProviderCode {
 	case "bailian":
 		embeddingProvider, err = eFactory.NewProvider(etypes.EmbeddingProviderTypeBailian, efactory.BailianProviderConfig{
@@ -108,3 +153,39 @@ func (s *ServiceContext) GetCollectionName(ctx context.Context, sceneCode string
 
 	return scene.CollectionName, nil
 }
+
+// defaultRerankFactor rerank_factor 未配置时的候选倍数
+const defaultRerankFactor = 3
+
+// GetReranker 根据scene_code获取重排序实现及候选倍数，场景未配置 rerank_provider 时返回 nil
+func (s *ServiceContext) GetReranker(ctx context.Context, sceneCode string) (rtypes.Reranker, int, error) {
+	scene, err := s.EmbeddingSceneModel.FindOneBySceneCode(ctx, sceneCode)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find embedding scene by scene_code %s: %w", sceneCode, err)
+	}
+	if scene.RerankProvider == "" || scene.RerankProvider == "none" {
+		return nil, 0, nil
+	}
+
+	factor := int(scene.RerankFactor)
+	if factor <= 0 {
+		factor = defaultRerankFactor
+	}
+
+	rFactory := &rfactory.RerankerFactory{}
+	var reranker rtypes.Reranker
+	switch rtypes.RerankerType(scene.RerankProvider) {
+	case rtypes.RerankerTypeBailian:
+		// 与百炼embedding共用API密钥，未配置时读取环境变量 BAILIAN_API_KEY
+		reranker, err = rFactory.NewReranker(rtypes.RerankerTypeBailian, rfactory.BailianRerankerConfig{
+			APIKey:    s.Config.EmbeddingProviders["bailian"].APIKey,
+			ModelCode: scene.RerankModel,
+		})
+	default:
+		reranker, err = rFactory.NewReranker(rtypes.RerankerType(scene.RerankProvider), nil)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create reranker for scene_code %s: %w", sceneCode, err)
+	}
+	return reranker, factor, nil
+}
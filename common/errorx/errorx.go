@@ -36,6 +36,9 @@ const (
 	ErrCodeLLMQuotaExceeded = 13002
 	ErrCodeLLMRequestFailed = 13003
 	ErrCodeLLMTimeout       = 13004
+	ErrCodeLLMSceneNotFound = 13005
+	ErrCodeLLMSceneExists   = 13006
+	ErrCodeLLMInvalidOutput = 13007
 
 	// RAG错误 (14000-14999)
 	ErrCodeDocumentNotFound      = 14001
@@ -96,6 +99,9 @@ var (
 	ErrLLMQuotaExceeded = &CodeError{Code: ErrCodeLLMQuotaExceeded, Msg: "LLM配额已用尽"}
 	ErrLLMRequestFailed = &CodeError{Code: ErrCodeLLMRequestFailed, Msg: "LLM请求失败"}
 	ErrLLMTimeout       = &CodeError{Code: ErrCodeLLMTimeout, Msg: "LLM请求超时"}
+	ErrLLMSceneNotFound = &CodeError{Code: ErrCodeLLMSceneNotFound, Msg: "LLM场景不存在"}
+	ErrLLMSceneExists   = &CodeError{Code: ErrCodeLLMSceneExists, Msg: "LLM场景已存在"}
+	ErrLLMInvalidOutput = &CodeError{Code: ErrCodeLLMInvalidOutput, Msg: "LLM输出不符合格式要求"}
 
 	// RAG相关错误
 	ErrDocumentNotFound      = &CodeError{Code: ErrCodeDocumentNotFound, Msg: "文档不存在"}
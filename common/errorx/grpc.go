@@ -0,0 +1,83 @@
+package errorx
+
+import (
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain gRPC错误详情中的业务错误域
+const ErrorDomain = "jxzy"
+
+// GRPCStatus 将业务错误转换为 gRPC status
+// 实现了该方法的错误可直接作为 RPC 方法的返回值，业务错误码通过 ErrorInfo 详情透传给调用方
+func (e *CodeError) GRPCStatus() *status.Status {
+	st := status.New(grpcCode(e.Code), e.Msg)
+	code := strconv.Itoa(e.Code)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   code,
+		Domain:   ErrorDomain,
+		Metadata: map[string]string{"code": code},
+	})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
+
+// FromError 从 gRPC 错误中解析业务错误，非业务错误返回 false
+func FromError(err error) (*CodeError, bool) {
+	if err == nil {
+		return nil, false
+	}
+	if codeErr, ok := err.(*CodeError); ok {
+		return codeErr, true
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil, false
+	}
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.Domain != ErrorDomain {
+			continue
+		}
+		code, convErr := strconv.Atoi(info.Metadata["code"])
+		if convErr != nil {
+			continue
+		}
+		return &CodeError{Code: code, Msg: st.Message()}, true
+	}
+	return nil, false
+}
+
+// grpcCode 业务错误码对应的 gRPC 状态码
+func grpcCode(code int) codes.Code {
+	switch code {
+	case ErrCodeSuccess:
+		return codes.OK
+	case ErrCodeParamError, ErrCodePromptInvalid:
+		return codes.InvalidArgument
+	case ErrCodeUnauthorized, ErrCodeTokenExpired, ErrCodeTokenInvalid:
+		return codes.Unauthenticated
+	case ErrCodeForbidden, ErrCodeContextNotBelongTo, ErrCodePromptNotBelongTo:
+		return codes.PermissionDenied
+	case ErrCodeNotFound, ErrCodeUserNotFound, ErrCodeContextNotFound, ErrCodePromptNotFound,
+		ErrCodeDocumentNotFound, ErrCodeCollectionNotFound, ErrCodeLLMSceneNotFound:
+		return codes.NotFound
+	case ErrCodeUserExists, ErrCodeContextExists, ErrCodePromptExists, ErrCodeLLMSceneExists:
+		return codes.AlreadyExists
+	case ErrCodeLLMQuotaExceeded:
+		return codes.ResourceExhausted
+	case ErrCodeLLMNotAvailable:
+		return codes.Unavailable
+	case ErrCodeLLMTimeout:
+		return codes.DeadlineExceeded
+	case ErrCodeLLMInvalidOutput:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
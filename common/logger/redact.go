@@ -0,0 +1,189 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+)
+
+// 内置脱敏规则名称
+const (
+	RedactIdCard = "id_card"
+	RedactPhone  = "phone"
+	RedactEmail  = "email"
+	RedactApiKey = "api_key"
+)
+
+// RedactConf 脱敏配置
+type RedactConf struct {
+	Disabled bool             `json:",optional"` // 关闭脱敏
+	Builtin  []string         `json:",optional"` // 启用的内置规则，为空时启用全部：id_card、phone、email、api_key
+	Rules    []RedactRuleConf `json:",optional"` // 自定义正则规则，在内置规则之后执行
+}
+
+// RedactRuleConf 自定义脱敏规则
+type RedactRuleConf struct {
+	Name        string
+	Pattern     string
+	Replacement string `json:",default=[REDACTED]"`
+}
+
+// redactRule 脱敏规则，Replacement 支持 $1 等分组引用
+type redactRule struct {
+	name        string
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// 内置规则按顺序执行：身份证号须先于手机号，避免18位号码中的11位被当作手机号
+var builtinRedactRules = []*redactRule{
+	{RedactIdCard, regexp.MustCompile(`\b[1-9]\d{5}(?:18|19|20)\d{2}(?:0[1-9]|1[0-2])(?:0[1-9]|[12]\d|3[01])\d{3}[\dXx]\b`), "[ID_CARD]"},
+	{RedactPhone, regexp.MustCompile(`(?:\+?86[- ]?|\b)1[3-9]\d{9}\b`), "[PHONE]"},
+	{RedactEmail, regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`), "[EMAIL]"},
+	{RedactApiKey, regexp.MustCompile(`\b(?:sk|ak|pk)-[A-Za-z0-9_-]{16,}`), "[API_KEY]"},
+	{RedactApiKey, regexp.MustCompile(`(?i)\b(Bearer\s+)[A-Za-z0-9._~+/=-]{16,}`), "${1}[API_KEY]"},
+	{RedactApiKey, regexp.MustCompile(`(?i)\b((?:api[_-]?key|secret[_-]?key|access[_-]?token|secret|password)["']?\s*[:=]\s*["']?)[^\s"',;]{6,}`), "${1}[API_KEY]"},
+}
+
+// Redactor 文本脱敏器，依次执行内置规则和自定义规则
+// nil Redactor 不做任何处理，可直接调用
+type Redactor struct {
+	rules []*redactRule
+}
+
+// NewRedactor 按配置创建脱敏器，配置关闭时返回nil
+func NewRedactor(c RedactConf) (*Redactor, error) {
+	if c.Disabled {
+		return nil, nil
+	}
+
+	enabled := make(map[string]bool, len(c.Builtin))
+	for _, name := range c.Builtin {
+		switch name {
+		case RedactIdCard, RedactPhone, RedactEmail, RedactApiKey:
+			enabled[name] = true
+		default:
+			return nil, fmt.Errorf("unknown builtin redact rule %q", name)
+		}
+	}
+	r := &Redactor{}
+	for _, rule := range builtinRedactRules {
+		if len(enabled) == 0 || enabled[rule.name] {
+			r.rules = append(r.rules, rule)
+		}
+	}
+	return r.With(c.Rules...)
+}
+
+// MustNewRedactor 按配置创建脱敏器，配置错误时 panic
+func MustNewRedactor(c RedactConf) *Redactor {
+	r, err := NewRedactor(c)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// With 返回追加了自定义规则的新脱敏器，原脱敏器不变
+func (r *Redactor) With(rules ...RedactRuleConf) (*Redactor, error) {
+	if len(rules) == 0 {
+		return r, nil
+	}
+	next := &Redactor{}
+	if r != nil {
+		next.rules = append(next.rules, r.rules...)
+	}
+	for _, rule := range rules {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact rule %q: %w", rule.Name, err)
+		}
+		replacement := rule.Replacement
+		if replacement == "" {
+			replacement = "[REDACTED]"
+		}
+		next.rules = append(next.rules, &redactRule{name: rule.Name, pattern: pattern, replacement: replacement})
+	}
+	return next, nil
+}
+
+// Redact 返回脱敏后的文本
+func (r *Redactor) Redact(s string) string {
+	if r == nil || s == "" {
+		return s
+	}
+	for _, rule := range r.rules {
+		s = rule.pattern.ReplaceAllString(s, rule.replacement)
+	}
+	return s
+}
+
+// WithRedactor 返回输出前对日志内容脱敏的日志记录器，r 为nil时原样返回
+// 内部日志记录器跳过脱敏包装的一层调用栈，日志中的 caller 仍为实际调用位置
+func WithRedactor(l logx.Logger, r *Redactor) logx.Logger {
+	if r == nil {
+		return l
+	}
+	return &redactLogger{inner: l.WithCallerSkip(1), redactor: r}
+}
+
+// redactLogger 对日志内容脱敏的日志记录器
+type redactLogger struct {
+	inner    logx.Logger
+	redactor *Redactor
+}
+
+func (l *redactLogger) sprint(v ...any) string {
+	return l.redactor.Redact(fmt.Sprint(v...))
+}
+
+func (l *redactLogger) sprintf(format string, v ...any) string {
+	return l.redactor.Redact(fmt.Sprintf(format, v...))
+}
+
+func (l *redactLogger) Debug(v ...any)                 { l.inner.Debug(l.sprint(v...)) }
+func (l *redactLogger) Debugf(format string, v ...any) { l.inner.Debug(l.sprintf(format, v...)) }
+func (l *redactLogger) Debugv(v any)                   { l.inner.Debug(l.sprint(v)) }
+func (l *redactLogger) Debugw(msg string, fields ...logx.LogField) {
+	l.inner.Debugw(l.redactor.Redact(msg), fields...)
+}
+
+func (l *redactLogger) Error(v ...any)                 { l.inner.Error(l.sprint(v...)) }
+func (l *redactLogger) Errorf(format string, v ...any) { l.inner.Error(l.sprintf(format, v...)) }
+func (l *redactLogger) Errorv(v any)                   { l.inner.Error(l.sprint(v)) }
+func (l *redactLogger) Errorw(msg string, fields ...logx.LogField) {
+	l.inner.Errorw(l.redactor.Redact(msg), fields...)
+}
+
+func (l *redactLogger) Info(v ...any)                 { l.inner.Info(l.sprint(v...)) }
+func (l *redactLogger) Infof(format string, v ...any) { l.inner.Info(l.sprintf(format, v...)) }
+func (l *redactLogger) Infov(v any)                   { l.inner.Info(l.sprint(v)) }
+func (l *redactLogger) Infow(msg string, fields ...logx.LogField) {
+	l.inner.Infow(l.redactor.Redact(msg), fields...)
+}
+
+func (l *redactLogger) Slow(v ...any)                 { l.inner.Slow(l.sprint(v...)) }
+func (l *redactLogger) Slowf(format string, v ...any) { l.inner.Slow(l.sprintf(format, v...)) }
+func (l *redactLogger) Slowv(v any)                   { l.inner.Slow(l.sprint(v)) }
+func (l *redactLogger) Sloww(msg string, fields ...logx.LogField) {
+	l.inner.Sloww(l.redactor.Redact(msg), fields...)
+}
+
+func (l *redactLogger) WithCallerSkip(skip int) logx.Logger {
+	return &redactLogger{inner: l.inner.WithCallerSkip(skip + 1), redactor: l.redactor}
+}
+
+func (l *redactLogger) WithContext(ctx context.Context) logx.Logger {
+	return &redactLogger{inner: l.inner.WithContext(ctx), redactor: l.redactor}
+}
+
+func (l *redactLogger) WithDuration(d time.Duration) logx.Logger {
+	return &redactLogger{inner: l.inner.WithDuration(d), redactor: l.redactor}
+}
+
+func (l *redactLogger) WithFields(fields ...logx.LogField) logx.Logger {
+	return &redactLogger{inner: l.inner.WithFields(fields...), redactor: l.redactor}
+}
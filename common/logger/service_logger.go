@@ -13,22 +13,28 @@ type ServiceLogger struct {
 	serviceName string
 	innerLogger logx.Logger
 	fields      []logx.LogField
+	ctx         context.Context
+	callerSkip  int
 }
 
 // NewServiceLogger 创建带服务名的日志记录器
 func NewServiceLogger(serviceName string) *ServiceLogger {
+	return newServiceLogger(serviceName, context.Background(), 0)
+}
+
+// newServiceLogger 创建服务日志记录器，内部日志记录器额外跳过 ServiceLogger 自身的一层调用栈
+func newServiceLogger(serviceName string, ctx context.Context, callerSkip int) *ServiceLogger {
 	return &ServiceLogger{
 		serviceName: serviceName,
-		innerLogger: logx.WithContext(context.Background()),
+		innerLogger: logx.WithContext(ctx).WithCallerSkip(callerSkip + 1),
+		ctx:         ctx,
+		callerSkip:  callerSkip,
 	}
 }
 
 // WithContext 创建带上下文的服务日志记录器
 func (s *ServiceLogger) WithContext(ctx context.Context) logx.Logger {
-	return &ServiceLogger{
-		serviceName: s.serviceName,
-		innerLogger: logx.WithContext(ctx),
-	}
+	return newServiceLogger(s.serviceName, ctx, s.callerSkip)
 }
 
 // 实现 logx.Logger 接口的所有方法
@@ -99,18 +105,14 @@ func (s *ServiceLogger) Sloww(msg string, fields ...logx.LogField) {
 
 // WithCallerSkip 返回带调用者跳过的日志记录器
 func (s *ServiceLogger) WithCallerSkip(skip int) logx.Logger {
-	return &ServiceLogger{
-		serviceName: s.serviceName,
-		innerLogger: logx.WithCallerSkip(skip),
-	}
+	return newServiceLogger(s.serviceName, s.ctx, skip)
 }
 
 // WithDuration 返回带持续时间的日志记录器
 func (s *ServiceLogger) WithDuration(d time.Duration) logx.Logger {
-	return &ServiceLogger{
-		serviceName: s.serviceName,
-		innerLogger: logx.WithDuration(d),
-	}
+	l := newServiceLogger(s.serviceName, s.ctx, s.callerSkip)
+	l.innerLogger = l.innerLogger.WithDuration(d)
+	return l
 }
 
 // WithFields 返回带字段的日志记录器